@@ -0,0 +1,79 @@
+// Command bankimport imports a bank statement file from the command line,
+// the same way the admin endpoint does:
+//
+//	bankimport -file ekstre.csv -date-column "İşlem Tarihi" -amount-column Tutar
+//	bankimport -file statement.xml
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"unicode/utf8"
+
+	bankstatement "github.com/pragmataW/apartment_management/pkg/bank_statement"
+	"github.com/pragmataW/apartment_management/repo"
+	"github.com/pragmataW/apartment_management/services"
+)
+
+func main() {
+	mapping := bankstatement.DefaultMapping()
+
+	file := flag.String("file", "", "statement file to import")
+	format := flag.String("format", "", "csv or camt053, taken from the file extension when empty")
+	importedBy := flag.String("by", "bankimport", "name recorded as the importer")
+	delimiter := flag.String("delimiter", string(mapping.Comma), "CSV field delimiter")
+	flag.StringVar(&mapping.Date, "date-column", mapping.Date, "CSV header of the booking date")
+	flag.StringVar(&mapping.Amount, "amount-column", mapping.Amount, "CSV header of the amount")
+	flag.StringVar(&mapping.Description, "description-column", mapping.Description, "CSV header of the description")
+	flag.StringVar(&mapping.Reference, "reference-column", mapping.Reference, "CSV header of the bank reference")
+	flag.StringVar(&mapping.Counterparty, "counterparty-column", mapping.Counterparty, "CSV header of the sender")
+	flag.StringVar(&mapping.DateLayout, "date-layout", mapping.DateLayout, "Go layout of the CSV dates")
+	flag.BoolVar(&mapping.DecimalComma, "decimal-comma", mapping.DecimalComma, "CSV amounts use a decimal comma")
+	flag.Parse()
+
+	if *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if utf8.RuneCountInString(*delimiter) != 1 {
+		log.Fatal("delimiter must be a single character")
+	}
+	mapping.Comma, _ = utf8.DecodeRuneInString(*delimiter)
+
+	content, err := os.Open(*file)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer content.Close()
+
+	port, _ := strconv.Atoi(os.Getenv("DB_PORT"))
+	repo := repo.NewRepo(
+		repo.NewDb(repo.DBConfig{
+			Host:     os.Getenv("DB_HOST"),
+			Port:     port,
+			User:     os.Getenv("POSTGRES_USER"),
+			Password: os.Getenv("POSTGRES_PASSWORD"),
+			DbName:   os.Getenv("POSTGRES_DB"),
+			SslMode:  os.Getenv("POSTGRES_SSL"),
+		}),
+	)
+	service := services.NewService(services.WithRepo(repo))
+
+	bankImport, err := service.ImportBankStatement(services.BankStatement{
+		FileName:   filepath.Base(*file),
+		Format:     *format,
+		Content:    content,
+		Mapping:    mapping,
+		ImportedBy: *importedBy,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("import %d: %d lines, %d matched, %d unmatched, %d duplicates, %d skipped\n",
+		bankImport.ID, bankImport.Lines, bankImport.Matched, bankImport.Unmatched, bankImport.Duplicates, bankImport.Skipped)
+}
@@ -1,28 +1,34 @@
 package main
 
 import (
-	"fmt"
 	"log"
 	"os"
+	"os/signal"
 	"strconv"
+	"syscall"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/pragmataW/apartment_management/controller"
 	configmanager "github.com/pragmataW/apartment_management/pkg/config_manager"
 	"github.com/pragmataW/apartment_management/pkg/encrypt"
+	filestore "github.com/pragmataW/apartment_management/pkg/file_store"
+	paymentprovider "github.com/pragmataW/apartment_management/pkg/payment_provider"
+	"github.com/pragmataW/apartment_management/pkg/scheduler"
 	"github.com/pragmataW/apartment_management/repo"
 	"github.com/pragmataW/apartment_management/services"
 )
 
 var (
-	host     string
-	port     int
-	user     string
-	password string
-	dbName   string
-	sslMode  string
-	chiper   string
-	jwtKey   string
+	host       string
+	port       int
+	user       string
+	password   string
+	dbName     string
+	sslMode    string
+	chiper     string
+	jwtKey     string
+	receiptDir string
 )
 
 func main() {
@@ -40,27 +46,63 @@ func main() {
 	)
 	encrypt := encrypt.NewEncryptor(chiper)
 	cfgManager := configmanager.NewConfigManager()
+
+	var paymentProvider services.IPaymentProvider
+	switch cfgManager.GetPaymentProvider() {
+	case paymentprovider.ProviderPaytr:
+		paymentProvider = paymentprovider.NewPaytr(paymentprovider.PaytrConfig{
+			MerchantID:   strconv.Itoa(cfgManager.GetMerchantID()),
+			MerchantKey:  cfgManager.GetMerchantKey(),
+			MerchantSalt: cfgManager.GetMerchantSalt(),
+			OkURL:        cfgManager.GetOkUrl(),
+			FailURL:      cfgManager.GetFailUrl(),
+		})
+	case paymentprovider.ProviderFake:
+		log.Println("using the fake payment provider, no real payments are taken")
+		paymentProvider = paymentprovider.NewFake(paymentprovider.FakeConfig{
+			Secret:      cfgManager.GetFakePaymentSecret(),
+			CallbackURL: cfgManager.GetFakePaymentCallbackUrl(),
+			Status:      cfgManager.GetFakePaymentStatus(),
+			Delay:       time.Second,
+		})
+	default:
+		log.Fatal("unknown payment provider " + cfgManager.GetPaymentProvider())
+	}
+
 	service := services.NewService(
 		services.WithConfigManager(cfgManager),
 		services.WithRepo(repo),
 		services.WithEncryptor(encrypt),
+		services.WithScheduler(scheduler.NewScheduler()),
+		services.WithReceiptStore(filestore.NewFileStore(receiptDir)),
+		services.WithPaymentProvider(paymentProvider),
 	)
 	ctrl := controller.NewController(
-		controller.WithConfigManager(cfgManager),
 		controller.WithService(service),
 	)
 
+	err := service.StartScheduledJobs()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	app := fiber.New()
+	ctrl.RegisterRoutes(app, jwtKey)
+
 	go func() {
-		err := service.IncreaseDuesAutomatically()
-		if err != nil {
-			fmt.Println(err)
-			log.Fatal(err)
+		quit := make(chan os.Signal, 1)
+		signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+		<-quit
+
+		service.StopScheduledJobs()
+		if err := app.Shutdown(); err != nil {
+			log.Println(err)
 		}
 	}()
 
-	app := fiber.New()
-	ctrl.RegisterRoutes(app, jwtKey)
-	log.Fatal(app.Listen(":2009"))
+	if err := app.Listen(":2009"); err != nil {
+		log.Fatal(err)
+	}
 }
 
 func init() {
@@ -73,4 +115,8 @@ func init() {
 	sslMode = os.Getenv("POSTGRES_SSL")
 	chiper = os.Getenv("CHIPER")
 	jwtKey = os.Getenv("JWT_KEY")
+	receiptDir = os.Getenv("RECEIPT_DIR")
+	if receiptDir == "" {
+		receiptDir = "receipts"
+	}
 }
@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/services"
+)
+
+func (ctrl *controller) CreateAssessment(c *fiber.Ctx) error {
+	var body dto.AssessmentReq
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "bad request " + err.Error(),
+		})
+	}
+
+	if err := validate.Struct(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	assessment := services.Assessment{
+		Name:         body.Name,
+		Description:  body.Description,
+		Total:        body.Total,
+		SplitRule:    body.SplitRule,
+		Installments: body.Installments,
+		CreatedBy:    actor(c),
+	}
+	if body.FirstDueDate != nil {
+		assessment.FirstDueDate = *body.FirstDueDate
+	}
+
+	created, err := ctrl.Service.CreateAssessment(assessment)
+	if err != nil {
+		if err, ok := err.(dto.AssessmentError); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toAssessmentResponse(created))
+}
+
+func (ctrl *controller) GetAssessments(c *fiber.Ctx) error {
+	assessments, err := ctrl.Service.GetAssessments()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	resp := []dto.AssessmentResponse{}
+	for _, assessment := range assessments {
+		resp = append(resp, toAssessmentResponse(assessment))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (ctrl *controller) GetAssessmentCharges(c *fiber.Ctx) error {
+	assessmentID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing query parameter: id",
+		})
+	}
+
+	charges, err := ctrl.Service.GetAssessmentCharges(assessmentID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	resp := []dto.AssessmentChargeResponse{}
+	for _, charge := range charges {
+		resp = append(resp, dto.AssessmentChargeResponse{
+			FlatNo:    charge.FlatNo,
+			Period:    charge.Period,
+			Amount:    charge.Amount,
+			Remaining: charge.Remaining,
+			Note:      charge.Note,
+			DueDate:   charge.DueDate,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func toAssessmentResponse(assessment services.Assessment) dto.AssessmentResponse {
+	return dto.AssessmentResponse{
+		ID:           assessment.ID,
+		Name:         assessment.Name,
+		Description:  assessment.Description,
+		Total:        assessment.Total,
+		SplitRule:    assessment.SplitRule,
+		Installments: assessment.Installments,
+		FirstDueDate: assessment.FirstDueDate,
+		CreatedBy:    assessment.CreatedBy,
+		CreatedAt:    assessment.CreatedAt,
+		Billed:       assessment.Billed,
+		Collected:    assessment.Collected,
+	}
+}
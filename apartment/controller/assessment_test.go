@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	mocks "github.com/pragmataW/apartment_management/controller_mocks"
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"github.com/pragmataW/apartment_management/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateAssessmentSuccess(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("CreateAssessment", mock.MatchedBy(func(assessment services.Assessment) bool {
+		return assessment.Name == "roof" && assessment.Total == money.New(1200000) && assessment.SplitRule == "share" &&
+			assessment.Installments == 4 && assessment.CreatedBy == "admin" && assessment.FirstDueDate.Month() == 11
+	})).Return(services.Assessment{ID: 1, Name: "roof", Total: money.New(1200000), SplitRule: "share", Installments: 4, Billed: money.New(1200000)}, nil)
+
+	app := fiber.New()
+	app.Post("/assessment", asAdmin, controller.CreateAssessment)
+
+	reqBody := `{"name":"roof","total":12000,"split_rule":"share","installments":4,"first_due_date":"2026-11-01T00:00:00Z"}`
+	req := httptest.NewRequest("POST", "/assessment", strings.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var respBody dto.AssessmentResponse
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, respBody.ID)
+	assert.Equal(t, money.New(1200000), respBody.Billed)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestCreateAssessmentBadRequest(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	app := fiber.New()
+	app.Post("/assessment", asAdmin, controller.CreateAssessment)
+
+	reqBody := `{"name":"roof","total":12000,"split_rule":"floor"}`
+	req := httptest.NewRequest("POST", "/assessment", strings.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockService.AssertNotCalled(t, "CreateAssessment", mock.Anything)
+}
+
+func TestCreateAssessmentSplitError(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("CreateAssessment", mock.Anything).Return(services.Assessment{}, dto.AssessmentError{Message: "there is nothing to split the amount by"})
+
+	app := fiber.New()
+	app.Post("/assessment", asAdmin, controller.CreateAssessment)
+
+	reqBody := `{"name":"roof","total":12000,"split_rule":"area"}`
+	req := httptest.NewRequest("POST", "/assessment", strings.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGetAssessmentChargesSuccess(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetAssessmentCharges", 3).Return([]services.AssessmentCharge{
+		{FlatNo: 1, Period: "2026-11", Amount: money.New(30000), Remaining: money.New(0)},
+		{FlatNo: 2, Period: "2026-11", Amount: money.New(30000), Remaining: money.New(30000)},
+	}, nil)
+
+	app := fiber.New()
+	app.Get("/assessment/:id/charges", controller.GetAssessmentCharges)
+
+	req := httptest.NewRequest("GET", "/assessment/3/charges", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var respBody []dto.AssessmentChargeResponse
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.NoError(t, err)
+	assert.Len(t, respBody, 2)
+	assert.Equal(t, money.New(30000), respBody[1].Remaining)
+}
+
+func TestGetAssessmentsError(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetAssessments").Return(nil, errors.New("some error"))
+
+	app := fiber.New()
+	app.Get("/assessment", controller.GetAssessments)
+
+	req := httptest.NewRequest("GET", "/assessment", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+}
@@ -0,0 +1,283 @@
+package controller
+
+import (
+	"errors"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pragmataW/apartment_management/dto"
+	bankstatement "github.com/pragmataW/apartment_management/pkg/bank_statement"
+	"github.com/pragmataW/apartment_management/services"
+)
+
+// ImportBankStatement takes the statement as the "statement" field of a
+// multipart form. The format field is csv or camt053 and defaults to the
+// file extension. A CSV layout other than the default is described with the
+// date_column, amount_column, description_column, reference_column,
+// counterparty_column, date_layout, delimiter and decimal_comma fields.
+func (ctrl *controller) ImportBankStatement(c *fiber.Ctx) error {
+	fileHeader, err := c.FormFile("statement")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing form file: statement",
+		})
+	}
+
+	mapping, err := csvMapping(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	defer file.Close()
+
+	bankImport, err := ctrl.Service.ImportBankStatement(services.BankStatement{
+		FileName:   fileHeader.Filename,
+		Format:     c.FormValue("format"),
+		Content:    file,
+		Mapping:    mapping,
+		ImportedBy: actor(c),
+	})
+	if err != nil {
+		return bankError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toBankImportResponse(bankImport))
+}
+
+func (ctrl *controller) GetBankImports(c *fiber.Ctx) error {
+	imports, err := ctrl.Service.GetBankImports()
+	if err != nil {
+		return bankError(c, err)
+	}
+
+	resp := []dto.BankImportResponse{}
+	for _, bankImport := range imports {
+		resp = append(resp, toBankImportResponse(bankImport))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// GetBankTransactions lists imported transactions by the status query
+// parameter; status=unmatched is the review queue.
+func (ctrl *controller) GetBankTransactions(c *fiber.Ctx) error {
+	transactions, err := ctrl.Service.GetBankTransactions(c.Query("status"))
+	if err != nil {
+		return bankError(c, err)
+	}
+
+	resp := []dto.BankTransactionResponse{}
+	for _, transaction := range transactions {
+		resp = append(resp, toBankTransactionResponse(transaction))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (ctrl *controller) MatchBankTransaction(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing query parameter: id",
+		})
+	}
+
+	var body dto.BankMatchReq
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "bad request " + err.Error(),
+		})
+	}
+
+	if err := validate.Struct(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	transaction, err := ctrl.Service.MatchBankTransaction(id, body.FlatNo, actor(c))
+	if err != nil {
+		return bankError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toBankTransactionResponse(transaction))
+}
+
+func (ctrl *controller) IgnoreBankTransaction(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing query parameter: id",
+		})
+	}
+
+	transaction, err := ctrl.Service.IgnoreBankTransaction(id, actor(c))
+	if err != nil {
+		return bankError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toBankTransactionResponse(transaction))
+}
+
+func (ctrl *controller) CreateReferenceRule(c *fiber.Ctx) error {
+	var body dto.ReferenceRuleReq
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "bad request " + err.Error(),
+		})
+	}
+
+	if err := validate.Struct(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	rule, err := ctrl.Service.CreateReferenceRule(services.ReferenceRule{
+		Name:      body.Name,
+		Pattern:   body.Pattern,
+		FlatNo:    body.FlatNo,
+		Priority:  body.Priority,
+		CreatedBy: actor(c),
+	})
+	if err != nil {
+		return bankError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toReferenceRuleResponse(rule))
+}
+
+func (ctrl *controller) GetReferenceRules(c *fiber.Ctx) error {
+	rules, err := ctrl.Service.GetReferenceRules()
+	if err != nil {
+		return bankError(c, err)
+	}
+
+	resp := []dto.ReferenceRuleResponse{}
+	for _, rule := range rules {
+		resp = append(resp, toReferenceRuleResponse(rule))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (ctrl *controller) DeleteReferenceRule(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing query parameter: id",
+		})
+	}
+
+	if err := ctrl.Service.DeleteReferenceRule(id); err != nil {
+		return bankError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "status ok",
+	})
+}
+
+// csvMapping starts from the default CSV layout and applies the fields the
+// form overrides.
+func csvMapping(c *fiber.Ctx) (bankstatement.Mapping, error) {
+	mapping := bankstatement.DefaultMapping()
+	for field, target := range map[string]*string{
+		"date_column":         &mapping.Date,
+		"amount_column":       &mapping.Amount,
+		"description_column":  &mapping.Description,
+		"reference_column":    &mapping.Reference,
+		"counterparty_column": &mapping.Counterparty,
+		"date_layout":         &mapping.DateLayout,
+	} {
+		if value := c.FormValue(field); value != "" {
+			*target = value
+		}
+	}
+
+	if delimiter := c.FormValue("delimiter"); delimiter != "" {
+		if utf8.RuneCountInString(delimiter) != 1 {
+			return bankstatement.Mapping{}, errors.New("delimiter must be a single character")
+		}
+		mapping.Comma, _ = utf8.DecodeRuneInString(delimiter)
+	}
+	if decimalComma := c.FormValue("decimal_comma"); decimalComma != "" {
+		value, err := strconv.ParseBool(decimalComma)
+		if err != nil {
+			return bankstatement.Mapping{}, errors.New("decimal_comma must be true or false")
+		}
+		mapping.DecimalComma = value
+	}
+	return mapping, nil
+}
+
+// bankError answers with 404 for a missing transaction or rule, 400 for a
+// rejected statement, rule or match and 500 otherwise.
+func bankError(c *fiber.Ctx, err error) error {
+	switch err := err.(type) {
+	case dto.ThereIsNoBankTransaction, dto.ThereIsNoReferenceRule:
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	case dto.BankImportError, dto.BankTransactionResolved, dto.ForeignCurrencyTransfer, dto.ThereIsNoFlat:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"message": err.Error(),
+	})
+}
+
+func toBankImportResponse(bankImport services.BankImport) dto.BankImportResponse {
+	return dto.BankImportResponse{
+		ID:         bankImport.ID,
+		FileName:   bankImport.FileName,
+		Format:     bankImport.Format,
+		Lines:      bankImport.Lines,
+		Matched:    bankImport.Matched,
+		Unmatched:  bankImport.Unmatched,
+		Duplicates: bankImport.Duplicates,
+		Skipped:    bankImport.Skipped,
+		ImportedBy: bankImport.ImportedBy,
+		ImportedAt: bankImport.ImportedAt,
+	}
+}
+
+func toBankTransactionResponse(transaction services.BankTransaction) dto.BankTransactionResponse {
+	return dto.BankTransactionResponse{
+		ID:           transaction.ID,
+		ImportID:     transaction.ImportID,
+		BankRef:      transaction.BankRef,
+		Date:         transaction.Date.Format(dateLayout),
+		Amount:       transaction.Amount,
+		Currency:     transaction.Currency,
+		Description:  transaction.Description,
+		Counterparty: transaction.Counterparty,
+		Status:       transaction.Status,
+		FlatNo:       transaction.FlatNo,
+		RuleID:       transaction.RuleID,
+		ResolvedBy:   transaction.ResolvedBy,
+		ResolvedAt:   transaction.ResolvedAt,
+	}
+}
+
+func toReferenceRuleResponse(rule services.ReferenceRule) dto.ReferenceRuleResponse {
+	return dto.ReferenceRuleResponse{
+		ID:        rule.ID,
+		Name:      rule.Name,
+		Pattern:   rule.Pattern,
+		FlatNo:    rule.FlatNo,
+		Priority:  rule.Priority,
+		CreatedBy: rule.CreatedBy,
+	}
+}
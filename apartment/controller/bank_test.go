@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	mocks "github.com/pragmataW/apartment_management/controller_mocks"
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"github.com/pragmataW/apartment_management/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestImportBankStatementSuccess(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("ImportBankStatement", mock.MatchedBy(func(statement services.BankStatement) bool {
+		content, _ := io.ReadAll(statement.Content)
+		return statement.FileName == "ekstre.csv" && statement.ImportedBy == "admin" &&
+			statement.Mapping.Date == "Tarih" && statement.Mapping.Comma == ',' && !statement.Mapping.DecimalComma &&
+			statement.Mapping.Amount == "amount" && string(content) == "Tarih,amount,description\n"
+	})).Return(services.BankImport{ID: 1, FileName: "ekstre.csv", Format: "csv", Lines: 4, Matched: 3, Unmatched: 1}, nil)
+
+	app := fiber.New()
+	app.Post("/bank/import", asAdmin, controller.ImportBankStatement)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("statement", "ekstre.csv")
+	assert.NoError(t, err)
+	part.Write([]byte("Tarih,amount,description\n"))
+	writer.WriteField("date_column", "Tarih")
+	writer.WriteField("delimiter", ",")
+	writer.WriteField("decimal_comma", "false")
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/bank/import", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var respBody dto.BankImportResponse
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, respBody.Matched)
+	assert.Equal(t, 1, respBody.Unmatched)
+}
+
+func TestImportBankStatementBadDelimiter(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	app := fiber.New()
+	app.Post("/bank/import", asAdmin, controller.ImportBankStatement)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("statement", "ekstre.csv")
+	assert.NoError(t, err)
+	part.Write([]byte("date;amount;description\n"))
+	writer.WriteField("delimiter", ";;")
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/bank/import", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockService.AssertNotCalled(t, "ImportBankStatement", mock.Anything)
+}
+
+func TestGetBankTransactionsQueue(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetBankTransactions", "unmatched").Return([]services.BankTransaction{
+		{ID: 5, BankRef: "B-2", Date: time.Date(2026, 10, 2, 0, 0, 0, 0, time.Local), Amount: money.New(4000), Description: "aidat", Status: "unmatched"},
+	}, nil)
+
+	app := fiber.New()
+	app.Get("/bank/transaction", controller.GetBankTransactions)
+
+	req := httptest.NewRequest("GET", "/bank/transaction?status=unmatched", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var respBody []dto.BankTransactionResponse
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.NoError(t, err)
+	assert.Len(t, respBody, 1)
+	assert.Equal(t, "2026-10-02", respBody[0].Date)
+	assert.Nil(t, respBody[0].FlatNo)
+}
+
+func TestMatchBankTransaction(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	flatNo := 3
+	mockService.On("MatchBankTransaction", 5, 3, "admin").Return(services.BankTransaction{ID: 5, Status: "matched", FlatNo: &flatNo}, nil)
+	mockService.On("MatchBankTransaction", 6, 3, "admin").Return(services.BankTransaction{}, dto.BankTransactionResolved{Message: "bank transaction is already ignored"})
+
+	app := fiber.New()
+	app.Post("/bank/transaction/:id/match", asAdmin, controller.MatchBankTransaction)
+
+	req := httptest.NewRequest("POST", "/bank/transaction/5/match", strings.NewReader(`{"flat_no":3}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	req = httptest.NewRequest("POST", "/bank/transaction/6/match", strings.NewReader(`{"flat_no":3}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
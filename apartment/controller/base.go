@@ -1,15 +1,33 @@
 package controller
 
 import (
+	"io"
+	"reflect"
+	"time"
+
 	"github.com/go-playground/validator/v10"
-	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	paymentprovider "github.com/pragmataW/apartment_management/pkg/payment_provider"
 	"github.com/pragmataW/apartment_management/services"
 )
 
 var (
-	validate = validator.New()
+	validate = newValidator()
 )
 
+// newValidator checks money amounts by their minor units, so tags such as
+// gt=0 work on them as on plain numbers.
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		if amount, ok := field.Interface().(money.Money); ok {
+			return amount.Minor
+		}
+		return nil
+	}, money.Money{})
+	return v
+}
+
 type IService interface {
 	LoginAdmin(password string) (string, error)
 	LoginUser(flatNo int, mail string, password string) (string, error)
@@ -18,29 +36,87 @@ type IService interface {
 	DeleteFlat(flatNo int) error
 	GetAllInfoAboutFlat(flatNo int) (services.Apartment, error)
 	GetAllInfoAboutAllFlat() ([]services.Apartment, error)
+	UpdateDuesProfile(apartment services.Apartment) error
+	GetPaymentAmount(email string, months int, payAll bool) (money.Money, error)
 	AddDues(flatNo int) error
 	DeleteDues(flatNo int) error
-	ChangeDuesPrice(price float64)
-	ChangePayDay(payDay int) error
+	GetLedger(flatNo int) (services.Ledger, error)
+	RecordManualPayment(receipt services.PaymentReceipt) (services.PaymentReceipt, error)
+	CancelManualPayment(receiptNo int, cancelledBy string, reason string) (services.PaymentReceipt, error)
+	GetPaymentReceipt(receiptNo int) (services.PaymentReceipt, error)
+	GetReceiptMails(status string) ([]services.ReceiptMail, error)
+	RetryReceiptMail(id int) (services.ReceiptMail, error)
+	GetPaymentReceipts(flatNo int) ([]services.PaymentReceipt, error)
+	ImportBankStatement(statement services.BankStatement) (services.BankImport, error)
+	GetBankImports() ([]services.BankImport, error)
+	GetBankTransactions(status string) ([]services.BankTransaction, error)
+	MatchBankTransaction(id int, flatNo int, resolvedBy string) (services.BankTransaction, error)
+	IgnoreBankTransaction(id int, resolvedBy string) (services.BankTransaction, error)
+	CreateReferenceRule(rule services.ReferenceRule) (services.ReferenceRule, error)
+	GetReferenceRules() ([]services.ReferenceRule, error)
+	DeleteReferenceRule(id int) error
+	GetBalanceByEmail(email string) (services.AccountBalance, error)
+	GetResidentFlat(flatNo int, email string) (services.Apartment, error)
+	GetResidentDues(flatNo int, email string) ([]services.OpenCharge, money.Money, error)
+	GetResidentPayments(flatNo int, email string) ([]services.LedgerEntry, error)
+	GetStatement(flatNo int, from time.Time, to time.Time) (services.Statement, error)
+	GetStatementByEmail(email string, from time.Time, to time.Time) (services.Statement, error)
+	AddLedgerAdjustment(flatNo int, amount money.Money, note string) error
+	ChangeDuesPrice(price money.Money, changedBy string) error
+	ChangePayDay(payDay int, changedBy string) error
+	GetDuesPrice() (services.DuesPrice, error)
+	GetPayDay() (int, error)
+	ChangePenaltyPolicy(policy services.PenaltyPolicy, changedBy string) error
+	GetPenaltyPolicy() (services.PenaltyPolicy, error)
+	GetSettingHistory(key string) ([]services.SettingChange, error)
+	CreateAssessment(assessment services.Assessment) (services.Assessment, error)
+	GetAssessments() ([]services.Assessment, error)
+	GetAssessmentCharges(assessmentID int) ([]services.AssessmentCharge, error)
+	CreateExpenseCategory(name string) (services.ExpenseCategory, error)
+	GetExpenseCategories() ([]services.ExpenseCategory, error)
+	DeleteExpenseCategory(id int) error
+	CreateVendor(vendor services.Vendor) (services.Vendor, error)
+	GetVendors() ([]services.Vendor, error)
+	UpdateVendor(vendor services.Vendor) error
+	DeleteVendor(id int) error
+	CreateExpense(expense services.Expense) (services.Expense, error)
+	UpdateExpense(expense services.Expense) error
+	DeleteExpense(id int) error
+	GetExpense(id int) (services.Expense, error)
+	GetExpenses(filter services.ExpenseFilter) ([]services.Expense, error)
+	AttachExpenseReceipt(id int, fileName string, content io.Reader) error
+	GetExpenseReceipt(id int) (string, string, io.ReadCloser, error)
+	GetFinancialReport(period string) (services.FinancialReport, error)
+	GetAgingReport(filter services.AgingFilter) (services.AgingReport, error)
+	CreateMeter(meter services.Meter) (services.Meter, error)
+	GetMeters(flatNo int) ([]services.Meter, error)
+	DeleteMeter(id int) error
+	AddMeterReading(reading services.MeterReading) (services.MeterReading, error)
+	GetMeterReadings(meterID int) ([]services.MeterReading, error)
+	ImportMeterReadings(content io.Reader, importedBy string) (int, error)
+	CreateUtilityBill(bill services.UtilityBill) (services.UtilityBill, error)
+	GetUtilityBills() ([]services.UtilityBill, error)
+	GetUtilityBill(id int) (services.UtilityBill, error)
+	GetReminderPolicy() (services.ReminderPolicy, error)
+	ChangeReminderPolicy(policy services.ReminderPolicy, changedBy string) error
+	SendDuesReminders(now time.Time) (int, error)
+	GetDuesReminders(flatNo int) ([]services.DuesReminder, error)
 	AddAnnouncement(announcement services.Announcement) error
 	GetAllAnnouncements() ([]services.Announcement, error)
 	SendMail(subject string, body string, mail string) error
-	IncreaseDuesAutomatically() error
-	GetPaymentToken(payment dto.PaymentSendReq) (string, error)
-	PaymentCallback(merchantOID string) error
-}
-
-type IConfigManager interface {
-	GetMerchantID() int
-	GetMerchantKey() string
-	GetMerchantSalt() string
-	GetFailUrl() string
-	GetOkUrl() string
+	GetScheduledJobs() []services.ScheduledJob
+	GetAccrualRuns() ([]services.AccrualRun, error)
+	GetPaymentToken(payment paymentprovider.Payment) (string, error)
+	PaymentCallback(form map[string]string) error
+	GetPaymentTransaction(merchantOID string) (services.PaymentTransaction, error)
+	GetPaymentTransactions(filter services.PaymentTransactionFilter) ([]services.PaymentTransaction, error)
+	AcceptPaymentReview(merchantOID string, reviewedBy string) (services.PaymentTransaction, error)
+	RefundPayment(merchantOID string, amount money.Money, reason string, refundedBy string) (services.PaymentTransaction, error)
+	GetPaymentRefunds(merchantOID string) ([]services.PaymentRefund, error)
 }
 
 type controller struct {
-	Service       IService
-	ConfigManager IConfigManager
+	Service IService
 }
 
 type controllerOption func(*controller)
@@ -58,9 +134,3 @@ func WithService(service IService) controllerOption {
 		c.Service = service
 	}
 }
-
-func WithConfigManager(configManager IConfigManager) controllerOption {
-	return func(c *controller) {
-		c.ConfigManager = configManager
-	}
-}
@@ -1,18 +1,13 @@
 package controller
 
 import (
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/base64"
-	"encoding/json"
-	"fmt"
+	"log"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/pragmataW/apartment_management/dto"
-	randomkeygen "github.com/pragmataW/apartment_management/pkg/random_keygen"
+	paymentprovider "github.com/pragmataW/apartment_management/pkg/payment_provider"
 	"github.com/pragmataW/apartment_management/services"
 )
 
@@ -144,7 +139,6 @@ func (ctrl *controller) UpdateFlatOwner(c *fiber.Ctx) error {
 		OwnerSurname: body.OwnerSurname,
 		Mail:         body.Mail,
 		Password:     body.Password,
-		DuesCount:    body.DuesCount,
 	}
 
 	err = ctrl.Service.UpdateFlatOwner(apartment)
@@ -159,6 +153,52 @@ func (ctrl *controller) UpdateFlatOwner(c *fiber.Ctx) error {
 	})
 }
 
+func (ctrl *controller) UpdateDuesProfile(c *fiber.Ctx) error {
+	flatNo, err := strconv.Atoi(c.Params("flatNo"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing query parameter: flatNo - " + strconv.Itoa(flatNo),
+		})
+	}
+
+	var body dto.DuesProfileRequest
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "bad request " + err.Error(),
+		})
+	}
+
+	if err := validate.Struct(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	apartment := services.Apartment{
+		FlatNo:          flatNo,
+		FlatType:        body.FlatType,
+		Area:            body.Area,
+		Share:           body.Share,
+		DuesCoefficient: body.DuesCoefficient,
+		DuesOverride:    body.DuesOverride,
+	}
+
+	if err := ctrl.Service.UpdateDuesProfile(apartment); err != nil {
+		if err, ok := err.(dto.ThereIsNoFlat); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "status ok",
+	})
+}
+
 func (ctrl *controller) DeleteFlat(c *fiber.Ctx) error {
 	flatNoParam := c.Params("flatNo")
 	if flatNoParam == "" {
@@ -207,12 +247,22 @@ func (ctrl *controller) GetAllInfoAboutFlat(c *fiber.Ctx) error {
 	}
 
 	resp := dto.ApartmentResponse{
-		FlatNo:       apartment.FlatNo,
-		OwnerName:    apartment.OwnerName,
-		OwnerSurname: apartment.OwnerSurname,
-		Mail:         apartment.Mail,
-		Password:     apartment.Password,
-		DuesCount:    apartment.DuesCount,
+		FlatNo:          apartment.FlatNo,
+		OwnerName:       apartment.OwnerName,
+		OwnerSurname:    apartment.OwnerSurname,
+		Mail:            apartment.Mail,
+		Password:        apartment.Password,
+		FlatType:        apartment.FlatType,
+		Area:            apartment.Area,
+		Share:           apartment.Share,
+		DuesCoefficient: apartment.DuesCoefficient,
+		DuesOverride:    apartment.DuesOverride,
+		MonthlyDues:     apartment.MonthlyDues,
+		DuesCount:       apartment.DuesCount,
+		Penalties:       apartment.Penalties,
+		Credit:          apartment.Credit,
+		Assessments:     apartment.Assessments,
+		Balance:         apartment.Balance,
 	}
 
 	return c.Status(fiber.StatusOK).JSON(resp)
@@ -229,12 +279,22 @@ func (ctrl *controller) GetAllInfoAboutAllFlat(c *fiber.Ctx) error {
 	var resp []dto.ApartmentResponse
 	for _, apartment := range apartments {
 		respApartment := dto.ApartmentResponse{
-			FlatNo:       apartment.FlatNo,
-			OwnerName:    apartment.OwnerName,
-			OwnerSurname: apartment.OwnerSurname,
-			Mail:         apartment.Mail,
-			Password:     apartment.Password,
-			DuesCount:    apartment.DuesCount,
+			FlatNo:          apartment.FlatNo,
+			OwnerName:       apartment.OwnerName,
+			OwnerSurname:    apartment.OwnerSurname,
+			Mail:            apartment.Mail,
+			Password:        apartment.Password,
+			FlatType:        apartment.FlatType,
+			Area:            apartment.Area,
+			Share:           apartment.Share,
+			DuesCoefficient: apartment.DuesCoefficient,
+			DuesOverride:    apartment.DuesOverride,
+			MonthlyDues:     apartment.MonthlyDues,
+			DuesCount:       apartment.DuesCount,
+			Penalties:       apartment.Penalties,
+			Credit:          apartment.Credit,
+			Assessments:     apartment.Assessments,
+			Balance:         apartment.Balance,
 		}
 		resp = append(resp, respApartment)
 	}
@@ -251,6 +311,11 @@ func (ctrl *controller) AddDues(c *fiber.Ctx) error {
 	}
 
 	if err := ctrl.Service.AddDues(flatNo); err != nil {
+		if err, ok := err.(dto.ThereIsNoFlat); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"message": err.Error(),
 		})
@@ -275,6 +340,11 @@ func (ctrl *controller) DeleteDues(c *fiber.Ctx) error {
 				"message": err.Error(),
 			})
 		}
+		if err, ok := err.(dto.ThereIsNoFlat); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"message": err.Error(),
 		})
@@ -293,7 +363,17 @@ func (ctrl *controller) ChangeDuesPrice(c *fiber.Ctx) error {
 		})
 	}
 
-	ctrl.Service.ChangeDuesPrice(body.Price)
+	if err := ctrl.Service.ChangeDuesPrice(body.Price, actor(c)); err != nil {
+		if err, ok := err.(dto.DuesPriceRangeError); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "status ok",
 	})
@@ -307,8 +387,13 @@ func (ctrl *controller) ChangePayDay(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := ctrl.Service.ChangePayDay(body.PayDay); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+	if err := ctrl.Service.ChangePayDay(body.PayDay, actor(c)); err != nil {
+		if err, ok := err.(dto.PayDayRangeError); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"message": err.Error(),
 		})
 	}
@@ -319,16 +404,34 @@ func (ctrl *controller) ChangePayDay(c *fiber.Ctx) error {
 }
 
 func (ctrl *controller) GetDuesPrice(c *fiber.Ctx) error {
+	duesPrice, err := ctrl.Service.GetDuesPrice()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
 	resp := dto.DuesPriceResponse{
-		DuesPrice: dto.DuesPrice,
+		DuesPrice: duesPrice.Price,
+	}
+	if !duesPrice.NextEffectiveFrom.IsZero() {
+		resp.NextDuesPrice = &duesPrice.NextPrice
+		resp.NextEffectiveFrom = &duesPrice.NextEffectiveFrom
 	}
 
 	return c.Status(fiber.StatusOK).JSON(resp)
 }
 
 func (ctrl *controller) GetPayDay(c *fiber.Ctx) error {
+	payDay, err := ctrl.Service.GetPayDay()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
 	resp := dto.PayDayResponse{
-		PayDay: dto.PayDay,
+		PayDay: payDay,
 	}
 
 	return c.Status(fiber.StatusOK).JSON(resp)
@@ -427,63 +530,33 @@ func (ctrl *controller) GetPaymentToken(c *fiber.Ctx) error {
 		})
 	}
 
-	merchantID := strconv.Itoa(ctrl.ConfigManager.GetMerchantID())
-	merchantKey := []byte(ctrl.ConfigManager.GetMerchantKey())
-	merchantSalt := []byte(ctrl.ConfigManager.GetMerchantSalt())
-
-	merchantOid := randomkeygen.NewKeygen(64).GenerateRandomKey()
-	fmt.Println(merchantOid)
 	email := c.Locals("email").(string)
-	paymentAmount := strconv.Itoa(int(dto.DuesPrice * 100))
-	userName := body.UserName
-	userAddress := body.UserAddress
-	userPhone := body.UserPhone
-	merchantOkUrl := ctrl.ConfigManager.GetOkUrl()
-	merchantFailUrl := ctrl.ConfigManager.GetFailUrl()
-
-	userBasket := body.UserBasket
-	userBasketJSON, _ := json.Marshal(userBasket)
-	userBasketEncoded := base64.StdEncoding.EncodeToString(userBasketJSON)
-
-	userIP := c.IP()
-	timeOutLimit := "30"
-	debugOn := body.DebugOn
-	testMode := body.TestMode
-	noInstallment := "1"
-	maxInstallment := "0"
-	currency := "TL"
-
-	hashStr := strings.Join([]string{
-		merchantID, userIP, merchantOid, email, paymentAmount,
-		userBasketEncoded, noInstallment, maxInstallment, currency, testMode,
-	}, "")
-	h := hmac.New(sha256.New, merchantKey)
-	h.Write([]byte(hashStr))
-	h.Write(merchantSalt)
-	paytrToken := base64.StdEncoding.EncodeToString(h.Sum(nil))
-
-	req := dto.PaymentSendReq{
-		MerchantId:     merchantID,
-		UserIP:         userIP,
-		MerchantOID:    merchantOid,
-		Email:          email,
-		PaymentAmount:  paymentAmount,
-		PaytrToken:     paytrToken,
-		UserBasket:     userBasketEncoded,
-		DebugOn:        debugOn,
-		NoInstallment:  noInstallment,
-		MaxInstallment: maxInstallment,
-		UserName:       userName,
-		UserAddress:    userAddress,
-		UserPhone:      userPhone,
-		OkURL:          merchantOkUrl,
-		FailURL:        merchantFailUrl,
-		TimeOutLimit:   timeOutLimit,
-		Currency:       currency,
-		TestMode:       testMode,
-	}
-
-	token, err := ctrl.Service.GetPaymentToken(req)
+
+	duesAmount, err := ctrl.Service.GetPaymentAmount(email, body.Months, body.PayAll)
+	if err != nil {
+		if err, ok := err.(dto.ThereIsNoDues); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	payment := paymentprovider.Payment{
+		Email:       email,
+		Amount:      duesAmount,
+		UserName:    body.UserName,
+		UserAddress: body.UserAddress,
+		UserPhone:   body.UserPhone,
+		UserIP:      c.IP(),
+		Basket:      body.UserBasket,
+		DebugOn:     body.DebugOn == "1",
+		TestMode:    body.TestMode == "1",
+	}
+
+	token, err := ctrl.Service.GetPaymentToken(payment)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"message": err.Error(),
@@ -495,35 +568,18 @@ func (ctrl *controller) GetPaymentToken(c *fiber.Ctx) error {
 	})
 }
 
+// PaymentCallback passes the payment provider's callback on to the service.
+// The provider keeps sending the callback until it is answered with "OK",
+// which is also the answer to a failed payment.
 func (ctrl *controller) PaymentCallback(c *fiber.Ctx) error {
-	merchantKey := ctrl.ConfigManager.GetMerchantKey()
-	merchantSalt := ctrl.ConfigManager.GetMerchantSalt()
-
-	merchantOid := c.FormValue("merchant_oid")
-	status := c.FormValue("status")
-	totalAmount := c.FormValue("total_amount")
-	receivedHash := c.FormValue("hash")
-
-	hashStr := merchantOid + merchantSalt + status + totalAmount
-	h := hmac.New(sha256.New, []byte(merchantKey))
-	h.Write([]byte(hashStr))
-	expectedHash := base64.StdEncoding.EncodeToString(h.Sum(nil))
-
-	if receivedHash != expectedHash {
-		fmt.Println("bad hash")
-		return c.SendString("PAYTR notification failed: bad hash")
-	}
+	form := map[string]string{}
+	c.Request().PostArgs().VisitAll(func(key []byte, value []byte) {
+		form[string(key)] = string(value)
+	})
 
-	if status == "success" {
-		err := ctrl.Service.PaymentCallback(merchantOid)
-		if err != nil {
-			fmt.Println("service error")
-			return c.SendString(err.Error())
-		}
-		fmt.Println("payment done")
-	} else {
-		fmt.Println("payment is not done")
-		return c.SendString(status)
+	if err := ctrl.Service.PaymentCallback(form); err != nil {
+		log.Println(err)
+		return c.SendString(err.Error())
 	}
 
 	return c.SendString("OK")
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"io"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	mocks "github.com/pragmataW/apartment_management/controller_mocks"
 	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/pkg/money"
 	"github.com/pragmataW/apartment_management/services"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -265,7 +267,6 @@ func TestUpdateFlatOwner(t *testing.T) {
 		OwnerSurname: "Doe",
 		Mail:         "john.doe@example.com",
 		Password:     "securepassword",
-		DuesCount:    5,
 	}
 	mockService.On("UpdateFlatOwner", apartment).Return(nil)
 
@@ -277,8 +278,7 @@ func TestUpdateFlatOwner(t *testing.T) {
 		"owner_name": "John",
 		"owner_surname": "Doe",
 		"mail": "john.doe@example.com",
-		"password": "securepassword",
-		"dues_count": 5
+		"password": "securepassword"
 	}`
 	req := httptest.NewRequest("PUT", "/updateFlatOwner", strings.NewReader(reqBody))
 	req.Header.Set("Content-Type", "application/json")
@@ -327,8 +327,7 @@ func TestUpdateFlatOwnerValidationFailed(t *testing.T) {
 		"owner_name": "",
 		"owner_surname": "Doe",
 		"mail": "john.doe@example.com",
-		"password": "securepassword",
-		"dues_count": 5
+		"password": "securepassword"
 	}`
 	req := httptest.NewRequest("PUT", "/updateFlatOwner", strings.NewReader(reqBody))
 	req.Header.Set("Content-Type", "application/json")
@@ -353,7 +352,6 @@ func TestUpdateFlatOwnerServiceError(t *testing.T) {
 		OwnerSurname: "Doe",
 		Mail:         "john.doe@example.com",
 		Password:     "securepassword",
-		DuesCount:    5,
 	}
 	mockService.On("UpdateFlatOwner", apartment).Return(errors.New("service error"))
 
@@ -365,8 +363,7 @@ func TestUpdateFlatOwnerServiceError(t *testing.T) {
 		"owner_name": "John",
 		"owner_surname": "Doe",
 		"mail": "john.doe@example.com",
-		"password": "securepassword",
-		"dues_count": 5
+		"password": "securepassword"
 	}`
 	req := httptest.NewRequest("PUT", "/updateFlatOwner", strings.NewReader(reqBody))
 	req.Header.Set("Content-Type", "application/json")
@@ -508,6 +505,7 @@ func TestGetAllInfoAboutFlatSuccess(t *testing.T) {
 		Mail:         "john.doe@example.com",
 		Password:     "password123",
 		DuesCount:    3,
+		Balance:      money.New(12000),
 	}
 
 	mockService.On("GetAllInfoAboutFlat", 1).Return(expectedApartment, nil)
@@ -532,6 +530,7 @@ func TestGetAllInfoAboutFlatSuccess(t *testing.T) {
 		OwnerSurname: expectedApartment.OwnerSurname,
 		Mail:         expectedApartment.Mail,
 		DuesCount:    expectedApartment.DuesCount,
+		Balance:      expectedApartment.Balance,
 		Password:     expectedApartment.Password,
 	}
 	assert.Equal(t, expectedResponse, respBody)
@@ -577,6 +576,7 @@ func TestGetAllInfoAboutAllFlatSuccess(t *testing.T) {
 			Mail:         "john.doe@example.com",
 			Password:     "password123",
 			DuesCount:    3,
+			Balance:      money.New(12000),
 		},
 		{
 			FlatNo:       2,
@@ -585,6 +585,7 @@ func TestGetAllInfoAboutAllFlatSuccess(t *testing.T) {
 			Mail:         "jane.smith@example.com",
 			Password:     "abc123",
 			DuesCount:    2,
+			Balance:      money.New(8000),
 		},
 	}
 
@@ -611,6 +612,7 @@ func TestGetAllInfoAboutAllFlatSuccess(t *testing.T) {
 			OwnerSurname: expectedApartments[0].OwnerSurname,
 			Mail:         expectedApartments[0].Mail,
 			DuesCount:    expectedApartments[0].DuesCount,
+			Balance:      expectedApartments[0].Balance,
 			Password:     expectedApartments[0].Password,
 		},
 		{
@@ -619,6 +621,7 @@ func TestGetAllInfoAboutAllFlatSuccess(t *testing.T) {
 			OwnerSurname: expectedApartments[1].OwnerSurname,
 			Mail:         expectedApartments[1].Mail,
 			DuesCount:    expectedApartments[1].DuesCount,
+			Balance:      expectedApartments[1].Balance,
 			Password:     expectedApartments[1].Password,
 		},
 	}
@@ -652,6 +655,68 @@ func TestAddDuesSuccess(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestUpdateDuesProfileSuccess(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	expected := services.Apartment{
+		FlatNo:          3,
+		FlatType:        "shop",
+		Area:            120,
+		DuesCoefficient: 2.5,
+	}
+	mockService.On("UpdateDuesProfile", expected).Return(nil)
+
+	app := fiber.New()
+	app.Put("/flat/:flatNo/dues/profile", controller.UpdateDuesProfile)
+
+	reqBody := `{"flat_type":"shop","area":120,"dues_coefficient":2.5}`
+	req := httptest.NewRequest("PUT", "/flat/3/dues/profile", strings.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestUpdateDuesProfileBadRequest(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	app := fiber.New()
+	app.Put("/flat/:flatNo/dues/profile", controller.UpdateDuesProfile)
+
+	reqBody := `{"flat_type":"penthouse","dues_coefficient":1}`
+	req := httptest.NewRequest("PUT", "/flat/3/dues/profile", strings.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	mockService.AssertNotCalled(t, "UpdateDuesProfile", mock.Anything)
+}
+
+func TestUpdateDuesProfileNoFlat(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("UpdateDuesProfile", mock.Anything).Return(dto.ThereIsNoFlat{Message: "there is no flat"})
+
+	app := fiber.New()
+	app.Put("/flat/:flatNo/dues/profile", controller.UpdateDuesProfile)
+
+	reqBody := `{"flat_type":"residential","dues_coefficient":1}`
+	req := httptest.NewRequest("PUT", "/flat/99/dues/profile", strings.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
 func TestDeleteDuesSuccess(t *testing.T) {
 	mockService := new(mocks.IService)
 	controller := NewController(WithService(mockService))
@@ -682,13 +747,13 @@ func TestChangeDuesPriceSuccess(t *testing.T) {
 	controller := NewController(WithService(mockService))
 
 	reqBody := dto.ChangeDuesPriceReq{
-		Price: 500,
+		Price: money.New(50000),
 	}
 
-	mockService.On("ChangeDuesPrice", reqBody.Price).Return(nil)
+	mockService.On("ChangeDuesPrice", reqBody.Price, "admin").Return(nil)
 
 	app := fiber.New()
-	app.Put("/changeDuesPrice", controller.ChangeDuesPrice)
+	app.Put("/changeDuesPrice", asAdmin, controller.ChangeDuesPrice)
 
 	reqBodyBytes, err := json.Marshal(reqBody)
 	assert.NoError(t, err)
@@ -715,10 +780,10 @@ func TestChangePayDaySuccess(t *testing.T) {
 		PayDay: 15,
 	}
 
-	mockService.On("ChangePayDay", reqBody.PayDay).Return(nil)
+	mockService.On("ChangePayDay", reqBody.PayDay, "admin").Return(nil)
 
 	app := fiber.New()
-	app.Put("/changePayDay", controller.ChangePayDay)
+	app.Put("/changePayDay", asAdmin, controller.ChangePayDay)
 
 	reqBodyBytes, err := json.Marshal(reqBody)
 	assert.NoError(t, err)
@@ -741,7 +806,7 @@ func TestChangePayDayBadRequest(t *testing.T) {
 	mockService := new(mocks.IService)
 	controller := NewController(WithService(mockService))
 
-	mockService.On("ChangePayDay", mock.Anything).Return(errors.New("negative pay day is not allowed"))
+	mockService.On("ChangePayDay", mock.Anything, mock.Anything).Return(dto.PayDayRangeError{Message: "negative pay day is not allowed"})
 
 	app := fiber.New()
 	app.Put("/changePayDay", controller.ChangePayDay)
@@ -771,11 +836,13 @@ func TestGetDuesPrice(t *testing.T) {
 	mockService := new(mocks.IService)
 	controller := NewController(WithService(mockService))
 
+	mockService.On("GetDuesPrice").Return(services.DuesPrice{Price: money.New(4000)}, nil)
+
 	app := fiber.New()
 	app.Get("/getDuesPrice", controller.GetDuesPrice)
 
 	expectedResp := dto.DuesPriceResponse{
-		DuesPrice: dto.DuesPrice,
+		DuesPrice: money.New(4000),
 	}
 
 	req := httptest.NewRequest("GET", "/getDuesPrice", nil)
@@ -796,11 +863,13 @@ func TestGetPayDay(t *testing.T) {
 	mockService := new(mocks.IService)
 	controller := NewController(WithService(mockService))
 
+	mockService.On("GetPayDay").Return(15, nil)
+
 	app := fiber.New()
 	app.Get("/getPayDay", controller.GetPayDay)
 
 	expectedResp := dto.PayDayResponse{
-		PayDay: dto.PayDay,
+		PayDay: 15,
 	}
 
 	req := httptest.NewRequest("GET", "/getPayDay", nil)
@@ -907,20 +976,11 @@ func TestSendMail(t *testing.T) {
 
 func TestGetPaymentToken(t *testing.T) {
 	// Create mock instances
-	mockConfigManager := new(mocks.IConfigManager)
 	mockService := new(mocks.IService)
 
-	// Configure mock config manager
-	mockConfigManager.On("GetMerchantID").Return(123)                 // Example return value
-	mockConfigManager.On("GetMerchantKey").Return("mocked_key")       // Example return value
-	mockConfigManager.On("GetMerchantSalt").Return("mocked_salt")     // Example return value
-	mockConfigManager.On("GetOkUrl").Return("http://mock.ok/url")     // Example return value
-	mockConfigManager.On("GetFailUrl").Return("http://mock.fail/url") // Example return value
-
-	// Create the controller with mock service and config manager
+	// Create the controller with mock service
 	controller := NewController(
 		WithService(mockService),
-		WithConfigManager(mockConfigManager),
 	)
 
 	// Mock request body
@@ -939,6 +999,7 @@ func TestGetPaymentToken(t *testing.T) {
 	mockBody, _ := json.Marshal(mockRequest)
 
 	// Mock expectations for service method
+	mockService.On("GetPaymentAmount", mock.Anything, 0, false).Return(money.New(4000), nil)
 	mockService.On("GetPaymentToken", mock.Anything).Return("mocked_token", nil)
 
 	// Create Fiber app instance for testing
@@ -963,6 +1024,92 @@ func TestGetPaymentToken(t *testing.T) {
 	assert.Contains(t, string(body), `"token":"mocked_token"`)
 
 	// Verify mock expectations
-	mockConfigManager.AssertExpectations(t)
 	mockService.AssertExpectations(t)
 }
+
+func TestGetPaymentTokenPayAllWithoutDues(t *testing.T) {
+	mockService := new(mocks.IService)
+
+	controller := NewController(
+		WithService(mockService),
+	)
+
+	mockRequest := dto.PaymentGetReq{
+		UserName:    "Test User",
+		UserAddress: "Test Address",
+		UserPhone:   "123456789",
+		UserBasket:  [][]interface{}{{"dues", "0", 1}},
+		DebugOn:     "1",
+		TestMode:    "1",
+		PayAll:      true,
+	}
+	mockBody, _ := json.Marshal(mockRequest)
+
+	mockService.On("GetPaymentAmount", "user@mail.com", 0, true).Return(money.New(0), dto.ThereIsNoDues{Message: "there is no dues"})
+
+	app := fiber.New()
+	app.Post("/getPaymentToken", func(c *fiber.Ctx) error {
+		c.Locals("email", "user@mail.com")
+		return c.Next()
+	}, controller.GetPaymentToken)
+
+	req := httptest.NewRequest("POST", "/getPaymentToken", bytes.NewReader(mockBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockService.AssertNotCalled(t, "GetPaymentToken", mock.Anything)
+}
+
+func TestPaymentCallback(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("PaymentCallback", map[string]string{
+		"merchant_oid": "oid",
+		"status":       "success",
+		"total_amount": "12000",
+		"hash":         "signed",
+	}).Return(nil)
+
+	app := fiber.New()
+	app.Post("/payment/callback", controller.PaymentCallback)
+
+	form := url.Values{
+		"merchant_oid": {"oid"},
+		"status":       {"success"},
+		"total_amount": {"12000"},
+		"hash":         {"signed"},
+	}
+	req := httptest.NewRequest("POST", "/payment/callback", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "OK", string(body))
+	mockService.AssertExpectations(t)
+}
+
+func TestPaymentCallbackBadHash(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("PaymentCallback", mock.Anything).Return(dto.PaymentCallbackError{Message: "paytr notification failed: bad hash"})
+
+	app := fiber.New()
+	app.Post("/payment/callback", controller.PaymentCallback)
+
+	req := httptest.NewRequest("POST", "/payment/callback", strings.NewReader("merchant_oid=oid&hash=wrong"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "paytr notification failed: bad hash", string(body))
+}
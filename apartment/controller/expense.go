@@ -0,0 +1,393 @@
+package controller
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/services"
+)
+
+const dateLayout = "2006-01-02"
+
+func (ctrl *controller) CreateExpenseCategory(c *fiber.Ctx) error {
+	var body dto.ExpenseCategoryReq
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "bad request " + err.Error(),
+		})
+	}
+
+	if err := validate.Struct(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	category, err := ctrl.Service.CreateExpenseCategory(body.Name)
+	if err != nil {
+		return expenseError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.ExpenseCategoryResponse{
+		ID:   category.ID,
+		Name: category.Name,
+	})
+}
+
+func (ctrl *controller) GetExpenseCategories(c *fiber.Ctx) error {
+	categories, err := ctrl.Service.GetExpenseCategories()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	resp := []dto.ExpenseCategoryResponse{}
+	for _, category := range categories {
+		resp = append(resp, dto.ExpenseCategoryResponse{
+			ID:   category.ID,
+			Name: category.Name,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (ctrl *controller) DeleteExpenseCategory(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing query parameter: id",
+		})
+	}
+
+	if err := ctrl.Service.DeleteExpenseCategory(id); err != nil {
+		return expenseError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "status ok",
+	})
+}
+
+func (ctrl *controller) CreateVendor(c *fiber.Ctx) error {
+	var body dto.VendorReq
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "bad request " + err.Error(),
+		})
+	}
+
+	if err := validate.Struct(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	vendor, err := ctrl.Service.CreateVendor(services.Vendor{
+		Name:  body.Name,
+		TaxNo: body.TaxNo,
+		Phone: body.Phone,
+		Email: body.Email,
+	})
+	if err != nil {
+		return expenseError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toVendorResponse(vendor))
+}
+
+func (ctrl *controller) GetVendors(c *fiber.Ctx) error {
+	vendors, err := ctrl.Service.GetVendors()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	resp := []dto.VendorResponse{}
+	for _, vendor := range vendors {
+		resp = append(resp, toVendorResponse(vendor))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (ctrl *controller) UpdateVendor(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing query parameter: id",
+		})
+	}
+
+	var body dto.VendorReq
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "bad request " + err.Error(),
+		})
+	}
+
+	if err := validate.Struct(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	err = ctrl.Service.UpdateVendor(services.Vendor{
+		ID:    id,
+		Name:  body.Name,
+		TaxNo: body.TaxNo,
+		Phone: body.Phone,
+		Email: body.Email,
+	})
+	if err != nil {
+		return expenseError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "status ok",
+	})
+}
+
+func (ctrl *controller) DeleteVendor(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing query parameter: id",
+		})
+	}
+
+	if err := ctrl.Service.DeleteVendor(id); err != nil {
+		return expenseError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "status ok",
+	})
+}
+
+func (ctrl *controller) CreateExpense(c *fiber.Ctx) error {
+	expense, err := parseExpenseReq(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	expense.CreatedBy = actor(c)
+
+	created, err := ctrl.Service.CreateExpense(expense)
+	if err != nil {
+		return expenseError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toExpenseResponse(created))
+}
+
+func (ctrl *controller) UpdateExpense(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing query parameter: id",
+		})
+	}
+
+	expense, err := parseExpenseReq(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	expense.ID = id
+
+	if err := ctrl.Service.UpdateExpense(expense); err != nil {
+		return expenseError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "status ok",
+	})
+}
+
+func (ctrl *controller) DeleteExpense(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing query parameter: id",
+		})
+	}
+
+	if err := ctrl.Service.DeleteExpense(id); err != nil {
+		return expenseError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "status ok",
+	})
+}
+
+func (ctrl *controller) GetExpense(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing query parameter: id",
+		})
+	}
+
+	expense, err := ctrl.Service.GetExpense(id)
+	if err != nil {
+		return expenseError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toExpenseResponse(expense))
+}
+
+// GetExpenses lists expenses, optionally narrowed by the from and to dates
+// and by category_id and vendor_id query parameters.
+func (ctrl *controller) GetExpenses(c *fiber.Ctx) error {
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	filter := services.ExpenseFilter{From: from, To: to}
+	filter.CategoryID = c.QueryInt("category_id")
+	filter.VendorID = c.QueryInt("vendor_id")
+
+	expenses, err := ctrl.Service.GetExpenses(filter)
+	if err != nil {
+		return expenseError(c, err)
+	}
+
+	resp := []dto.ExpenseResponse{}
+	for _, expense := range expenses {
+		resp = append(resp, toExpenseResponse(expense))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// UploadExpenseReceipt takes the receipt as the "receipt" field of a
+// multipart form.
+func (ctrl *controller) UploadExpenseReceipt(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing query parameter: id",
+		})
+	}
+
+	fileHeader, err := c.FormFile("receipt")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing form file: receipt",
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	defer file.Close()
+
+	if err := ctrl.Service.AttachExpenseReceipt(id, fileHeader.Filename, file); err != nil {
+		return expenseError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "status ok",
+	})
+}
+
+func (ctrl *controller) GetExpenseReceipt(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing query parameter: id",
+		})
+	}
+
+	name, contentType, content, err := ctrl.Service.GetExpenseReceipt(id)
+	if err != nil {
+		return expenseError(c, err)
+	}
+
+	c.Attachment(name)
+	c.Set(fiber.HeaderContentType, contentType)
+	return c.Status(fiber.StatusOK).SendStream(content)
+}
+
+func parseExpenseReq(c *fiber.Ctx) (services.Expense, error) {
+	var body dto.ExpenseReq
+	if err := c.BodyParser(&body); err != nil {
+		return services.Expense{}, err
+	}
+
+	if err := validate.Struct(body); err != nil {
+		return services.Expense{}, err
+	}
+
+	date, err := time.ParseInLocation(dateLayout, body.Date, time.Local)
+	if err != nil {
+		return services.Expense{}, err
+	}
+
+	return services.Expense{
+		CategoryID:  body.CategoryID,
+		VendorID:    body.VendorID,
+		Date:        date,
+		Amount:      body.Amount,
+		Description: body.Description,
+	}, nil
+}
+
+// expenseError answers with 404 for a missing expense, 400 for a rejected
+// one and 500 otherwise.
+func expenseError(c *fiber.Ctx, err error) error {
+	switch err := err.(type) {
+	case dto.ThereIsNoExpense:
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	case dto.ExpenseError:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"message": err.Error(),
+	})
+}
+
+func toVendorResponse(vendor services.Vendor) dto.VendorResponse {
+	return dto.VendorResponse{
+		ID:    vendor.ID,
+		Name:  vendor.Name,
+		TaxNo: vendor.TaxNo,
+		Phone: vendor.Phone,
+		Email: vendor.Email,
+	}
+}
+
+func toExpenseResponse(expense services.Expense) dto.ExpenseResponse {
+	return dto.ExpenseResponse{
+		ID:           expense.ID,
+		CategoryID:   expense.CategoryID,
+		CategoryName: expense.CategoryName,
+		VendorID:     expense.VendorID,
+		VendorName:   expense.VendorName,
+		Date:         expense.Date.Format(dateLayout),
+		Amount:       expense.Amount,
+		Description:  expense.Description,
+		HasReceipt:   expense.HasReceipt,
+		CreatedBy:    expense.CreatedBy,
+		CreatedAt:    expense.CreatedAt,
+		UpdatedAt:    expense.UpdatedAt,
+	}
+}
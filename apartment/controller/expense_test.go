@@ -0,0 +1,192 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	mocks "github.com/pragmataW/apartment_management/controller_mocks"
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"github.com/pragmataW/apartment_management/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateExpenseSuccess(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("CreateExpense", mock.MatchedBy(func(expense services.Expense) bool {
+		return expense.CategoryID == 1 && expense.Amount == money.New(150000) && expense.CreatedBy == "admin" &&
+			expense.Date.Format("2006-01-02") == "2026-10-05" && *expense.VendorID == 2
+	})).Return(services.Expense{ID: 7, CategoryID: 1, CategoryName: "cleaning", Date: time.Date(2026, 10, 5, 0, 0, 0, 0, time.Local), Amount: money.New(150000)}, nil)
+
+	app := fiber.New()
+	app.Post("/expense", asAdmin, controller.CreateExpense)
+
+	reqBody := `{"category_id":1,"vendor_id":2,"date":"2026-10-05","amount":1500,"description":"stairs"}`
+	req := httptest.NewRequest("POST", "/expense", strings.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var respBody dto.ExpenseResponse
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, respBody.ID)
+	assert.Equal(t, "2026-10-05", respBody.Date)
+	assert.Equal(t, "cleaning", respBody.CategoryName)
+}
+
+func TestCreateExpenseBadDate(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	app := fiber.New()
+	app.Post("/expense", asAdmin, controller.CreateExpense)
+
+	reqBody := `{"category_id":1,"date":"05.10.2026","amount":1500}`
+	req := httptest.NewRequest("POST", "/expense", strings.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockService.AssertNotCalled(t, "CreateExpense", mock.Anything)
+}
+
+func TestGetExpensesFilter(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetExpenses", mock.MatchedBy(func(filter services.ExpenseFilter) bool {
+		return filter.From.Format("2006-01-02") == "2026-01-01" && filter.To.IsZero() && filter.CategoryID == 3
+	})).Return([]services.Expense{{ID: 1, CategoryID: 3, Amount: money.New(20000)}}, nil)
+
+	app := fiber.New()
+	app.Get("/expense", controller.GetExpenses)
+
+	req := httptest.NewRequest("GET", "/expense?from=2026-01-01&category_id=3", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var respBody []dto.ExpenseResponse
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.NoError(t, err)
+	assert.Len(t, respBody, 1)
+}
+
+func TestGetExpenseNotFound(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetExpense", 9).Return(services.Expense{}, dto.ThereIsNoExpense{Message: "there is no expense"})
+
+	app := fiber.New()
+	app.Get("/expense/:id", controller.GetExpense)
+
+	req := httptest.NewRequest("GET", "/expense/9", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestDeleteExpenseCategoryInUse(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("DeleteExpenseCategory", 2).Return(dto.ExpenseError{Message: "expense category is in use"})
+
+	app := fiber.New()
+	app.Delete("/expense/category/:id", controller.DeleteExpenseCategory)
+
+	req := httptest.NewRequest("DELETE", "/expense/category/2", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestUploadExpenseReceiptSuccess(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("AttachExpenseReceipt", 3, "invoice.pdf", mock.Anything).Return(nil)
+
+	app := fiber.New()
+	app.Post("/expense/:id/receipt", controller.UploadExpenseReceipt)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("receipt", "invoice.pdf")
+	assert.NoError(t, err)
+	part.Write([]byte("%PDF-1.4"))
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/expense/3/receipt", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockService.AssertExpectations(t)
+}
+
+func TestUploadExpenseReceiptMissingFile(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	app := fiber.New()
+	app.Post("/expense/:id/receipt", controller.UploadExpenseReceipt)
+
+	req := httptest.NewRequest("POST", "/expense/3/receipt", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGetExpenseReceiptSuccess(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetExpenseReceipt", 3).Return("expense-3-1.pdf", "application/pdf", io.NopCloser(strings.NewReader("%PDF-1.4")), nil)
+
+	app := fiber.New()
+	app.Get("/expense/:id/receipt", controller.GetExpenseReceipt)
+
+	req := httptest.NewRequest("GET", "/expense/3/receipt", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/pdf", resp.Header.Get("Content-Type"))
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), "expense-3-1.pdf")
+
+	content, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "%PDF-1.4", string(content))
+}
+
+func TestGetVendorsError(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetVendors").Return(nil, errors.New("some error"))
+
+	app := fiber.New()
+	app.Get("/expense/vendor", controller.GetVendors)
+
+	req := httptest.NewRequest("GET", "/expense/vendor", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+}
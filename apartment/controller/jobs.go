@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/pragmataW/apartment_management/dto"
+)
+
+func (ctrl *controller) GetScheduledJobs(c *fiber.Ctx) error {
+	jobs := ctrl.Service.GetScheduledJobs()
+
+	resp := []dto.ScheduledJobResponse{}
+	for _, job := range jobs {
+		resp = append(resp, dto.ScheduledJobResponse{
+			Name:    job.Name,
+			NextRun: job.NextRun,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (ctrl *controller) GetAccrualRuns(c *fiber.Ctx) error {
+	runs, err := ctrl.Service.GetAccrualRuns()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	resp := []dto.AccrualRunResponse{}
+	for _, run := range runs {
+		resp = append(resp, dto.AccrualRunResponse{
+			Period:    run.Period,
+			Amount:    run.Amount,
+			FlatCount: run.FlatCount,
+			DueDate:   run.DueDate,
+			RunAt:     run.RunAt,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	mocks "github.com/pragmataW/apartment_management/controller_mocks"
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"github.com/pragmataW/apartment_management/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetScheduledJobs(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	next := time.Date(2026, 11, 15, 0, 0, 0, 0, time.UTC)
+	mockService.On("GetScheduledJobs").Return([]services.ScheduledJob{
+		{Name: "dues_accrual", NextRun: next},
+	})
+
+	app := fiber.New()
+	app.Get("/admin/jobs", controller.GetScheduledJobs)
+
+	req := httptest.NewRequest("GET", "/admin/jobs", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var respBody []dto.ScheduledJobResponse
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.NoError(t, err)
+	assert.Len(t, respBody, 1)
+	assert.Equal(t, "dues_accrual", respBody[0].Name)
+	assert.True(t, next.Equal(respBody[0].NextRun))
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetAccrualRuns(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetAccrualRuns").Return([]services.AccrualRun{
+		{Period: "2026-10", Amount: money.New(4000), FlatCount: 12},
+	}, nil)
+
+	app := fiber.New()
+	app.Get("/admin/accruals", controller.GetAccrualRuns)
+
+	req := httptest.NewRequest("GET", "/admin/accruals", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var respBody []dto.AccrualRunResponse
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.NoError(t, err)
+	assert.Len(t, respBody, 1)
+	assert.Equal(t, "2026-10", respBody[0].Period)
+	assert.Equal(t, 12, respBody[0].FlatCount)
+
+	mockService.AssertExpectations(t)
+}
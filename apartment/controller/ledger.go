@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pragmataW/apartment_management/dto"
+)
+
+func (ctrl *controller) GetLedger(c *fiber.Ctx) error {
+	flatNo, err := strconv.Atoi(c.Params("flatNo"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing query parameter: flatNo - " + strconv.Itoa(flatNo),
+		})
+	}
+
+	ledger, err := ctrl.Service.GetLedger(flatNo)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	resp := dto.LedgerResponse{
+		FlatNo:  ledger.FlatNo,
+		Balance: ledger.Balance,
+		Entries: []dto.LedgerEntryResponse{},
+	}
+	for _, entry := range ledger.Entries {
+		resp.Entries = append(resp.Entries, dto.LedgerEntryResponse{
+			ID:        entry.ID,
+			Period:    entry.Period,
+			Type:      entry.Type,
+			Amount:    entry.Amount,
+			Source:    entry.Source,
+			Reference: entry.Reference,
+			Note:      entry.Note,
+			DueDate:   entry.DueDate,
+			CreatedAt: entry.CreatedAt,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (ctrl *controller) AddLedgerAdjustment(c *fiber.Ctx) error {
+	flatNo, err := strconv.Atoi(c.Params("flatNo"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing query parameter: flatNo - " + strconv.Itoa(flatNo),
+		})
+	}
+
+	var body dto.LedgerAdjustmentReq
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "bad request " + err.Error(),
+		})
+	}
+
+	if err := validate.Struct(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	if err := ctrl.Service.AddLedgerAdjustment(flatNo, body.Amount, body.Note); err != nil {
+		if err, ok := err.(dto.ThereIsNoFlat); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "status ok",
+	})
+}
+
+func (ctrl *controller) GetMyBalance(c *fiber.Ctx) error {
+	email := c.Locals("email").(string)
+
+	balance, err := ctrl.Service.GetBalanceByEmail(email)
+	if err != nil {
+		if err, ok := err.(dto.ThereIsNoFlat); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	resp := dto.BalanceResponse{
+		FlatNo:      balance.FlatNo,
+		Balance:     balance.Balance,
+		Credit:      balance.Credit,
+		OpenDues:    balance.OpenDues,
+		Penalties:   balance.Penalties,
+		MonthlyDues: balance.MonthlyDues,
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
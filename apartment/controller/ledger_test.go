@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	mocks "github.com/pragmataW/apartment_management/controller_mocks"
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"github.com/pragmataW/apartment_management/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLedgerSuccess(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	ledger := services.Ledger{
+		FlatNo:  1,
+		Balance: money.New(4000),
+		Entries: []services.LedgerEntry{
+			{ID: 1, FlatNo: 1, Period: "2026-09", Type: "accrual", Amount: money.New(4000), Source: "scheduler"},
+			{ID: 2, FlatNo: 1, Period: "2026-10", Type: "accrual", Amount: money.New(4000), Source: "scheduler"},
+			{ID: 3, FlatNo: 1, Period: "2026-09", Type: "payment", Amount: money.New(-4000), Source: "paytr", Reference: "oid"},
+		},
+	}
+	mockService.On("GetLedger", 1).Return(ledger, nil)
+
+	app := fiber.New()
+	app.Get("/flat/:flatNo/ledger", controller.GetLedger)
+
+	req := httptest.NewRequest("GET", "/flat/1/ledger", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var respBody dto.LedgerResponse
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, respBody.FlatNo)
+	assert.Equal(t, money.New(4000), respBody.Balance)
+	assert.Len(t, respBody.Entries, 3)
+	assert.Equal(t, "oid", respBody.Entries[2].Reference)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetLedgerServiceError(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetLedger", 1).Return(services.Ledger{}, errors.New("service error"))
+
+	app := fiber.New()
+	app.Get("/flat/:flatNo/ledger", controller.GetLedger)
+
+	req := httptest.NewRequest("GET", "/flat/1/ledger", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestAddLedgerAdjustmentSuccess(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("AddLedgerAdjustment", 1, money.New(-1550), "water leak refund").Return(nil)
+
+	app := fiber.New()
+	app.Post("/flat/:flatNo/ledger/adjustment", controller.AddLedgerAdjustment)
+
+	req := httptest.NewRequest("POST", "/flat/1/ledger/adjustment", strings.NewReader(`{"amount": -15.5, "note": "water leak refund"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"message":"status ok"}`, string(body))
+
+	mockService.AssertExpectations(t)
+}
+
+func TestAddLedgerAdjustmentValidationFailed(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	app := fiber.New()
+	app.Post("/flat/:flatNo/ledger/adjustment", controller.AddLedgerAdjustment)
+
+	req := httptest.NewRequest("POST", "/flat/1/ledger/adjustment", strings.NewReader(`{"amount": -15.5}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	mockService.AssertNotCalled(t, "AddLedgerAdjustment")
+}
+
+func asUser(c *fiber.Ctx) error {
+	c.Locals("email", "user@mail.com")
+	c.Locals("role", "user")
+	return c.Next()
+}
+
+func TestGetMyBalanceSuccess(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	balance := services.AccountBalance{FlatNo: 4, Balance: money.New(-20000), Credit: money.New(20000), MonthlyDues: money.New(4000)}
+	mockService.On("GetBalanceByEmail", "user@mail.com").Return(balance, nil)
+
+	app := fiber.New()
+	app.Get("/me/balance", asUser, controller.GetMyBalance)
+
+	req := httptest.NewRequest("GET", "/me/balance", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var respBody dto.BalanceResponse
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, respBody.FlatNo)
+	assert.Equal(t, money.New(20000), respBody.Credit)
+	assert.Equal(t, money.New(-20000), respBody.Balance)
+}
+
+func TestGetMyBalanceError(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetBalanceByEmail", "user@mail.com").Return(services.AccountBalance{}, errors.New("some error"))
+
+	app := fiber.New()
+	app.Get("/me/balance", asUser, controller.GetMyBalance)
+
+	req := httptest.NewRequest("GET", "/me/balance", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+}
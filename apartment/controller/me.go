@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/pragmataW/apartment_management/dto"
+)
+
+// resident reads the flat and email the JWT middleware took from the
+// resident's token. Handlers under /me only ever look at this flat.
+func resident(c *fiber.Ctx) (int, string, bool) {
+	flatNo, ok := c.Locals("flatNo").(int)
+	if !ok || flatNo <= 0 {
+		return 0, "", false
+	}
+	email, _ := c.Locals("email").(string)
+	return flatNo, email, true
+}
+
+func (ctrl *controller) GetMe(c *fiber.Ctx) error {
+	flatNo, email, ok := resident(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "Unauthorized: Missing flat in JWT",
+		})
+	}
+
+	apartment, err := ctrl.Service.GetResidentFlat(flatNo, email)
+	if err != nil {
+		return residentError(c, err)
+	}
+
+	resp := dto.MyFlatResponse{
+		FlatNo:          apartment.FlatNo,
+		FlatType:        apartment.FlatType,
+		Area:            apartment.Area,
+		Share:           apartment.Share,
+		DuesCoefficient: apartment.DuesCoefficient,
+		MonthlyDues:     apartment.MonthlyDues,
+		OpenDues:        apartment.DuesCount,
+		Penalties:       apartment.Penalties,
+		Assessments:     apartment.Assessments,
+		Credit:          apartment.Credit,
+		Balance:         apartment.Balance,
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (ctrl *controller) GetMyProfile(c *fiber.Ctx) error {
+	flatNo, email, ok := resident(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "Unauthorized: Missing flat in JWT",
+		})
+	}
+
+	apartment, err := ctrl.Service.GetResidentFlat(flatNo, email)
+	if err != nil {
+		return residentError(c, err)
+	}
+
+	resp := dto.ProfileResponse{
+		FlatNo:       apartment.FlatNo,
+		OwnerName:    apartment.OwnerName,
+		OwnerSurname: apartment.OwnerSurname,
+		Mail:         apartment.Mail,
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (ctrl *controller) GetMyDues(c *fiber.Ctx) error {
+	flatNo, email, ok := resident(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "Unauthorized: Missing flat in JWT",
+		})
+	}
+
+	charges, total, err := ctrl.Service.GetResidentDues(flatNo, email)
+	if err != nil {
+		return residentError(c, err)
+	}
+
+	resp := dto.MyDuesResponse{
+		Total:   total,
+		Charges: []dto.OpenChargeResponse{},
+	}
+	for _, charge := range charges {
+		resp.Charges = append(resp.Charges, dto.OpenChargeResponse{
+			ID:        charge.ID,
+			Period:    charge.Period,
+			Type:      charge.Type,
+			Note:      charge.Note,
+			Amount:    charge.Amount,
+			Remaining: charge.Remaining,
+			DueDate:   charge.DueDate,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (ctrl *controller) GetMyPayments(c *fiber.Ctx) error {
+	flatNo, email, ok := resident(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "Unauthorized: Missing flat in JWT",
+		})
+	}
+
+	payments, err := ctrl.Service.GetResidentPayments(flatNo, email)
+	if err != nil {
+		return residentError(c, err)
+	}
+
+	resp := []dto.LedgerEntryResponse{}
+	for _, payment := range payments {
+		resp = append(resp, dto.LedgerEntryResponse{
+			ID:        payment.ID,
+			Period:    payment.Period,
+			Type:      payment.Type,
+			Amount:    payment.Amount,
+			Source:    payment.Source,
+			Reference: payment.Reference,
+			Note:      payment.Note,
+			DueDate:   payment.DueDate,
+			CreatedAt: payment.CreatedAt,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// residentError answers with 403 when the token no longer matches a flat,
+// so a stale token cannot be used to probe which flats exist.
+func residentError(c *fiber.Ctx, err error) error {
+	switch err.(type) {
+	case dto.FlatAccessDenied, dto.ThereIsNoFlat:
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"message": "Forbidden: token does not belong to this flat",
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"message": err.Error(),
+	})
+}
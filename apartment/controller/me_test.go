@@ -0,0 +1,141 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	mocks "github.com/pragmataW/apartment_management/controller_mocks"
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"github.com/pragmataW/apartment_management/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func asResident(c *fiber.Ctx) error {
+	c.Locals("email", "user@mail.com")
+	c.Locals("role", "user")
+	c.Locals("flatNo", 3)
+	return c.Next()
+}
+
+func TestGetMeSuccess(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetResidentFlat", 3, "user@mail.com").Return(services.Apartment{FlatNo: 3, FlatType: "residential", MonthlyDues: money.New(4000), DuesCount: 2, Balance: money.New(8000)}, nil)
+
+	app := fiber.New()
+	app.Get("/me", asResident, controller.GetMe)
+
+	req := httptest.NewRequest("GET", "/me", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var respBody dto.MyFlatResponse
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, respBody.FlatNo)
+	assert.Equal(t, 2, respBody.OpenDues)
+	assert.Equal(t, money.New(8000), respBody.Balance)
+}
+
+func TestGetMeWithoutFlatClaim(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	app := fiber.New()
+	app.Get("/me", asUser, controller.GetMe)
+
+	req := httptest.NewRequest("GET", "/me", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	mockService.AssertNotCalled(t, "GetResidentFlat", mock.Anything, mock.Anything)
+}
+
+func TestGetMyProfileForbidden(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetResidentFlat", 3, "user@mail.com").Return(services.Apartment{}, dto.FlatAccessDenied{Message: "token does not belong to this flat"})
+
+	app := fiber.New()
+	app.Get("/me/profile", asResident, controller.GetMyProfile)
+
+	req := httptest.NewRequest("GET", "/me/profile", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+}
+
+func TestGetMyProfileSuccess(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetResidentFlat", 3, "user@mail.com").Return(services.Apartment{FlatNo: 3, OwnerName: "Ayşe", OwnerSurname: "Yılmaz", Mail: "user@mail.com", Password: "secret"}, nil)
+
+	app := fiber.New()
+	app.Get("/me/profile", asResident, controller.GetMyProfile)
+
+	req := httptest.NewRequest("GET", "/me/profile", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var respBody map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, "Ayşe", respBody["owner_name"])
+	assert.NotContains(t, respBody, "password")
+}
+
+func TestGetMyDuesSuccess(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetResidentDues", 3, "user@mail.com").Return([]services.OpenCharge{
+		{ID: 1, Period: "2026-09", Type: "accrual", Amount: money.New(4000), Remaining: money.New(1550), DueDate: time.Date(2026, 9, 1, 0, 0, 0, 0, time.Local)},
+	}, money.New(1550), nil)
+
+	app := fiber.New()
+	app.Get("/me/dues", asResident, controller.GetMyDues)
+
+	req := httptest.NewRequest("GET", "/me/dues", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var respBody dto.MyDuesResponse
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(1550), respBody.Total)
+	assert.Len(t, respBody.Charges, 1)
+}
+
+func TestGetMyPaymentsSuccess(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetResidentPayments", 3, "user@mail.com").Return([]services.LedgerEntry{
+		{ID: 4, Period: "2026-10", Type: "payment", Amount: money.New(8000), Source: "paytr", Reference: "oid-2"},
+	}, nil)
+
+	app := fiber.New()
+	app.Get("/me/payments", asResident, controller.GetMyPayments)
+
+	req := httptest.NewRequest("GET", "/me/payments", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var respBody []dto.LedgerEntryResponse
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.NoError(t, err)
+	assert.Len(t, respBody, 1)
+	assert.Equal(t, "oid-2", respBody[0].Reference)
+}
@@ -0,0 +1,314 @@
+package controller
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/services"
+)
+
+func (ctrl *controller) CreateMeter(c *fiber.Ctx) error {
+	var body dto.MeterReq
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "bad request " + err.Error(),
+		})
+	}
+
+	if err := validate.Struct(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	meter, err := ctrl.Service.CreateMeter(services.Meter{
+		FlatNo:    body.FlatNo,
+		Type:      body.Type,
+		Serial:    body.Serial,
+		Unit:      body.Unit,
+		CreatedBy: actor(c),
+	})
+	if err != nil {
+		return meterError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toMeterResponse(meter))
+}
+
+// GetMeters lists every meter, or those of one flat with the flat_no query
+// parameter.
+func (ctrl *controller) GetMeters(c *fiber.Ctx) error {
+	flatNo := 0
+	if value := c.Query("flat_no"); value != "" {
+		var err error
+		flatNo, err = strconv.Atoi(value)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": "flat_no must be a number",
+			})
+		}
+	}
+
+	meters, err := ctrl.Service.GetMeters(flatNo)
+	if err != nil {
+		return meterError(c, err)
+	}
+
+	resp := []dto.MeterResponse{}
+	for _, meter := range meters {
+		resp = append(resp, toMeterResponse(meter))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (ctrl *controller) DeleteMeter(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing query parameter: id",
+		})
+	}
+
+	if err := ctrl.Service.DeleteMeter(id); err != nil {
+		return meterError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "status ok",
+	})
+}
+
+func (ctrl *controller) AddMeterReading(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing query parameter: id",
+		})
+	}
+
+	var body dto.MeterReadingReq
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "bad request " + err.Error(),
+		})
+	}
+
+	if err := validate.Struct(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	var readAt time.Time
+	if body.ReadAt != "" {
+		readAt, err = time.ParseInLocation(dateLayout, body.ReadAt, time.Local)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
+	}
+
+	reading, err := ctrl.Service.AddMeterReading(services.MeterReading{
+		MeterID:   id,
+		ReadAt:    readAt,
+		Value:     body.Value,
+		CreatedBy: actor(c),
+	})
+	if err != nil {
+		return meterError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toMeterReadingResponse(reading))
+}
+
+func (ctrl *controller) GetMeterReadings(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing query parameter: id",
+		})
+	}
+
+	readings, err := ctrl.Service.GetMeterReadings(id)
+	if err != nil {
+		return meterError(c, err)
+	}
+
+	resp := []dto.MeterReadingResponse{}
+	for _, reading := range readings {
+		resp = append(resp, toMeterReadingResponse(reading))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// ImportMeterReadings takes a CSV file with serial, date and value columns as
+// the "readings" field of a multipart form.
+func (ctrl *controller) ImportMeterReadings(c *fiber.Ctx) error {
+	fileHeader, err := c.FormFile("readings")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing form file: readings",
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	defer file.Close()
+
+	imported, err := ctrl.Service.ImportMeterReadings(file, actor(c))
+	if err != nil {
+		return meterError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.MeterImportResponse{Imported: imported})
+}
+
+func (ctrl *controller) CreateUtilityBill(c *fiber.Ctx) error {
+	var body dto.UtilityBillReq
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "bad request " + err.Error(),
+		})
+	}
+
+	if err := validate.Struct(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	bill := services.UtilityBill{
+		Type:        body.Type,
+		Period:      body.Period,
+		Total:       body.Total,
+		CommonShare: body.CommonShare,
+		CommonRule:  body.CommonRule,
+		Note:        body.Note,
+		CreatedBy:   actor(c),
+	}
+	dates := map[string]*time.Time{"from": &bill.From, "to": &bill.To, "due_date": &bill.DueDate}
+	for field, value := range map[string]string{"from": body.From, "to": body.To, "due_date": body.DueDate} {
+		if value == "" {
+			continue
+		}
+		date, err := time.ParseInLocation(dateLayout, value, time.Local)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": field + ": " + err.Error(),
+			})
+		}
+		*dates[field] = date
+	}
+
+	created, err := ctrl.Service.CreateUtilityBill(bill)
+	if err != nil {
+		return meterError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toUtilityBillResponse(created))
+}
+
+func (ctrl *controller) GetUtilityBills(c *fiber.Ctx) error {
+	bills, err := ctrl.Service.GetUtilityBills()
+	if err != nil {
+		return meterError(c, err)
+	}
+
+	resp := []dto.UtilityBillResponse{}
+	for _, bill := range bills {
+		resp = append(resp, toUtilityBillResponse(bill))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (ctrl *controller) GetUtilityBill(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing query parameter: id",
+		})
+	}
+
+	bill, err := ctrl.Service.GetUtilityBill(id)
+	if err != nil {
+		return meterError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toUtilityBillResponse(bill))
+}
+
+func meterError(c *fiber.Ctx, err error) error {
+	switch err := err.(type) {
+	case dto.ThereIsNoMeter, dto.ThereIsNoUtilityBill, dto.ThereIsNoFlat:
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	case dto.MeterError:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"message": err.Error(),
+	})
+}
+
+func toMeterResponse(meter services.Meter) dto.MeterResponse {
+	return dto.MeterResponse{
+		ID:        meter.ID,
+		FlatNo:    meter.FlatNo,
+		Type:      meter.Type,
+		Serial:    meter.Serial,
+		Unit:      meter.Unit,
+		CreatedBy: meter.CreatedBy,
+		CreatedAt: meter.CreatedAt,
+	}
+}
+
+func toMeterReadingResponse(reading services.MeterReading) dto.MeterReadingResponse {
+	return dto.MeterReadingResponse{
+		ID:        reading.ID,
+		MeterID:   reading.MeterID,
+		ReadAt:    reading.ReadAt.Format(dateLayout),
+		Value:     reading.Value,
+		Source:    reading.Source,
+		CreatedBy: reading.CreatedBy,
+	}
+}
+
+func toUtilityBillResponse(bill services.UtilityBill) dto.UtilityBillResponse {
+	resp := dto.UtilityBillResponse{
+		ID:          bill.ID,
+		Type:        bill.Type,
+		Period:      bill.Period,
+		Total:       bill.Total,
+		CommonShare: bill.CommonShare,
+		CommonRule:  bill.CommonRule,
+		From:        bill.From.Format(dateLayout),
+		To:          bill.To.Format(dateLayout),
+		DueDate:     bill.DueDate.Format(dateLayout),
+		Note:        bill.Note,
+		CreatedBy:   bill.CreatedBy,
+		CreatedAt:   bill.CreatedAt,
+	}
+	for _, line := range bill.Lines {
+		resp.Lines = append(resp.Lines, dto.UtilityBillLineResponse{
+			FlatNo:            line.FlatNo,
+			Consumption:       line.Consumption,
+			CommonAmount:      line.CommonAmount,
+			ConsumptionAmount: line.ConsumptionAmount,
+			Amount:            line.Amount,
+		})
+	}
+	return resp
+}
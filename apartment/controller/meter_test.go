@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	mocks "github.com/pragmataW/apartment_management/controller_mocks"
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"github.com/pragmataW/apartment_management/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAddMeterReadingGoesDown(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("AddMeterReading", mock.MatchedBy(func(reading services.MeterReading) bool {
+		return reading.MeterID == 3 && reading.Value == 90 && reading.ReadAt.Format(dateLayout) == "2026-09-30" && reading.CreatedBy == "admin"
+	})).Return(services.MeterReading{}, dto.MeterError{Message: "meter W-1 read 90 on 2026-09-30, lower than the 100 read on 2026-09-01"})
+
+	app := fiber.New()
+	app.Post("/meter/:id/reading", asAdmin, controller.AddMeterReading)
+
+	req := httptest.NewRequest("POST", "/meter/3/reading", strings.NewReader(`{"value":90,"read_at":"2026-09-30"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockService.AssertExpectations(t)
+}
+
+func TestImportMeterReadingsSuccess(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("ImportMeterReadings", mock.MatchedBy(func(content io.Reader) bool {
+		data, _ := io.ReadAll(content)
+		return string(data) == "serial,date,value\nW-1,2026-09-30,130\n"
+	}), "admin").Return(1, nil)
+
+	app := fiber.New()
+	app.Post("/meter/reading/import", asAdmin, controller.ImportMeterReadings)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("readings", "readings.csv")
+	assert.NoError(t, err)
+	part.Write([]byte("serial,date,value\nW-1,2026-09-30,130\n"))
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/meter/reading/import", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var respBody dto.MeterImportResponse
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, respBody.Imported)
+	mockService.AssertExpectations(t)
+}
+
+func TestCreateUtilityBillSuccess(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("CreateUtilityBill", mock.MatchedBy(func(bill services.UtilityBill) bool {
+		return bill.Type == "water" && bill.Total == money.New(100000) && bill.CommonShare == 20 &&
+			bill.From.Format(dateLayout) == "2026-09-01" && bill.To.Format(dateLayout) == "2026-09-30" && bill.DueDate.IsZero()
+	})).Return(services.UtilityBill{
+		ID: 2, Type: "water", Period: "2026-09", Total: money.New(100000),
+		Lines: []services.UtilityBillLine{{FlatNo: 1, Consumption: 30, Amount: money.New(66667)}},
+	}, nil)
+
+	app := fiber.New()
+	app.Post("/utility/bill", asAdmin, controller.CreateUtilityBill)
+
+	reqBody := `{"type":"water","total":1000,"common_share":20,"from":"2026-09-01","to":"2026-09-30"}`
+	req := httptest.NewRequest("POST", "/utility/bill", strings.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var respBody dto.UtilityBillResponse
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-09", respBody.Period)
+	assert.Len(t, respBody.Lines, 1)
+	assert.Equal(t, money.New(66667), respBody.Lines[0].Amount)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetUtilityBillNotFound(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetUtilityBill", 9).Return(services.UtilityBill{}, dto.ThereIsNoUtilityBill{Message: "there is no utility bill"})
+
+	app := fiber.New()
+	app.Get("/utility/bill/:id", asAdmin, controller.GetUtilityBill)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/utility/bill/9", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,141 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/services"
+)
+
+// GetPaymentTransactions lists the online payments, newest first. They can be
+// narrowed with the status, flat_no, from and to query parameters.
+func (ctrl *controller) GetPaymentTransactions(c *fiber.Ctx) error {
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	filter := services.PaymentTransactionFilter{From: from, To: to}
+	filter.Status = c.Query("status")
+	filter.FlatNo = c.QueryInt("flat_no")
+
+	transactions, err := ctrl.Service.GetPaymentTransactions(filter)
+	if err != nil {
+		return paymentError(c, err)
+	}
+
+	resp := []dto.PaymentTransactionResponse{}
+	for _, transaction := range transactions {
+		resp = append(resp, toPaymentTransactionResponse(transaction))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (ctrl *controller) GetPaymentTransaction(c *fiber.Ctx) error {
+	transaction, err := ctrl.Service.GetPaymentTransaction(c.Params("oid"))
+	if err != nil {
+		return paymentError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toPaymentTransactionResponse(transaction))
+}
+
+// AcceptPaymentReview books a payment held for review because its total did
+// not match what was asked for, at the amount actually paid.
+func (ctrl *controller) AcceptPaymentReview(c *fiber.Ctx) error {
+	transaction, err := ctrl.Service.AcceptPaymentReview(c.Params("oid"), actor(c))
+	if err != nil {
+		return paymentError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toPaymentTransactionResponse(transaction))
+}
+
+// RefundPayment gives money back on an online payment through its provider,
+// all that is left of it unless an amount is given, and reverses the months
+// the refunded part had settled.
+func (ctrl *controller) RefundPayment(c *fiber.Ctx) error {
+	var body dto.PaymentRefundReq
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "bad request " + err.Error(),
+		})
+	}
+
+	if err := validate.Struct(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	transaction, err := ctrl.Service.RefundPayment(c.Params("oid"), body.Amount, body.Reason, actor(c))
+	if err != nil {
+		return paymentError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toPaymentTransactionResponse(transaction))
+}
+
+func (ctrl *controller) GetPaymentRefunds(c *fiber.Ctx) error {
+	refunds, err := ctrl.Service.GetPaymentRefunds(c.Params("oid"))
+	if err != nil {
+		return paymentError(c, err)
+	}
+
+	resp := []dto.PaymentRefundResponse{}
+	for _, refund := range refunds {
+		resp = append(resp, dto.PaymentRefundResponse{
+			ID:          refund.ID,
+			MerchantOID: refund.MerchantOID,
+			Amount:      refund.Amount,
+			Reason:      refund.Reason,
+			RefundedBy:  refund.RefundedBy,
+			Status:      refund.Status,
+			Error:       refund.Error,
+			CreatedAt:   refund.CreatedAt,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func paymentError(c *fiber.Ctx, err error) error {
+	switch err := err.(type) {
+	case dto.ThereIsNoPaymentTransaction:
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	case dto.PaymentTransactionError, dto.PaymentStateConflict:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	case dto.PaymentProviderError:
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"message": err.Error(),
+	})
+}
+
+func toPaymentTransactionResponse(transaction services.PaymentTransaction) dto.PaymentTransactionResponse {
+	return dto.PaymentTransactionResponse{
+		MerchantOID: transaction.MerchantOID,
+		Provider:    transaction.Provider,
+		FlatNo:      transaction.FlatNo,
+		Email:       transaction.Email,
+		Amount:      transaction.Amount,
+		PaidAmount:  transaction.PaidAmount,
+		Status:      transaction.Status,
+		FailReason:  transaction.FailReason,
+		ReceiptNo:   transaction.ReceiptNo,
+		ReviewedBy:  transaction.ReviewedBy,
+		CreatedAt:   transaction.CreatedAt,
+		CallbackAt:  transaction.CallbackAt,
+
+		RefundedAmount: transaction.RefundedAmount,
+	}
+}
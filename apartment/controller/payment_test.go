@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	mocks "github.com/pragmataW/apartment_management/controller_mocks"
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"github.com/pragmataW/apartment_management/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetPaymentTransactions(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)
+	mockService.On("GetPaymentTransactions", services.PaymentTransactionFilter{Status: "failed", FlatNo: 3, From: from}).Return([]services.PaymentTransaction{
+		{MerchantOID: "oid", Provider: "paytr", FlatNo: 3, Amount: money.New(12000), Status: "failed", FailReason: "insufficient funds"},
+	}, nil)
+
+	app := fiber.New()
+	app.Get("/payment/transaction", controller.GetPaymentTransactions)
+
+	req := httptest.NewRequest("GET", "/payment/transaction?status=failed&flat_no=3&from=2026-10-01", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var body []dto.PaymentTransactionResponse
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.Len(t, body, 1)
+	assert.Equal(t, "insufficient funds", body[0].FailReason)
+	assert.Equal(t, money.New(12000), body[0].Amount)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetPaymentTransactionsBadStatus(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetPaymentTransactions", mock.Anything).Return(nil, dto.PaymentTransactionError{Message: "status must be created, pending, succeeded, failed, expired or refunded"})
+
+	app := fiber.New()
+	app.Get("/payment/transaction", controller.GetPaymentTransactions)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/payment/transaction?status=lost", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGetPaymentTransactionNotFound(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetPaymentTransaction", "missing").Return(services.PaymentTransaction{}, dto.ThereIsNoPaymentTransaction{Message: "there is no payment transaction"})
+
+	app := fiber.New()
+	app.Get("/payment/transaction/:oid", controller.GetPaymentTransaction)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/payment/transaction/missing", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestAcceptPaymentReview(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("AcceptPaymentReview", "oid", "admin").Return(services.PaymentTransaction{
+		MerchantOID: "oid", Amount: money.New(12000), PaidAmount: money.New(4000), Status: "succeeded", ReviewedBy: "admin",
+	}, nil)
+
+	app := fiber.New()
+	app.Post("/payment/transaction/:oid/accept", asAdmin, controller.AcceptPaymentReview)
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/payment/transaction/oid/accept", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var body dto.PaymentTransactionResponse
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, money.New(4000), body.PaidAmount)
+	mockService.AssertExpectations(t)
+}
+
+func TestAcceptPaymentReviewNotInReview(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("AcceptPaymentReview", "oid", "admin").Return(services.PaymentTransaction{}, dto.PaymentStateConflict{Message: "payment transaction is not held for review"})
+
+	app := fiber.New()
+	app.Post("/payment/transaction/:oid/accept", asAdmin, controller.AcceptPaymentReview)
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/payment/transaction/oid/accept", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestRefundPayment(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("RefundPayment", "oid", money.New(4000), "paid twice", "admin").Return(services.PaymentTransaction{
+		MerchantOID: "oid", PaidAmount: money.New(12000), RefundedAmount: money.New(4000), Status: "succeeded",
+	}, nil)
+
+	app := fiber.New()
+	app.Post("/payment/transaction/:oid/refund", asAdmin, controller.RefundPayment)
+
+	req := httptest.NewRequest("POST", "/payment/transaction/oid/refund", strings.NewReader(`{"amount":"40.00","reason":"paid twice"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var body dto.PaymentTransactionResponse
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, money.New(4000), body.RefundedAmount)
+	mockService.AssertExpectations(t)
+}
+
+func TestRefundPaymentProviderFails(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("RefundPayment", "oid", money.New(0), "paid twice", "admin").Return(services.PaymentTransaction{}, dto.PaymentProviderError{Message: "refund failed"})
+
+	app := fiber.New()
+	app.Post("/payment/transaction/:oid/refund", asAdmin, controller.RefundPayment)
+
+	req := httptest.NewRequest("POST", "/payment/transaction/oid/refund", strings.NewReader(`{"reason":"paid twice"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadGateway, resp.StatusCode)
+}
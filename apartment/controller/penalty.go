@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/services"
+)
+
+func (ctrl *controller) ChangePenaltyPolicy(c *fiber.Ctx) error {
+	var body dto.PenaltyPolicyReq
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "bad request " + err.Error(),
+		})
+	}
+
+	if err := validate.Struct(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	policy := services.PenaltyPolicy{
+		GraceDays: body.GraceDays,
+		Rate:      body.Rate,
+		Fee:       body.Fee,
+	}
+
+	if err := ctrl.Service.ChangePenaltyPolicy(policy, actor(c)); err != nil {
+		if err, ok := err.(dto.PenaltyPolicyError); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "status ok",
+	})
+}
+
+func (ctrl *controller) GetPenaltyPolicy(c *fiber.Ctx) error {
+	policy, err := ctrl.Service.GetPenaltyPolicy()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	resp := dto.PenaltyPolicyResponse{
+		GraceDays: policy.GraceDays,
+		Rate:      policy.Rate,
+		Fee:       policy.Fee,
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	mocks "github.com/pragmataW/apartment_management/controller_mocks"
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"github.com/pragmataW/apartment_management/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestChangePenaltyPolicySuccess(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	policy := services.PenaltyPolicy{GraceDays: 10, Rate: 5}
+	mockService.On("ChangePenaltyPolicy", policy, "admin").Return(nil)
+
+	app := fiber.New()
+	app.Put("/flat/dues/penalty", asAdmin, controller.ChangePenaltyPolicy)
+
+	req := httptest.NewRequest("PUT", "/flat/dues/penalty", strings.NewReader(`{"grace_days":10,"rate":5}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestChangePenaltyPolicyBadRequest(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("ChangePenaltyPolicy", mock.Anything, mock.Anything).Return(dto.PenaltyPolicyError{Message: "penalty rate must be between 0 and 5 percent"})
+
+	app := fiber.New()
+	app.Put("/flat/dues/penalty", asAdmin, controller.ChangePenaltyPolicy)
+
+	req := httptest.NewRequest("PUT", "/flat/dues/penalty", strings.NewReader(`{"rate":9}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGetPenaltyPolicy(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetPenaltyPolicy").Return(services.PenaltyPolicy{GraceDays: 10, Rate: 5, Fee: money.New(0)}, nil)
+
+	app := fiber.New()
+	app.Get("/config/dues/penalty", controller.GetPenaltyPolicy)
+
+	req := httptest.NewRequest("GET", "/config/dues/penalty", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"grace_days":10,"rate":5,"fee":0}`, string(body))
+}
+
+func TestGetPenaltyPolicyError(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetPenaltyPolicy").Return(services.PenaltyPolicy{}, errors.New("some error"))
+
+	app := fiber.New()
+	app.Get("/config/dues/penalty", controller.GetPenaltyPolicy)
+
+	req := httptest.NewRequest("GET", "/config/dues/penalty", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+}
@@ -0,0 +1,204 @@
+package controller
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/services"
+)
+
+// RecordManualPayment books a cash, transfer or cheque payment for the flat
+// and answers with the issued receipt.
+func (ctrl *controller) RecordManualPayment(c *fiber.Ctx) error {
+	flatNo, err := strconv.Atoi(c.Params("flatNo"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing query parameter: flatNo - " + strconv.Itoa(flatNo),
+		})
+	}
+
+	var body dto.ManualPaymentReq
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "bad request " + err.Error(),
+		})
+	}
+
+	if err := validate.Struct(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	var paidAt time.Time
+	if body.PaidAt != "" {
+		paidAt, err = time.ParseInLocation(dateLayout, body.PaidAt, time.Local)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
+	}
+
+	receipt, err := ctrl.Service.RecordManualPayment(services.PaymentReceipt{
+		FlatNo:      flatNo,
+		Amount:      body.Amount,
+		Method:      body.Method,
+		PaidAt:      paidAt,
+		CollectedBy: body.CollectedBy,
+		Note:        body.Note,
+		CreatedBy:   actor(c),
+	})
+	if err != nil {
+		return receiptError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toReceiptResponse(receipt))
+}
+
+// GetPaymentReceipts lists the receipts, narrowed to one flat by the flat_no
+// query parameter.
+func (ctrl *controller) GetPaymentReceipts(c *fiber.Ctx) error {
+	receipts, err := ctrl.Service.GetPaymentReceipts(c.QueryInt("flat_no"))
+	if err != nil {
+		return receiptError(c, err)
+	}
+
+	resp := []dto.ReceiptResponse{}
+	for _, receipt := range receipts {
+		resp = append(resp, toReceiptResponse(receipt))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (ctrl *controller) GetPaymentReceipt(c *fiber.Ctx) error {
+	receiptNo, err := strconv.Atoi(c.Params("receiptNo"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing query parameter: receiptNo",
+		})
+	}
+
+	receipt, err := ctrl.Service.GetPaymentReceipt(receiptNo)
+	if err != nil {
+		return receiptError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toReceiptResponse(receipt))
+}
+
+// CancelPaymentReceipt cancels the receipt with a reversal entry; the receipt
+// itself is kept and shows as cancelled.
+func (ctrl *controller) CancelPaymentReceipt(c *fiber.Ctx) error {
+	receiptNo, err := strconv.Atoi(c.Params("receiptNo"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing query parameter: receiptNo",
+		})
+	}
+
+	var body dto.CancelReceiptReq
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "bad request " + err.Error(),
+		})
+	}
+
+	if err := validate.Struct(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	receipt, err := ctrl.Service.CancelManualPayment(receiptNo, actor(c), body.Reason)
+	if err != nil {
+		return receiptError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toReceiptResponse(receipt))
+}
+
+// GetReceiptMails lists the receipt emails of online payments, filtered by
+// the status query parameter; status=failed lists those given up on.
+func (ctrl *controller) GetReceiptMails(c *fiber.Ctx) error {
+	mails, err := ctrl.Service.GetReceiptMails(c.Query("status"))
+	if err != nil {
+		return receiptError(c, err)
+	}
+
+	resp := []dto.ReceiptMailResponse{}
+	for _, mail := range mails {
+		resp = append(resp, toReceiptMailResponse(mail))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (ctrl *controller) RetryReceiptMail(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing query parameter: id",
+		})
+	}
+
+	mail, err := ctrl.Service.RetryReceiptMail(id)
+	if err != nil {
+		return receiptError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toReceiptMailResponse(mail))
+}
+
+// receiptError answers with 404 for a missing receipt, 400 for a rejected
+// payment or cancellation and 500 otherwise.
+func receiptError(c *fiber.Ctx, err error) error {
+	switch err := err.(type) {
+	case dto.ThereIsNoReceipt, dto.ThereIsNoReceiptMail:
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	case dto.ThereIsNoFlat, dto.ManualPaymentError, dto.ReceiptAlreadyCancelled, dto.ReceiptMailError, dto.ReceiptMailClaimed:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"message": err.Error(),
+	})
+}
+
+func toReceiptResponse(receipt services.PaymentReceipt) dto.ReceiptResponse {
+	return dto.ReceiptResponse{
+		ReceiptNo:    receipt.ReceiptNo,
+		Reference:    receipt.Reference,
+		FlatNo:       receipt.FlatNo,
+		Amount:       receipt.Amount,
+		Method:       receipt.Method,
+		PaidAt:       receipt.PaidAt.Format(dateLayout),
+		CollectedBy:  receipt.CollectedBy,
+		Note:         receipt.Note,
+		CreatedBy:    receipt.CreatedBy,
+		CreatedAt:    receipt.CreatedAt,
+		Cancelled:    receipt.CancelledAt != nil,
+		CancelledAt:  receipt.CancelledAt,
+		CancelledBy:  receipt.CancelledBy,
+		CancelReason: receipt.CancelReason,
+	}
+}
+
+func toReceiptMailResponse(mail services.ReceiptMail) dto.ReceiptMailResponse {
+	return dto.ReceiptMailResponse{
+		ID:          mail.ID,
+		ReceiptNo:   mail.ReceiptNo,
+		Mail:        mail.Mail,
+		Status:      mail.Status,
+		Attempts:    mail.Attempts,
+		Error:       mail.Error,
+		NextAttempt: mail.NextAttempt,
+		SentAt:      mail.SentAt,
+	}
+}
@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	mocks "github.com/pragmataW/apartment_management/controller_mocks"
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"github.com/pragmataW/apartment_management/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRecordManualPaymentSuccess(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	paidAt := time.Date(2026, 10, 10, 0, 0, 0, 0, time.Local)
+	mockService.On("RecordManualPayment", mock.MatchedBy(func(receipt services.PaymentReceipt) bool {
+		return receipt.FlatNo == 3 && receipt.Amount == money.New(8000) && receipt.Method == "cash" &&
+			receipt.PaidAt.Equal(paidAt) && receipt.CreatedBy == "admin"
+	})).Return(services.PaymentReceipt{ReceiptNo: 1, Reference: "R-000001", FlatNo: 3, Amount: money.New(8000), Method: "cash", PaidAt: paidAt, CollectedBy: "admin"}, nil)
+
+	app := fiber.New()
+	app.Post("/flat/:flatNo/payment", asAdmin, controller.RecordManualPayment)
+
+	reqBody := `{"amount":80,"method":"cash","paid_at":"2026-10-10"}`
+	req := httptest.NewRequest("POST", "/flat/3/payment", strings.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var respBody dto.ReceiptResponse
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, respBody.ReceiptNo)
+	assert.Equal(t, "R-000001", respBody.Reference)
+	assert.Equal(t, "2026-10-10", respBody.PaidAt)
+	assert.False(t, respBody.Cancelled)
+}
+
+func TestRecordManualPaymentBadMethod(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	app := fiber.New()
+	app.Post("/flat/:flatNo/payment", asAdmin, controller.RecordManualPayment)
+
+	reqBody := `{"amount":80,"method":"card"}`
+	req := httptest.NewRequest("POST", "/flat/3/payment", strings.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockService.AssertNotCalled(t, "RecordManualPayment", mock.Anything)
+}
+
+func TestCancelPaymentReceipt(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("CancelManualPayment", 5, "admin", "wrong flat").Return(services.PaymentReceipt{}, dto.ReceiptAlreadyCancelled{Message: "receipt is already cancelled"})
+	mockService.On("CancelManualPayment", 6, "admin", "wrong flat").Return(services.PaymentReceipt{}, dto.ThereIsNoReceipt{Message: "there is no receipt"})
+
+	app := fiber.New()
+	app.Post("/receipt/:receiptNo/cancel", asAdmin, controller.CancelPaymentReceipt)
+
+	req := httptest.NewRequest("POST", "/receipt/5/cancel", strings.NewReader(`{"reason":"wrong flat"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	req = httptest.NewRequest("POST", "/receipt/6/cancel", strings.NewReader(`{"reason":"wrong flat"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestGetReceiptMailsBadStatus(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetReceiptMails", "lost").Return(nil, dto.ReceiptMailError{Message: "status must be pending, sent or failed"})
+
+	app := fiber.New()
+	app.Get("/receipt/mail", asAdmin, controller.GetReceiptMails)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/receipt/mail?status=lost", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestRetryReceiptMailSuccess(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("RetryReceiptMail", 3).Return(services.ReceiptMail{ID: 3, ReceiptNo: 12, Status: "sent", Attempts: 1}, nil)
+
+	app := fiber.New()
+	app.Post("/receipt/mail/:id/retry", asAdmin, controller.RetryReceiptMail)
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/receipt/mail/3/retry", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var respBody dto.ReceiptMailResponse
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, "sent", respBody.Status)
+	mockService.AssertExpectations(t)
+}
@@ -17,17 +17,83 @@ func (ctrl *controller) RegisterRoutes(app *fiber.App, jwtKey string) {
 	app.Delete("/flat/:flatNo", adminMiddleware, ctrl.DeleteFlat)
 	app.Get("/flat/:flatNo", adminMiddleware, ctrl.GetAllInfoAboutFlat)
 	app.Get("/flat", adminMiddleware, ctrl.GetAllInfoAboutAllFlat)
+	app.Put("/flat/:flatNo/dues/profile", adminMiddleware, ctrl.UpdateDuesProfile)
 	app.Post("/flat/:flatNo/dues", adminMiddleware, ctrl.AddDues)
 	app.Delete("/flat/:flatNo/dues", adminMiddleware, ctrl.DeleteDues)
+	app.Get("/flat/:flatNo/ledger", adminMiddleware, ctrl.GetLedger)
+	app.Get("/flat/:flatNo/statement", adminMiddleware, ctrl.GetStatement)
+	app.Post("/flat/:flatNo/ledger/adjustment", adminMiddleware, ctrl.AddLedgerAdjustment)
+	app.Post("/flat/:flatNo/payment", adminMiddleware, ctrl.RecordManualPayment)
+	app.Get("/receipt", adminMiddleware, ctrl.GetPaymentReceipts)
+	app.Get("/receipt/mail", adminMiddleware, ctrl.GetReceiptMails)
+	app.Post("/receipt/mail/:id/retry", adminMiddleware, ctrl.RetryReceiptMail)
+	app.Get("/receipt/:receiptNo", adminMiddleware, ctrl.GetPaymentReceipt)
+	app.Post("/receipt/:receiptNo/cancel", adminMiddleware, ctrl.CancelPaymentReceipt)
+	app.Post("/bank/import", adminMiddleware, ctrl.ImportBankStatement)
+	app.Get("/bank/import", adminMiddleware, ctrl.GetBankImports)
+	app.Get("/bank/transaction", adminMiddleware, ctrl.GetBankTransactions)
+	app.Post("/bank/transaction/:id/match", adminMiddleware, ctrl.MatchBankTransaction)
+	app.Post("/bank/transaction/:id/ignore", adminMiddleware, ctrl.IgnoreBankTransaction)
+	app.Post("/bank/rule", adminMiddleware, ctrl.CreateReferenceRule)
+	app.Get("/bank/rule", adminMiddleware, ctrl.GetReferenceRules)
+	app.Delete("/bank/rule/:id", adminMiddleware, ctrl.DeleteReferenceRule)
 	app.Put("/flat/dues/price", adminMiddleware, ctrl.ChangeDuesPrice)
 	app.Put("/flat/payday", adminMiddleware, ctrl.ChangePayDay)
+	app.Put("/flat/dues/penalty", adminMiddleware, ctrl.ChangePenaltyPolicy)
+	app.Put("/flat/dues/reminder", adminMiddleware, ctrl.ChangeReminderPolicy)
+	app.Get("/flat/dues/reminder", adminMiddleware, ctrl.GetReminderPolicy)
+	app.Get("/dues/reminder", adminMiddleware, ctrl.GetDuesReminders)
+	app.Post("/dues/reminder/run", adminMiddleware, ctrl.SendDuesReminders)
+	app.Get("/settings/:key/history", adminMiddleware, ctrl.GetSettingHistory)
+	app.Post("/meter", adminMiddleware, ctrl.CreateMeter)
+	app.Get("/meter", adminMiddleware, ctrl.GetMeters)
+	app.Delete("/meter/:id", adminMiddleware, ctrl.DeleteMeter)
+	app.Post("/meter/reading/import", adminMiddleware, ctrl.ImportMeterReadings)
+	app.Post("/meter/:id/reading", adminMiddleware, ctrl.AddMeterReading)
+	app.Get("/meter/:id/reading", adminMiddleware, ctrl.GetMeterReadings)
+	app.Post("/utility/bill", adminMiddleware, ctrl.CreateUtilityBill)
+	app.Get("/utility/bill", adminMiddleware, ctrl.GetUtilityBills)
+	app.Get("/utility/bill/:id", adminMiddleware, ctrl.GetUtilityBill)
+	app.Post("/assessment", adminMiddleware, ctrl.CreateAssessment)
+	app.Get("/assessment", adminMiddleware, ctrl.GetAssessments)
+	app.Get("/assessment/:id/charges", adminMiddleware, ctrl.GetAssessmentCharges)
+	app.Post("/expense/category", adminMiddleware, ctrl.CreateExpenseCategory)
+	app.Get("/expense/category", middleware.JwtMiddleware(jwtKey, "admin", "user"), ctrl.GetExpenseCategories)
+	app.Delete("/expense/category/:id", adminMiddleware, ctrl.DeleteExpenseCategory)
+	app.Post("/expense/vendor", adminMiddleware, ctrl.CreateVendor)
+	app.Get("/expense/vendor", adminMiddleware, ctrl.GetVendors)
+	app.Put("/expense/vendor/:id", adminMiddleware, ctrl.UpdateVendor)
+	app.Delete("/expense/vendor/:id", adminMiddleware, ctrl.DeleteVendor)
+	app.Post("/expense", adminMiddleware, ctrl.CreateExpense)
+	app.Get("/expense/:id", adminMiddleware, ctrl.GetExpense)
+	app.Put("/expense/:id", adminMiddleware, ctrl.UpdateExpense)
+	app.Delete("/expense/:id", adminMiddleware, ctrl.DeleteExpense)
+	app.Post("/expense/:id/receipt", adminMiddleware, ctrl.UploadExpenseReceipt)
+	app.Get("/expense/:id/receipt", adminMiddleware, ctrl.GetExpenseReceipt)
+	app.Get("/report/aging", adminMiddleware, ctrl.GetAgingReport)
+	app.Get("/report/:period", adminMiddleware, ctrl.GetFinancialReport)
 	app.Post("/announcement", adminMiddleware, ctrl.AddAnnouncement)
 	app.Post("/sendmail", adminMiddleware, ctrl.SendMail)
+	app.Get("/admin/jobs", adminMiddleware, ctrl.GetScheduledJobs)
+	app.Get("/admin/accruals", adminMiddleware, ctrl.GetAccrualRuns)
 
 	userMiddleware := middleware.JwtMiddleware(jwtKey, "user")
 	app.Post("/payment/token", userMiddleware, ctrl.GetPaymentToken)
+	app.Get("/payment/transaction", adminMiddleware, ctrl.GetPaymentTransactions)
+	app.Get("/payment/transaction/:oid", adminMiddleware, ctrl.GetPaymentTransaction)
+	app.Post("/payment/transaction/:oid/accept", adminMiddleware, ctrl.AcceptPaymentReview)
+	app.Post("/payment/transaction/:oid/refund", adminMiddleware, ctrl.RefundPayment)
+	app.Get("/payment/transaction/:oid/refund", adminMiddleware, ctrl.GetPaymentRefunds)
+	app.Get("/me", userMiddleware, ctrl.GetMe)
+	app.Get("/me/profile", userMiddleware, ctrl.GetMyProfile)
+	app.Get("/me/dues", userMiddleware, ctrl.GetMyDues)
+	app.Get("/me/payments", userMiddleware, ctrl.GetMyPayments)
+	app.Get("/me/balance", userMiddleware, ctrl.GetMyBalance)
+	app.Get("/me/statement", userMiddleware, ctrl.GetMyStatement)
 
 	app.Get("/config/dues/price", middleware.JwtMiddleware(jwtKey, "admin", "user"), ctrl.GetDuesPrice)
 	app.Get("/config/payday", middleware.JwtMiddleware(jwtKey, "admin", "user"), ctrl.GetPayDay)
+	app.Get("/config/dues/penalty", middleware.JwtMiddleware(jwtKey, "admin", "user"), ctrl.GetPenaltyPolicy)
+	app.Get("/expense", middleware.JwtMiddleware(jwtKey, "admin", "user"), ctrl.GetExpenses)
 	app.Get("/announcement", middleware.JwtMiddleware(jwtKey, "admin", "user"), ctrl.GetAllAnnouncements)
 }
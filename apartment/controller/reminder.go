@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/services"
+)
+
+func (ctrl *controller) ChangeReminderPolicy(c *fiber.Ctx) error {
+	var body dto.ReminderPolicyReq
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "bad request " + err.Error(),
+		})
+	}
+
+	if err := validate.Struct(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	policy := services.ReminderPolicy{
+		Enabled:         *body.Enabled,
+		DaysAfterPayDay: body.DaysAfterPayDay,
+		IntervalDays:    body.IntervalDays,
+	}
+
+	if err := ctrl.Service.ChangeReminderPolicy(policy, actor(c)); err != nil {
+		if err, ok := err.(dto.ReminderPolicyError); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "status ok",
+	})
+}
+
+func (ctrl *controller) GetReminderPolicy(c *fiber.Ctx) error {
+	policy, err := ctrl.Service.GetReminderPolicy()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.ReminderPolicyResponse{
+		Enabled:         policy.Enabled,
+		DaysAfterPayDay: policy.DaysAfterPayDay,
+		IntervalDays:    policy.IntervalDays,
+	})
+}
+
+// GetDuesReminders lists the reminder log, or that of one flat with the
+// flat_no query parameter.
+func (ctrl *controller) GetDuesReminders(c *fiber.Ctx) error {
+	flatNo := 0
+	if value := c.Query("flat_no"); value != "" {
+		var err error
+		flatNo, err = strconv.Atoi(value)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": "flat_no must be a number",
+			})
+		}
+	}
+
+	reminders, err := ctrl.Service.GetDuesReminders(flatNo)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	resp := []dto.DuesReminderResponse{}
+	for _, reminder := range reminders {
+		resp = append(resp, dto.DuesReminderResponse{
+			ID:     reminder.ID,
+			FlatNo: reminder.FlatNo,
+			Mail:   reminder.Mail,
+			Amount: reminder.Amount,
+			Status: reminder.Status,
+			Error:  reminder.Error,
+			SentAt: reminder.SentAt,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// SendDuesReminders runs the reminder job now instead of waiting for the
+// schedule. The policy and the per-flat interval still apply.
+func (ctrl *controller) SendDuesReminders(c *fiber.Ctx) error {
+	sent, err := ctrl.Service.SendDuesReminders(time.Now())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.ReminderRunResponse{Sent: sent})
+}
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	mocks "github.com/pragmataW/apartment_management/controller_mocks"
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"github.com/pragmataW/apartment_management/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestChangeReminderPolicySuccess(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("ChangeReminderPolicy", services.ReminderPolicy{Enabled: true, DaysAfterPayDay: 5, IntervalDays: 7}, "admin").Return(nil)
+
+	app := fiber.New()
+	app.Put("/flat/dues/reminder", asAdmin, controller.ChangeReminderPolicy)
+
+	req := httptest.NewRequest("PUT", "/flat/dues/reminder", strings.NewReader(`{"enabled":true,"days_after_pay_day":5,"interval_days":7}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockService.AssertExpectations(t)
+}
+
+func TestChangeReminderPolicyBadRequest(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	app := fiber.New()
+	app.Put("/flat/dues/reminder", asAdmin, controller.ChangeReminderPolicy)
+
+	req := httptest.NewRequest("PUT", "/flat/dues/reminder", strings.NewReader(`{"enabled":true,"days_after_pay_day":5,"interval_days":0}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockService.AssertNotCalled(t, "ChangeReminderPolicy", mock.Anything, mock.Anything)
+}
+
+func TestGetDuesReminders(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetDuesReminders", 4).Return([]services.DuesReminder{
+		{ID: 1, FlatNo: 4, Mail: "d@mail.com", Amount: money.New(8000), Status: "sent"},
+	}, nil)
+
+	app := fiber.New()
+	app.Get("/dues/reminder", asAdmin, controller.GetDuesReminders)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/dues/reminder?flat_no=4", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var respBody []dto.DuesReminderResponse
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.NoError(t, err)
+	assert.Len(t, respBody, 1)
+	assert.Equal(t, money.New(8000), respBody[0].Amount)
+}
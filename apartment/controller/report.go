@@ -0,0 +1,242 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"github.com/pragmataW/apartment_management/services"
+)
+
+// GetFinancialReport answers with the report of the period in the path as
+// JSON, or as a CSV download when format=csv is asked for.
+func (ctrl *controller) GetFinancialReport(c *fiber.Ctx) error {
+	format := c.Query("format", "json")
+	if format != "json" && format != "csv" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "format must be json or csv",
+		})
+	}
+
+	report, err := ctrl.Service.GetFinancialReport(c.Params("period"))
+	if err != nil {
+		if err, ok := err.(dto.ReportPeriodError); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	if format == "csv" {
+		content, err := reportCSV(report)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
+
+		c.Attachment("report-" + report.Period + ".csv")
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		return c.Status(fiber.StatusOK).Send(content)
+	}
+
+	resp := dto.ReportResponse{
+		Period:             report.Period,
+		From:               report.From.Format(dateLayout),
+		To:                 report.To.Format(dateLayout),
+		DuesAccrued:        report.DuesAccrued,
+		PenaltiesAccrued:   report.PenaltiesAccrued,
+		AssessmentsAccrued: report.AssessmentsAccrued,
+		OtherCharges:       report.OtherCharges,
+		TotalAccrued:       report.TotalAccrued,
+		CollectedPaytr:     report.CollectedPaytr,
+		CollectedManual:    report.CollectedManual,
+		TotalCollected:     report.TotalCollected,
+		Waived:             report.Waived,
+		OtherCredits:       report.OtherCredits,
+		Expenses:           []dto.ReportExpenseResponse{},
+		TotalExpenses:      report.TotalExpenses,
+		Balance:            report.Balance,
+		CollectionRate:     report.CollectionRate,
+		PeriodOutstanding:  report.PeriodOutstanding,
+		TotalOutstanding:   report.TotalOutstanding,
+	}
+	for _, expense := range report.Expenses {
+		resp.Expenses = append(resp.Expenses, dto.ReportExpenseResponse{
+			Category: expense.Category,
+			Count:    expense.Count,
+			Amount:   expense.Amount,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// reportCSV lays the report out as section, item, amount rows so that it
+// opens as a single sheet in a spreadsheet.
+func reportCSV(report services.FinancialReport) ([]byte, error) {
+	rows := [][]string{
+		{"section", "item", "amount"},
+		{"period", "period", report.Period},
+		{"period", "from", report.From.Format(dateLayout)},
+		{"period", "to", report.To.Format(dateLayout)},
+		{"accrued", "dues", formatAmount(report.DuesAccrued)},
+		{"accrued", "penalties", formatAmount(report.PenaltiesAccrued)},
+		{"accrued", "assessments", formatAmount(report.AssessmentsAccrued)},
+		{"accrued", "other", formatAmount(report.OtherCharges)},
+		{"accrued", "total", formatAmount(report.TotalAccrued)},
+		{"collected", "paytr", formatAmount(report.CollectedPaytr)},
+		{"collected", "manual", formatAmount(report.CollectedManual)},
+		{"collected", "total", formatAmount(report.TotalCollected)},
+		{"credits", "waived", formatAmount(report.Waived)},
+		{"credits", "other", formatAmount(report.OtherCredits)},
+	}
+	for _, expense := range report.Expenses {
+		rows = append(rows, []string{"expenses", expense.Category, formatAmount(expense.Amount)})
+	}
+	rows = append(rows,
+		[]string{"expenses", "total", formatAmount(report.TotalExpenses)},
+		[]string{"summary", "balance", formatAmount(report.Balance)},
+		[]string{"summary", "collection_rate", strconv.FormatFloat(report.CollectionRate, 'f', 2, 64)},
+		[]string{"summary", "period_outstanding", formatAmount(report.PeriodOutstanding)},
+		[]string{"summary", "total_outstanding", formatAmount(report.TotalOutstanding)},
+	)
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GetAgingReport answers with every flat's overdue balance split into 0-30,
+// 31-60, 61-90 and 90+ day buckets. min_days, min_amount, sort and order
+// narrow and order the flats; format=csv returns them as a download.
+func (ctrl *controller) GetAgingReport(c *fiber.Ctx) error {
+	format := c.Query("format", "json")
+	if format != "json" && format != "csv" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "format must be json or csv",
+		})
+	}
+
+	order := c.Query("order", "asc")
+	if order != "asc" && order != "desc" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "order must be asc or desc",
+		})
+	}
+
+	filter := services.AgingFilter{
+		MinDays: c.QueryInt("min_days"),
+		Sort:    c.Query("sort"),
+		Desc:    order == "desc",
+	}
+	if value := c.Query("min_amount"); value != "" {
+		amount, err := money.Parse(value)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": "min_amount must be an amount like 1500.50",
+			})
+		}
+		filter.MinAmount = amount
+	}
+
+	report, err := ctrl.Service.GetAgingReport(filter)
+	if err != nil {
+		if err, ok := err.(dto.AgingReportError); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	if format == "csv" {
+		content, err := agingCSV(report)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
+
+		c.Attachment("aging-" + report.At.Format(dateLayout) + ".csv")
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		return c.Status(fiber.StatusOK).Send(content)
+	}
+
+	resp := dto.AgingResponse{
+		At:     report.At.Format(dateLayout),
+		Totals: toAgingBucketsResponse(report.Totals),
+		Flats:  []dto.AgingFlatResponse{},
+	}
+	for _, flat := range report.Flats {
+		resp.Flats = append(resp.Flats, dto.AgingFlatResponse{
+			FlatNo:        flat.FlatNo,
+			OwnerName:     flat.OwnerName,
+			Mail:          flat.Mail,
+			Buckets:       toAgingBucketsResponse(flat.Buckets),
+			OldestDueDate: flat.OldestDueDate.Format(dateLayout),
+			DaysOverdue:   flat.DaysOverdue,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func toAgingBucketsResponse(buckets services.AgingBuckets) dto.AgingBucketsResponse {
+	return dto.AgingBucketsResponse{
+		Days0To30:  buckets.Days0To30,
+		Days31To60: buckets.Days31To60,
+		Days61To90: buckets.Days61To90,
+		Over90:     buckets.Over90,
+		Total:      buckets.Total,
+	}
+}
+
+// agingCSV writes one row per flat in the order of the report and a closing
+// row with the totals.
+func agingCSV(report services.AgingReport) ([]byte, error) {
+	rows := [][]string{
+		{"flat_no", "owner_name", "mail", "0_30", "31_60", "61_90", "90_plus", "total", "oldest_due_date", "days_overdue"},
+	}
+	for _, flat := range report.Flats {
+		rows = append(rows, []string{
+			strconv.Itoa(flat.FlatNo),
+			flat.OwnerName,
+			flat.Mail,
+			formatAmount(flat.Buckets.Days0To30),
+			formatAmount(flat.Buckets.Days31To60),
+			formatAmount(flat.Buckets.Days61To90),
+			formatAmount(flat.Buckets.Over90),
+			formatAmount(flat.Buckets.Total),
+			flat.OldestDueDate.Format(dateLayout),
+			strconv.Itoa(flat.DaysOverdue),
+		})
+	}
+	rows = append(rows, []string{
+		"total", "", "",
+		formatAmount(report.Totals.Days0To30),
+		formatAmount(report.Totals.Days31To60),
+		formatAmount(report.Totals.Days61To90),
+		formatAmount(report.Totals.Over90),
+		formatAmount(report.Totals.Total),
+		"", "",
+	})
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
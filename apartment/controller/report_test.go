@@ -0,0 +1,185 @@
+package controller
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	mocks "github.com/pragmataW/apartment_management/controller_mocks"
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"github.com/pragmataW/apartment_management/services"
+	"github.com/stretchr/testify/assert"
+)
+
+var testReport = services.FinancialReport{
+	Period:         "2026-10",
+	From:           time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local),
+	To:             time.Date(2026, 10, 31, 0, 0, 0, 0, time.Local),
+	DuesAccrued:    money.New(300000),
+	TotalAccrued:   money.New(300000),
+	CollectedPaytr: money.New(200000),
+	TotalCollected: money.New(200000),
+	Expenses:       []services.ExpenseLine{{Category: "cleaning", Count: 2, Amount: money.New(75000)}},
+	TotalExpenses:  money.New(75000),
+	Balance:        money.New(125000),
+	CollectionRate: 66.67,
+}
+
+func TestGetFinancialReportJSON(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetFinancialReport", "2026-10").Return(testReport, nil)
+
+	app := fiber.New()
+	app.Get("/report/:period", controller.GetFinancialReport)
+
+	req := httptest.NewRequest("GET", "/report/2026-10", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var respBody dto.ReportResponse
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-10-31", respBody.To)
+	assert.Equal(t, money.New(125000), respBody.Balance)
+	assert.Len(t, respBody.Expenses, 1)
+}
+
+func TestGetFinancialReportCSV(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetFinancialReport", "2026-10").Return(testReport, nil)
+
+	app := fiber.New()
+	app.Get("/report/:period", controller.GetFinancialReport)
+
+	req := httptest.NewRequest("GET", "/report/2026-10?format=csv", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/csv")
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), "report-2026-10.csv")
+
+	rows, err := csv.NewReader(resp.Body).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"section", "item", "amount"}, rows[0])
+	assert.Contains(t, rows, []string{"expenses", "cleaning", "750.00"})
+	assert.Contains(t, rows, []string{"summary", "balance", "1250.00"})
+}
+
+func TestGetFinancialReportBadPeriod(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetFinancialReport", "october").Return(services.FinancialReport{}, dto.ReportPeriodError{Message: "period must look like 2026, 2026-Q3 or 2026-10"})
+
+	app := fiber.New()
+	app.Get("/report/:period", controller.GetFinancialReport)
+
+	req := httptest.NewRequest("GET", "/report/october", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGetFinancialReportBadFormat(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	app := fiber.New()
+	app.Get("/report/:period", controller.GetFinancialReport)
+
+	req := httptest.NewRequest("GET", "/report/2026-10?format=xlsx", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+var testAgingReport = services.AgingReport{
+	At: time.Date(2026, 10, 17, 0, 0, 0, 0, time.Local),
+	Totals: services.AgingBuckets{
+		Days0To30: money.New(4000),
+		Over90:    money.New(8000),
+		Total:     money.New(12000),
+	},
+	Flats: []services.AgingFlat{
+		{
+			FlatNo:        4,
+			OwnerName:     "John Doe",
+			Buckets:       services.AgingBuckets{Days0To30: money.New(4000), Over90: money.New(8000), Total: money.New(12000)},
+			OldestDueDate: time.Date(2026, 6, 5, 0, 0, 0, 0, time.Local),
+			DaysOverdue:   134,
+		},
+	},
+}
+
+func TestGetAgingReportJSON(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetAgingReport", services.AgingFilter{MinDays: 90, MinAmount: money.New(10000), Sort: "total", Desc: true}).Return(testAgingReport, nil)
+
+	app := fiber.New()
+	app.Get("/report/aging", controller.GetAgingReport)
+
+	req := httptest.NewRequest("GET", "/report/aging?min_days=90&min_amount=100&sort=total&order=desc", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var respBody dto.AgingResponse
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-10-17", respBody.At)
+	assert.Equal(t, money.New(12000), respBody.Totals.Total)
+	assert.Len(t, respBody.Flats, 1)
+	assert.Equal(t, money.New(8000), respBody.Flats[0].Buckets.Over90)
+	assert.Equal(t, "2026-06-05", respBody.Flats[0].OldestDueDate)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetAgingReportCSV(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetAgingReport", services.AgingFilter{}).Return(testAgingReport, nil)
+
+	app := fiber.New()
+	app.Get("/report/aging", controller.GetAgingReport)
+
+	req := httptest.NewRequest("GET", "/report/aging?format=csv", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), "aging-2026-10-17.csv")
+
+	rows, err := csv.NewReader(resp.Body).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, rows, 3)
+	assert.Equal(t, []string{"4", "John Doe", "", "40.00", "0.00", "0.00", "80.00", "120.00", "2026-06-05", "134"}, rows[1])
+	assert.Equal(t, "total", rows[2][0])
+}
+
+func TestGetAgingReportBadQuery(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetAgingReport", services.AgingFilter{Sort: "owner"}).Return(services.AgingReport{}, dto.AgingReportError{Message: "sort must be flat_no, total or days_overdue"})
+
+	app := fiber.New()
+	app.Get("/report/aging", controller.GetAgingReport)
+
+	for _, query := range []string{"order=up", "min_amount=lots", "format=xlsx", "sort=owner"} {
+		req := httptest.NewRequest("GET", "/report/aging?"+query, nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode, query)
+	}
+}
@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/pragmataW/apartment_management/dto"
+)
+
+// actor names whoever is behind the request, as set by the JWT middleware.
+// Admin tokens carry no email, so the role is used for them.
+func actor(c *fiber.Ctx) string {
+	if email, ok := c.Locals("email").(string); ok && email != "" {
+		return email
+	}
+	if role, ok := c.Locals("role").(string); ok {
+		return role
+	}
+	return ""
+}
+
+func (ctrl *controller) GetSettingHistory(c *fiber.Ctx) error {
+	key := c.Params("key")
+	if key == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing query parameter: key",
+		})
+	}
+
+	history, err := ctrl.Service.GetSettingHistory(key)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	resp := []dto.SettingChangeResponse{}
+	for _, change := range history {
+		resp = append(resp, dto.SettingChangeResponse{
+			Key:           change.Key,
+			Value:         change.Value,
+			PreviousValue: change.PreviousValue,
+			ChangedBy:     change.ChangedBy,
+			ChangedAt:     change.ChangedAt,
+			EffectiveFrom: change.EffectiveFrom,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
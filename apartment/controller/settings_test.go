@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	mocks "github.com/pragmataW/apartment_management/controller_mocks"
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"github.com/pragmataW/apartment_management/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func asAdmin(c *fiber.Ctx) error {
+	c.Locals("email", "")
+	c.Locals("role", "admin")
+	return c.Next()
+}
+
+func TestGetSettingHistorySuccess(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	changedAt := time.Date(2026, 10, 20, 10, 0, 0, 0, time.UTC)
+	history := []services.SettingChange{
+		{
+			Key:           "dues_price",
+			Value:         "50.00",
+			PreviousValue: "40.00",
+			ChangedBy:     "admin",
+			ChangedAt:     changedAt,
+			EffectiveFrom: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	mockService.On("GetSettingHistory", "dues_price").Return(history, nil)
+
+	app := fiber.New()
+	app.Get("/settings/:key/history", controller.GetSettingHistory)
+
+	req := httptest.NewRequest("GET", "/settings/dues_price/history", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var respBody []dto.SettingChangeResponse
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.NoError(t, err)
+	assert.Len(t, respBody, 1)
+	assert.Equal(t, "50.00", respBody[0].Value)
+	assert.Equal(t, "40.00", respBody[0].PreviousValue)
+	assert.Equal(t, "admin", respBody[0].ChangedBy)
+	assert.True(t, changedAt.Equal(respBody[0].ChangedAt))
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetSettingHistoryServiceError(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetSettingHistory", "pay_day").Return(nil, errors.New("service error"))
+
+	app := fiber.New()
+	app.Get("/settings/:key/history", controller.GetSettingHistory)
+
+	req := httptest.NewRequest("GET", "/settings/pay_day/history", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetDuesPriceWithPendingChange(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	effectiveFrom := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	mockService.On("GetDuesPrice").Return(services.DuesPrice{Price: money.New(4000), NextPrice: money.New(5000), NextEffectiveFrom: effectiveFrom}, nil)
+
+	app := fiber.New()
+	app.Get("/config/dues/price", controller.GetDuesPrice)
+
+	req := httptest.NewRequest("GET", "/config/dues/price", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var respBody dto.DuesPriceResponse
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(4000), respBody.DuesPrice)
+	assert.Equal(t, money.New(5000), *respBody.NextDuesPrice)
+	assert.True(t, effectiveFrom.Equal(*respBody.NextEffectiveFrom))
+}
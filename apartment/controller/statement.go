@@ -0,0 +1,217 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"github.com/pragmataW/apartment_management/pkg/pdf"
+	"github.com/pragmataW/apartment_management/services"
+)
+
+var statementColumns = []pdf.Column{
+	{Width: 10},
+	{Width: 10},
+	{Width: 30},
+	{Width: 12, Right: true},
+	{Width: 12, Right: true},
+	{Width: 12, Right: true},
+}
+
+// GetStatement answers with the statement of any flat. The range comes from
+// the from and to query parameters and the format from format, one of json,
+// csv and pdf.
+func (ctrl *controller) GetStatement(c *fiber.Ctx) error {
+	flatNo, err := strconv.Atoi(c.Params("flatNo"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "missing query parameter: flatNo - " + strconv.Itoa(flatNo),
+		})
+	}
+
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	statement, err := ctrl.Service.GetStatement(flatNo, from, to)
+	if err != nil {
+		return statementError(c, err)
+	}
+	return sendStatement(c, statement)
+}
+
+// GetMyStatement answers with the statement of the resident's own flat.
+func (ctrl *controller) GetMyStatement(c *fiber.Ctx) error {
+	email := c.Locals("email").(string)
+
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	statement, err := ctrl.Service.GetStatementByEmail(email, from, to)
+	if err != nil {
+		return statementError(c, err)
+	}
+	return sendStatement(c, statement)
+}
+
+func sendStatement(c *fiber.Ctx, statement services.Statement) error {
+	name := "statement-flat-" + strconv.Itoa(statement.FlatNo) + "-" + statement.To.Format(dateLayout)
+
+	switch c.Query("format", "json") {
+	case "json":
+		return c.Status(fiber.StatusOK).JSON(toStatementResponse(statement))
+	case "csv":
+		content, err := statementCSV(statement)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
+
+		c.Attachment(name + ".csv")
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		return c.Status(fiber.StatusOK).Send(content)
+	case "pdf":
+		c.Attachment(name + ".pdf")
+		c.Set(fiber.HeaderContentType, "application/pdf")
+		return c.Status(fiber.StatusOK).Send(statementPDF(statement))
+	}
+
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"message": "format must be json, csv or pdf",
+	})
+}
+
+func statementError(c *fiber.Ctx, err error) error {
+	switch err := err.(type) {
+	case dto.ThereIsNoFlat, dto.StatementRangeError:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"message": err.Error(),
+	})
+}
+
+// parseDateRange reads the optional from and to query parameters as dates.
+func parseDateRange(c *fiber.Ctx) (time.Time, time.Time, error) {
+	var from, to time.Time
+	var err error
+	if value := c.Query("from"); value != "" {
+		if from, err = time.ParseInLocation(dateLayout, value, time.Local); err != nil {
+			return time.Time{}, time.Time{}, errors.New("from must be a date like 2006-01-02")
+		}
+	}
+	if value := c.Query("to"); value != "" {
+		if to, err = time.ParseInLocation(dateLayout, value, time.Local); err != nil {
+			return time.Time{}, time.Time{}, errors.New("to must be a date like 2006-01-02")
+		}
+	}
+	return from, to, nil
+}
+
+func toStatementResponse(statement services.Statement) dto.StatementResponse {
+	resp := dto.StatementResponse{
+		FlatNo:         statement.FlatNo,
+		OwnerName:      statement.OwnerName,
+		To:             statement.To.Format(dateLayout),
+		OpeningBalance: statement.OpeningBalance,
+		TotalDebit:     statement.TotalDebit,
+		TotalCredit:    statement.TotalCredit,
+		ClosingBalance: statement.ClosingBalance,
+		Lines:          []dto.StatementLineResponse{},
+	}
+	if !statement.From.IsZero() {
+		resp.From = statement.From.Format(dateLayout)
+	}
+	for _, line := range statement.Lines {
+		resp.Lines = append(resp.Lines, dto.StatementLineResponse{
+			Date:        line.Date,
+			Period:      line.Period,
+			Type:        line.Type,
+			Description: line.Description,
+			Debit:       line.Debit,
+			Credit:      line.Credit,
+			Balance:     line.Balance,
+		})
+	}
+	return resp
+}
+
+func statementCSV(statement services.Statement) ([]byte, error) {
+	rows := [][]string{
+		{"date", "period", "type", "description", "debit", "credit", "balance"},
+		{"", "", "opening", "opening balance", "", "", formatAmount(statement.OpeningBalance)},
+	}
+	for _, line := range statement.Lines {
+		rows = append(rows, []string{
+			line.Date.Format(dateLayout),
+			line.Period,
+			line.Type,
+			line.Description,
+			formatAmount(line.Debit),
+			formatAmount(line.Credit),
+			formatAmount(line.Balance),
+		})
+	}
+	rows = append(rows, []string{"", "", "closing", "closing balance", formatAmount(statement.TotalDebit), formatAmount(statement.TotalCredit), formatAmount(statement.ClosingBalance)})
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func statementPDF(statement services.Statement) []byte {
+	from := "first entry"
+	if !statement.From.IsZero() {
+		from = statement.From.Format(dateLayout)
+	}
+
+	doc := pdf.NewDocument()
+	doc.Text("Account statement", 14, true)
+	doc.Space(6)
+	doc.Text("Flat: "+strconv.Itoa(statement.FlatNo), 10, false)
+	if statement.OwnerName != "" {
+		doc.Text("Owner: "+statement.OwnerName, 10, false)
+	}
+	doc.Text("Period: "+from+" - "+statement.To.Format(dateLayout), 10, false)
+	doc.Text("Issued: "+time.Now().Format(dateLayout), 10, false)
+	doc.Space(10)
+
+	doc.Row(statementColumns, []string{"Date", "Type", "Description", "Debit", "Credit", "Balance"}, 9, true)
+	doc.Row(statementColumns, []string{"", "", "Opening balance", "", "", formatAmount(statement.OpeningBalance)}, 9, false)
+	for _, line := range statement.Lines {
+		doc.Row(statementColumns, []string{
+			line.Date.Format(dateLayout),
+			line.Type,
+			line.Description,
+			formatAmount(line.Debit),
+			formatAmount(line.Credit),
+			formatAmount(line.Balance),
+		}, 9, false)
+	}
+	doc.Row(statementColumns, []string{"", "", "Total", formatAmount(statement.TotalDebit), formatAmount(statement.TotalCredit), ""}, 9, true)
+	doc.Space(6)
+	doc.Text("Closing balance: "+formatAmount(statement.ClosingBalance)+" TRY", 10, true)
+	return doc.Bytes()
+}
+
+func formatAmount(amount money.Money) string {
+	return amount.String()
+}
@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	mocks "github.com/pragmataW/apartment_management/controller_mocks"
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"github.com/pragmataW/apartment_management/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testStatement = services.Statement{
+	FlatNo:         4,
+	OwnerName:      "Ayşe Yılmaz",
+	From:           time.Date(2026, 9, 1, 0, 0, 0, 0, time.Local),
+	To:             time.Date(2026, 9, 30, 0, 0, 0, 0, time.Local),
+	OpeningBalance: money.New(100000),
+	TotalDebit:     money.New(100000),
+	TotalCredit:    money.New(150000),
+	ClosingBalance: money.New(50000),
+	Lines: []services.StatementLine{
+		{Date: time.Date(2026, 9, 1, 9, 0, 0, 0, time.Local), Period: "2026-09", Type: "accrual", Description: "dues for 2026-09", Debit: money.New(100000), Balance: money.New(200000)},
+		{Date: time.Date(2026, 9, 10, 14, 0, 0, 0, time.Local), Period: "2026-08", Type: "payment", Description: "payment oid-1", Credit: money.New(150000), Balance: money.New(50000)},
+	},
+}
+
+func TestGetStatementJSON(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetStatement", 4, testStatement.From, testStatement.To).Return(testStatement, nil)
+
+	app := fiber.New()
+	app.Get("/flat/:flatNo/statement", controller.GetStatement)
+
+	req := httptest.NewRequest("GET", "/flat/4/statement?from=2026-09-01&to=2026-09-30", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var respBody dto.StatementResponse
+	err = json.NewDecoder(resp.Body).Decode(&respBody)
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-09-01", respBody.From)
+	assert.Len(t, respBody.Lines, 2)
+	assert.Equal(t, money.New(50000), respBody.ClosingBalance)
+}
+
+func TestGetStatementCSV(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetStatement", 4, mock.Anything, mock.Anything).Return(testStatement, nil)
+
+	app := fiber.New()
+	app.Get("/flat/:flatNo/statement", controller.GetStatement)
+
+	req := httptest.NewRequest("GET", "/flat/4/statement?format=csv", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), "statement-flat-4-2026-09-30.csv")
+
+	rows, err := csv.NewReader(resp.Body).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, rows, 5)
+	assert.Equal(t, []string{"2026-09-10", "2026-08", "payment", "payment oid-1", "0.00", "1500.00", "500.00"}, rows[3])
+	assert.Equal(t, "500.00", rows[4][6])
+}
+
+func TestGetStatementPDF(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetStatement", 4, mock.Anything, mock.Anything).Return(testStatement, nil)
+
+	app := fiber.New()
+	app.Get("/flat/:flatNo/statement", controller.GetStatement)
+
+	req := httptest.NewRequest("GET", "/flat/4/statement?format=pdf", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/pdf", resp.Header.Get("Content-Type"))
+
+	content, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(content, []byte("%PDF-1.4")))
+	assert.True(t, bytes.HasSuffix(content, []byte("%%EOF\n")))
+	assert.Contains(t, string(content), "payment oid-1")
+}
+
+func TestGetStatementBadRequest(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	app := fiber.New()
+	app.Get("/flat/:flatNo/statement", controller.GetStatement)
+
+	req := httptest.NewRequest("GET", "/flat/4/statement?from=01.09.2026", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	mockService.On("GetStatement", 4, mock.Anything, mock.Anything).Return(testStatement, nil)
+	req = httptest.NewRequest("GET", "/flat/4/statement?format=docx", nil)
+	resp, err = app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGetMyStatementNoFlat(t *testing.T) {
+	mockService := new(mocks.IService)
+	controller := NewController(WithService(mockService))
+
+	mockService.On("GetStatementByEmail", "user@mail.com", time.Time{}, time.Time{}).Return(services.Statement{}, dto.ThereIsNoFlat{Message: "there is no flat"})
+
+	app := fiber.New()
+	app.Get("/me/statement", asUser, controller.GetMyStatement)
+
+	req := httptest.NewRequest("GET", "/me/statement", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockService.AssertExpectations(t)
+}
@@ -3,10 +3,16 @@
 package mocks
 
 import (
-	dto "github.com/pragmataW/apartment_management/dto"
+	io "io"
+
+	money "github.com/pragmataW/apartment_management/pkg/money"
 	mock "github.com/stretchr/testify/mock"
 
+	paymentprovider "github.com/pragmataW/apartment_management/pkg/payment_provider"
+
 	services "github.com/pragmataW/apartment_management/services"
+
+	time "time"
 )
 
 // IService is an autogenerated mock type for the IService type
@@ -14,6 +20,34 @@ type IService struct {
 	mock.Mock
 }
 
+// AcceptPaymentReview provides a mock function with given fields: merchantOID, reviewedBy
+func (_m *IService) AcceptPaymentReview(merchantOID string, reviewedBy string) (services.PaymentTransaction, error) {
+	ret := _m.Called(merchantOID, reviewedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptPaymentReview")
+	}
+
+	var r0 services.PaymentTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (services.PaymentTransaction, error)); ok {
+		return rf(merchantOID, reviewedBy)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) services.PaymentTransaction); ok {
+		r0 = rf(merchantOID, reviewedBy)
+	} else {
+		r0 = ret.Get(0).(services.PaymentTransaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(merchantOID, reviewedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AddAnnouncement provides a mock function with given fields: announcement
 func (_m *IService) AddAnnouncement(announcement services.Announcement) error {
 	ret := _m.Called(announcement)
@@ -50,22 +84,63 @@ func (_m *IService) AddDues(flatNo int) error {
 	return r0
 }
 
-// ChangeDuesPrice provides a mock function with given fields: price
-func (_m *IService) ChangeDuesPrice(price float64) {
-	_m.Called(price)
+// AddLedgerAdjustment provides a mock function with given fields: flatNo, amount, note
+func (_m *IService) AddLedgerAdjustment(flatNo int, amount money.Money, note string) error {
+	ret := _m.Called(flatNo, amount, note)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddLedgerAdjustment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, money.Money, string) error); ok {
+		r0 = rf(flatNo, amount, note)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
 }
 
-// ChangePayDay provides a mock function with given fields: payDay
-func (_m *IService) ChangePayDay(payDay int) error {
-	ret := _m.Called(payDay)
+// AddMeterReading provides a mock function with given fields: reading
+func (_m *IService) AddMeterReading(reading services.MeterReading) (services.MeterReading, error) {
+	ret := _m.Called(reading)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ChangePayDay")
+		panic("no return value specified for AddMeterReading")
+	}
+
+	var r0 services.MeterReading
+	var r1 error
+	if rf, ok := ret.Get(0).(func(services.MeterReading) (services.MeterReading, error)); ok {
+		return rf(reading)
+	}
+	if rf, ok := ret.Get(0).(func(services.MeterReading) services.MeterReading); ok {
+		r0 = rf(reading)
+	} else {
+		r0 = ret.Get(0).(services.MeterReading)
+	}
+
+	if rf, ok := ret.Get(1).(func(services.MeterReading) error); ok {
+		r1 = rf(reading)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AttachExpenseReceipt provides a mock function with given fields: id, fileName, content
+func (_m *IService) AttachExpenseReceipt(id int, fileName string, content io.Reader) error {
+	ret := _m.Called(id, fileName, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AttachExpenseReceipt")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(payDay)
+	if rf, ok := ret.Get(0).(func(int, string, io.Reader) error); ok {
+		r0 = rf(id, fileName, content)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -73,17 +148,45 @@ func (_m *IService) ChangePayDay(payDay int) error {
 	return r0
 }
 
-// CreateFlat provides a mock function with given fields: flatNo
-func (_m *IService) CreateFlat(flatNo int) error {
-	ret := _m.Called(flatNo)
+// CancelManualPayment provides a mock function with given fields: receiptNo, cancelledBy, reason
+func (_m *IService) CancelManualPayment(receiptNo int, cancelledBy string, reason string) (services.PaymentReceipt, error) {
+	ret := _m.Called(receiptNo, cancelledBy, reason)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateFlat")
+		panic("no return value specified for CancelManualPayment")
+	}
+
+	var r0 services.PaymentReceipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string, string) (services.PaymentReceipt, error)); ok {
+		return rf(receiptNo, cancelledBy, reason)
+	}
+	if rf, ok := ret.Get(0).(func(int, string, string) services.PaymentReceipt); ok {
+		r0 = rf(receiptNo, cancelledBy, reason)
+	} else {
+		r0 = ret.Get(0).(services.PaymentReceipt)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string, string) error); ok {
+		r1 = rf(receiptNo, cancelledBy, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChangeDuesPrice provides a mock function with given fields: price, changedBy
+func (_m *IService) ChangeDuesPrice(price money.Money, changedBy string) error {
+	ret := _m.Called(price, changedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeDuesPrice")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(flatNo)
+	if rf, ok := ret.Get(0).(func(money.Money, string) error); ok {
+		r0 = rf(price, changedBy)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -91,17 +194,17 @@ func (_m *IService) CreateFlat(flatNo int) error {
 	return r0
 }
 
-// DeleteAllDues provides a mock function with given fields: flatNo
-func (_m *IService) DeleteAllDues(flatNo int) error {
-	ret := _m.Called(flatNo)
+// ChangePayDay provides a mock function with given fields: payDay, changedBy
+func (_m *IService) ChangePayDay(payDay int, changedBy string) error {
+	ret := _m.Called(payDay, changedBy)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteAllDues")
+		panic("no return value specified for ChangePayDay")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(flatNo)
+	if rf, ok := ret.Get(0).(func(int, string) error); ok {
+		r0 = rf(payDay, changedBy)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -109,17 +212,17 @@ func (_m *IService) DeleteAllDues(flatNo int) error {
 	return r0
 }
 
-// DeleteDues provides a mock function with given fields: flatNo
-func (_m *IService) DeleteDues(flatNo int) error {
-	ret := _m.Called(flatNo)
+// ChangePenaltyPolicy provides a mock function with given fields: policy, changedBy
+func (_m *IService) ChangePenaltyPolicy(policy services.PenaltyPolicy, changedBy string) error {
+	ret := _m.Called(policy, changedBy)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteDues")
+		panic("no return value specified for ChangePenaltyPolicy")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(flatNo)
+	if rf, ok := ret.Get(0).(func(services.PenaltyPolicy, string) error); ok {
+		r0 = rf(policy, changedBy)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -127,17 +230,17 @@ func (_m *IService) DeleteDues(flatNo int) error {
 	return r0
 }
 
-// DeleteFlat provides a mock function with given fields: flatNo
-func (_m *IService) DeleteFlat(flatNo int) error {
-	ret := _m.Called(flatNo)
+// ChangeReminderPolicy provides a mock function with given fields: policy, changedBy
+func (_m *IService) ChangeReminderPolicy(policy services.ReminderPolicy, changedBy string) error {
+	ret := _m.Called(policy, changedBy)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteFlat")
+		panic("no return value specified for ChangeReminderPolicy")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(flatNo)
+	if rf, ok := ret.Get(0).(func(services.ReminderPolicy, string) error); ok {
+		r0 = rf(policy, changedBy)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -145,29 +248,27 @@ func (_m *IService) DeleteFlat(flatNo int) error {
 	return r0
 }
 
-// GetAllAnnouncements provides a mock function with given fields:
-func (_m *IService) GetAllAnnouncements() ([]services.Announcement, error) {
-	ret := _m.Called()
+// CreateAssessment provides a mock function with given fields: assessment
+func (_m *IService) CreateAssessment(assessment services.Assessment) (services.Assessment, error) {
+	ret := _m.Called(assessment)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllAnnouncements")
+		panic("no return value specified for CreateAssessment")
 	}
 
-	var r0 []services.Announcement
+	var r0 services.Assessment
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]services.Announcement, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(services.Assessment) (services.Assessment, error)); ok {
+		return rf(assessment)
 	}
-	if rf, ok := ret.Get(0).(func() []services.Announcement); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(services.Assessment) services.Assessment); ok {
+		r0 = rf(assessment)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]services.Announcement)
-		}
+		r0 = ret.Get(0).(services.Assessment)
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(services.Assessment) error); ok {
+		r1 = rf(assessment)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -175,29 +276,27 @@ func (_m *IService) GetAllAnnouncements() ([]services.Announcement, error) {
 	return r0, r1
 }
 
-// GetAllInfoAboutAllFlat provides a mock function with given fields:
-func (_m *IService) GetAllInfoAboutAllFlat() ([]services.Apartment, error) {
-	ret := _m.Called()
+// CreateExpense provides a mock function with given fields: expense
+func (_m *IService) CreateExpense(expense services.Expense) (services.Expense, error) {
+	ret := _m.Called(expense)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllInfoAboutAllFlat")
+		panic("no return value specified for CreateExpense")
 	}
 
-	var r0 []services.Apartment
+	var r0 services.Expense
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]services.Apartment, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(services.Expense) (services.Expense, error)); ok {
+		return rf(expense)
 	}
-	if rf, ok := ret.Get(0).(func() []services.Apartment); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(services.Expense) services.Expense); ok {
+		r0 = rf(expense)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]services.Apartment)
-		}
+		r0 = ret.Get(0).(services.Expense)
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(services.Expense) error); ok {
+		r1 = rf(expense)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -205,27 +304,27 @@ func (_m *IService) GetAllInfoAboutAllFlat() ([]services.Apartment, error) {
 	return r0, r1
 }
 
-// GetAllInfoAboutFlat provides a mock function with given fields: flatNo
-func (_m *IService) GetAllInfoAboutFlat(flatNo int) (services.Apartment, error) {
-	ret := _m.Called(flatNo)
+// CreateExpenseCategory provides a mock function with given fields: name
+func (_m *IService) CreateExpenseCategory(name string) (services.ExpenseCategory, error) {
+	ret := _m.Called(name)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllInfoAboutFlat")
+		panic("no return value specified for CreateExpenseCategory")
 	}
 
-	var r0 services.Apartment
+	var r0 services.ExpenseCategory
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) (services.Apartment, error)); ok {
-		return rf(flatNo)
+	if rf, ok := ret.Get(0).(func(string) (services.ExpenseCategory, error)); ok {
+		return rf(name)
 	}
-	if rf, ok := ret.Get(0).(func(int) services.Apartment); ok {
-		r0 = rf(flatNo)
+	if rf, ok := ret.Get(0).(func(string) services.ExpenseCategory); ok {
+		r0 = rf(name)
 	} else {
-		r0 = ret.Get(0).(services.Apartment)
+		r0 = ret.Get(0).(services.ExpenseCategory)
 	}
 
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(flatNo)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -233,27 +332,45 @@ func (_m *IService) GetAllInfoAboutFlat(flatNo int) (services.Apartment, error)
 	return r0, r1
 }
 
-// GetPaymentToken provides a mock function with given fields: payment
-func (_m *IService) GetPaymentToken(payment dto.PaymentSendReq) (string, error) {
-	ret := _m.Called(payment)
+// CreateFlat provides a mock function with given fields: flatNo
+func (_m *IService) CreateFlat(flatNo int) error {
+	ret := _m.Called(flatNo)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPaymentToken")
+		panic("no return value specified for CreateFlat")
 	}
 
-	var r0 string
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(flatNo)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateMeter provides a mock function with given fields: meter
+func (_m *IService) CreateMeter(meter services.Meter) (services.Meter, error) {
+	ret := _m.Called(meter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMeter")
+	}
+
+	var r0 services.Meter
 	var r1 error
-	if rf, ok := ret.Get(0).(func(dto.PaymentSendReq) (string, error)); ok {
-		return rf(payment)
+	if rf, ok := ret.Get(0).(func(services.Meter) (services.Meter, error)); ok {
+		return rf(meter)
 	}
-	if rf, ok := ret.Get(0).(func(dto.PaymentSendReq) string); ok {
-		r0 = rf(payment)
+	if rf, ok := ret.Get(0).(func(services.Meter) services.Meter); ok {
+		r0 = rf(meter)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(services.Meter)
 	}
 
-	if rf, ok := ret.Get(1).(func(dto.PaymentSendReq) error); ok {
-		r1 = rf(payment)
+	if rf, ok := ret.Get(1).(func(services.Meter) error); ok {
+		r1 = rf(meter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -261,45 +378,55 @@ func (_m *IService) GetPaymentToken(payment dto.PaymentSendReq) (string, error)
 	return r0, r1
 }
 
-// IncreaseDuesAutomatically provides a mock function with given fields:
-func (_m *IService) IncreaseDuesAutomatically() error {
-	ret := _m.Called()
+// CreateReferenceRule provides a mock function with given fields: rule
+func (_m *IService) CreateReferenceRule(rule services.ReferenceRule) (services.ReferenceRule, error) {
+	ret := _m.Called(rule)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IncreaseDuesAutomatically")
+		panic("no return value specified for CreateReferenceRule")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func() error); ok {
-		r0 = rf()
+	var r0 services.ReferenceRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(services.ReferenceRule) (services.ReferenceRule, error)); ok {
+		return rf(rule)
+	}
+	if rf, ok := ret.Get(0).(func(services.ReferenceRule) services.ReferenceRule); ok {
+		r0 = rf(rule)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(services.ReferenceRule)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(services.ReferenceRule) error); ok {
+		r1 = rf(rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// LoginAdmin provides a mock function with given fields: password
-func (_m *IService) LoginAdmin(password string) (string, error) {
-	ret := _m.Called(password)
+// CreateUtilityBill provides a mock function with given fields: bill
+func (_m *IService) CreateUtilityBill(bill services.UtilityBill) (services.UtilityBill, error) {
+	ret := _m.Called(bill)
 
 	if len(ret) == 0 {
-		panic("no return value specified for LoginAdmin")
+		panic("no return value specified for CreateUtilityBill")
 	}
 
-	var r0 string
+	var r0 services.UtilityBill
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(password)
+	if rf, ok := ret.Get(0).(func(services.UtilityBill) (services.UtilityBill, error)); ok {
+		return rf(bill)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(password)
+	if rf, ok := ret.Get(0).(func(services.UtilityBill) services.UtilityBill); ok {
+		r0 = rf(bill)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(services.UtilityBill)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(password)
+	if rf, ok := ret.Get(1).(func(services.UtilityBill) error); ok {
+		r1 = rf(bill)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -307,27 +434,27 @@ func (_m *IService) LoginAdmin(password string) (string, error) {
 	return r0, r1
 }
 
-// LoginUser provides a mock function with given fields: flatNo, mail, password
-func (_m *IService) LoginUser(flatNo int, mail string, password string) (string, error) {
-	ret := _m.Called(flatNo, mail, password)
+// CreateVendor provides a mock function with given fields: vendor
+func (_m *IService) CreateVendor(vendor services.Vendor) (services.Vendor, error) {
+	ret := _m.Called(vendor)
 
 	if len(ret) == 0 {
-		panic("no return value specified for LoginUser")
+		panic("no return value specified for CreateVendor")
 	}
 
-	var r0 string
+	var r0 services.Vendor
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int, string, string) (string, error)); ok {
-		return rf(flatNo, mail, password)
+	if rf, ok := ret.Get(0).(func(services.Vendor) (services.Vendor, error)); ok {
+		return rf(vendor)
 	}
-	if rf, ok := ret.Get(0).(func(int, string, string) string); ok {
-		r0 = rf(flatNo, mail, password)
+	if rf, ok := ret.Get(0).(func(services.Vendor) services.Vendor); ok {
+		r0 = rf(vendor)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(services.Vendor)
 	}
 
-	if rf, ok := ret.Get(1).(func(int, string, string) error); ok {
-		r1 = rf(flatNo, mail, password)
+	if rf, ok := ret.Get(1).(func(services.Vendor) error); ok {
+		r1 = rf(vendor)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -335,17 +462,17 @@ func (_m *IService) LoginUser(flatNo int, mail string, password string) (string,
 	return r0, r1
 }
 
-// PaymentCallback provides a mock function with given fields: merchantOID
-func (_m *IService) PaymentCallback(merchantOID string) error {
-	ret := _m.Called(merchantOID)
+// DeleteDues provides a mock function with given fields: flatNo
+func (_m *IService) DeleteDues(flatNo int) error {
+	ret := _m.Called(flatNo)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PaymentCallback")
+		panic("no return value specified for DeleteDues")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(merchantOID)
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(flatNo)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -353,17 +480,17 @@ func (_m *IService) PaymentCallback(merchantOID string) error {
 	return r0
 }
 
-// SendMail provides a mock function with given fields: subject, body, mail
-func (_m *IService) SendMail(subject string, body string, mail string) error {
-	ret := _m.Called(subject, body, mail)
+// DeleteExpense provides a mock function with given fields: id
+func (_m *IService) DeleteExpense(id int) error {
+	ret := _m.Called(id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SendMail")
+		panic("no return value specified for DeleteExpense")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
-		r0 = rf(subject, body, mail)
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -371,17 +498,1712 @@ func (_m *IService) SendMail(subject string, body string, mail string) error {
 	return r0
 }
 
-// UpdateFlatOwner provides a mock function with given fields: apartment
-func (_m *IService) UpdateFlatOwner(apartment services.Apartment) error {
-	ret := _m.Called(apartment)
+// DeleteExpenseCategory provides a mock function with given fields: id
+func (_m *IService) DeleteExpenseCategory(id int) error {
+	ret := _m.Called(id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateFlatOwner")
+		panic("no return value specified for DeleteExpenseCategory")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(services.Apartment) error); ok {
-		r0 = rf(apartment)
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteFlat provides a mock function with given fields: flatNo
+func (_m *IService) DeleteFlat(flatNo int) error {
+	ret := _m.Called(flatNo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFlat")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(flatNo)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteMeter provides a mock function with given fields: id
+func (_m *IService) DeleteMeter(id int) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMeter")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteReferenceRule provides a mock function with given fields: id
+func (_m *IService) DeleteReferenceRule(id int) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteReferenceRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteVendor provides a mock function with given fields: id
+func (_m *IService) DeleteVendor(id int) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteVendor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAccrualRuns provides a mock function with given fields:
+func (_m *IService) GetAccrualRuns() ([]services.AccrualRun, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccrualRuns")
+	}
+
+	var r0 []services.AccrualRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]services.AccrualRun, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []services.AccrualRun); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.AccrualRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAgingReport provides a mock function with given fields: filter
+func (_m *IService) GetAgingReport(filter services.AgingFilter) (services.AgingReport, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAgingReport")
+	}
+
+	var r0 services.AgingReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(services.AgingFilter) (services.AgingReport, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(services.AgingFilter) services.AgingReport); ok {
+		r0 = rf(filter)
+	} else {
+		r0 = ret.Get(0).(services.AgingReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(services.AgingFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllAnnouncements provides a mock function with given fields:
+func (_m *IService) GetAllAnnouncements() ([]services.Announcement, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllAnnouncements")
+	}
+
+	var r0 []services.Announcement
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]services.Announcement, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []services.Announcement); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.Announcement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllInfoAboutAllFlat provides a mock function with given fields:
+func (_m *IService) GetAllInfoAboutAllFlat() ([]services.Apartment, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllInfoAboutAllFlat")
+	}
+
+	var r0 []services.Apartment
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]services.Apartment, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []services.Apartment); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.Apartment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllInfoAboutFlat provides a mock function with given fields: flatNo
+func (_m *IService) GetAllInfoAboutFlat(flatNo int) (services.Apartment, error) {
+	ret := _m.Called(flatNo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllInfoAboutFlat")
+	}
+
+	var r0 services.Apartment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (services.Apartment, error)); ok {
+		return rf(flatNo)
+	}
+	if rf, ok := ret.Get(0).(func(int) services.Apartment); ok {
+		r0 = rf(flatNo)
+	} else {
+		r0 = ret.Get(0).(services.Apartment)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(flatNo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAssessmentCharges provides a mock function with given fields: assessmentID
+func (_m *IService) GetAssessmentCharges(assessmentID int) ([]services.AssessmentCharge, error) {
+	ret := _m.Called(assessmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAssessmentCharges")
+	}
+
+	var r0 []services.AssessmentCharge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]services.AssessmentCharge, error)); ok {
+		return rf(assessmentID)
+	}
+	if rf, ok := ret.Get(0).(func(int) []services.AssessmentCharge); ok {
+		r0 = rf(assessmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.AssessmentCharge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(assessmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAssessments provides a mock function with given fields:
+func (_m *IService) GetAssessments() ([]services.Assessment, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAssessments")
+	}
+
+	var r0 []services.Assessment
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]services.Assessment, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []services.Assessment); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.Assessment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBalanceByEmail provides a mock function with given fields: email
+func (_m *IService) GetBalanceByEmail(email string) (services.AccountBalance, error) {
+	ret := _m.Called(email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalanceByEmail")
+	}
+
+	var r0 services.AccountBalance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (services.AccountBalance, error)); ok {
+		return rf(email)
+	}
+	if rf, ok := ret.Get(0).(func(string) services.AccountBalance); ok {
+		r0 = rf(email)
+	} else {
+		r0 = ret.Get(0).(services.AccountBalance)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBankImports provides a mock function with given fields:
+func (_m *IService) GetBankImports() ([]services.BankImport, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBankImports")
+	}
+
+	var r0 []services.BankImport
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]services.BankImport, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []services.BankImport); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.BankImport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBankTransactions provides a mock function with given fields: status
+func (_m *IService) GetBankTransactions(status string) ([]services.BankTransaction, error) {
+	ret := _m.Called(status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBankTransactions")
+	}
+
+	var r0 []services.BankTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]services.BankTransaction, error)); ok {
+		return rf(status)
+	}
+	if rf, ok := ret.Get(0).(func(string) []services.BankTransaction); ok {
+		r0 = rf(status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.BankTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDuesPrice provides a mock function with given fields:
+func (_m *IService) GetDuesPrice() (services.DuesPrice, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDuesPrice")
+	}
+
+	var r0 services.DuesPrice
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (services.DuesPrice, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() services.DuesPrice); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(services.DuesPrice)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDuesReminders provides a mock function with given fields: flatNo
+func (_m *IService) GetDuesReminders(flatNo int) ([]services.DuesReminder, error) {
+	ret := _m.Called(flatNo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDuesReminders")
+	}
+
+	var r0 []services.DuesReminder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]services.DuesReminder, error)); ok {
+		return rf(flatNo)
+	}
+	if rf, ok := ret.Get(0).(func(int) []services.DuesReminder); ok {
+		r0 = rf(flatNo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.DuesReminder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(flatNo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExpense provides a mock function with given fields: id
+func (_m *IService) GetExpense(id int) (services.Expense, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExpense")
+	}
+
+	var r0 services.Expense
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (services.Expense, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) services.Expense); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(services.Expense)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExpenseCategories provides a mock function with given fields:
+func (_m *IService) GetExpenseCategories() ([]services.ExpenseCategory, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExpenseCategories")
+	}
+
+	var r0 []services.ExpenseCategory
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]services.ExpenseCategory, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []services.ExpenseCategory); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.ExpenseCategory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExpenseReceipt provides a mock function with given fields: id
+func (_m *IService) GetExpenseReceipt(id int) (string, string, io.ReadCloser, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExpenseReceipt")
+	}
+
+	var r0 string
+	var r1 string
+	var r2 io.ReadCloser
+	var r3 error
+	if rf, ok := ret.Get(0).(func(int) (string, string, io.ReadCloser, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) string); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) string); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(int) io.ReadCloser); ok {
+		r2 = rf(id)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(3).(func(int) error); ok {
+		r3 = rf(id)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+// GetExpenses provides a mock function with given fields: filter
+func (_m *IService) GetExpenses(filter services.ExpenseFilter) ([]services.Expense, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExpenses")
+	}
+
+	var r0 []services.Expense
+	var r1 error
+	if rf, ok := ret.Get(0).(func(services.ExpenseFilter) ([]services.Expense, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(services.ExpenseFilter) []services.Expense); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.Expense)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(services.ExpenseFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFinancialReport provides a mock function with given fields: period
+func (_m *IService) GetFinancialReport(period string) (services.FinancialReport, error) {
+	ret := _m.Called(period)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFinancialReport")
+	}
+
+	var r0 services.FinancialReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (services.FinancialReport, error)); ok {
+		return rf(period)
+	}
+	if rf, ok := ret.Get(0).(func(string) services.FinancialReport); ok {
+		r0 = rf(period)
+	} else {
+		r0 = ret.Get(0).(services.FinancialReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(period)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLedger provides a mock function with given fields: flatNo
+func (_m *IService) GetLedger(flatNo int) (services.Ledger, error) {
+	ret := _m.Called(flatNo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLedger")
+	}
+
+	var r0 services.Ledger
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (services.Ledger, error)); ok {
+		return rf(flatNo)
+	}
+	if rf, ok := ret.Get(0).(func(int) services.Ledger); ok {
+		r0 = rf(flatNo)
+	} else {
+		r0 = ret.Get(0).(services.Ledger)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(flatNo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMeterReadings provides a mock function with given fields: meterID
+func (_m *IService) GetMeterReadings(meterID int) ([]services.MeterReading, error) {
+	ret := _m.Called(meterID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMeterReadings")
+	}
+
+	var r0 []services.MeterReading
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]services.MeterReading, error)); ok {
+		return rf(meterID)
+	}
+	if rf, ok := ret.Get(0).(func(int) []services.MeterReading); ok {
+		r0 = rf(meterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.MeterReading)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(meterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMeters provides a mock function with given fields: flatNo
+func (_m *IService) GetMeters(flatNo int) ([]services.Meter, error) {
+	ret := _m.Called(flatNo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMeters")
+	}
+
+	var r0 []services.Meter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]services.Meter, error)); ok {
+		return rf(flatNo)
+	}
+	if rf, ok := ret.Get(0).(func(int) []services.Meter); ok {
+		r0 = rf(flatNo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.Meter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(flatNo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPayDay provides a mock function with given fields:
+func (_m *IService) GetPayDay() (int, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPayDay")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (int, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaymentAmount provides a mock function with given fields: email, months, payAll
+func (_m *IService) GetPaymentAmount(email string, months int, payAll bool) (money.Money, error) {
+	ret := _m.Called(email, months, payAll)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentAmount")
+	}
+
+	var r0 money.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int, bool) (money.Money, error)); ok {
+		return rf(email, months, payAll)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, bool) money.Money); ok {
+		r0 = rf(email, months, payAll)
+	} else {
+		r0 = ret.Get(0).(money.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, bool) error); ok {
+		r1 = rf(email, months, payAll)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaymentReceipt provides a mock function with given fields: receiptNo
+func (_m *IService) GetPaymentReceipt(receiptNo int) (services.PaymentReceipt, error) {
+	ret := _m.Called(receiptNo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentReceipt")
+	}
+
+	var r0 services.PaymentReceipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (services.PaymentReceipt, error)); ok {
+		return rf(receiptNo)
+	}
+	if rf, ok := ret.Get(0).(func(int) services.PaymentReceipt); ok {
+		r0 = rf(receiptNo)
+	} else {
+		r0 = ret.Get(0).(services.PaymentReceipt)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(receiptNo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaymentReceipts provides a mock function with given fields: flatNo
+func (_m *IService) GetPaymentReceipts(flatNo int) ([]services.PaymentReceipt, error) {
+	ret := _m.Called(flatNo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentReceipts")
+	}
+
+	var r0 []services.PaymentReceipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]services.PaymentReceipt, error)); ok {
+		return rf(flatNo)
+	}
+	if rf, ok := ret.Get(0).(func(int) []services.PaymentReceipt); ok {
+		r0 = rf(flatNo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.PaymentReceipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(flatNo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaymentRefunds provides a mock function with given fields: merchantOID
+func (_m *IService) GetPaymentRefunds(merchantOID string) ([]services.PaymentRefund, error) {
+	ret := _m.Called(merchantOID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentRefunds")
+	}
+
+	var r0 []services.PaymentRefund
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]services.PaymentRefund, error)); ok {
+		return rf(merchantOID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []services.PaymentRefund); ok {
+		r0 = rf(merchantOID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.PaymentRefund)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(merchantOID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaymentToken provides a mock function with given fields: payment
+func (_m *IService) GetPaymentToken(payment paymentprovider.Payment) (string, error) {
+	ret := _m.Called(payment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentToken")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(paymentprovider.Payment) (string, error)); ok {
+		return rf(payment)
+	}
+	if rf, ok := ret.Get(0).(func(paymentprovider.Payment) string); ok {
+		r0 = rf(payment)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(paymentprovider.Payment) error); ok {
+		r1 = rf(payment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaymentTransaction provides a mock function with given fields: merchantOID
+func (_m *IService) GetPaymentTransaction(merchantOID string) (services.PaymentTransaction, error) {
+	ret := _m.Called(merchantOID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentTransaction")
+	}
+
+	var r0 services.PaymentTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (services.PaymentTransaction, error)); ok {
+		return rf(merchantOID)
+	}
+	if rf, ok := ret.Get(0).(func(string) services.PaymentTransaction); ok {
+		r0 = rf(merchantOID)
+	} else {
+		r0 = ret.Get(0).(services.PaymentTransaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(merchantOID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaymentTransactions provides a mock function with given fields: filter
+func (_m *IService) GetPaymentTransactions(filter services.PaymentTransactionFilter) ([]services.PaymentTransaction, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentTransactions")
+	}
+
+	var r0 []services.PaymentTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(services.PaymentTransactionFilter) ([]services.PaymentTransaction, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(services.PaymentTransactionFilter) []services.PaymentTransaction); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.PaymentTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(services.PaymentTransactionFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPenaltyPolicy provides a mock function with given fields:
+func (_m *IService) GetPenaltyPolicy() (services.PenaltyPolicy, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPenaltyPolicy")
+	}
+
+	var r0 services.PenaltyPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (services.PenaltyPolicy, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() services.PenaltyPolicy); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(services.PenaltyPolicy)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReceiptMails provides a mock function with given fields: status
+func (_m *IService) GetReceiptMails(status string) ([]services.ReceiptMail, error) {
+	ret := _m.Called(status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReceiptMails")
+	}
+
+	var r0 []services.ReceiptMail
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]services.ReceiptMail, error)); ok {
+		return rf(status)
+	}
+	if rf, ok := ret.Get(0).(func(string) []services.ReceiptMail); ok {
+		r0 = rf(status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.ReceiptMail)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReferenceRules provides a mock function with given fields:
+func (_m *IService) GetReferenceRules() ([]services.ReferenceRule, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReferenceRules")
+	}
+
+	var r0 []services.ReferenceRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]services.ReferenceRule, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []services.ReferenceRule); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.ReferenceRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReminderPolicy provides a mock function with given fields:
+func (_m *IService) GetReminderPolicy() (services.ReminderPolicy, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReminderPolicy")
+	}
+
+	var r0 services.ReminderPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (services.ReminderPolicy, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() services.ReminderPolicy); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(services.ReminderPolicy)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetResidentDues provides a mock function with given fields: flatNo, email
+func (_m *IService) GetResidentDues(flatNo int, email string) ([]services.OpenCharge, money.Money, error) {
+	ret := _m.Called(flatNo, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetResidentDues")
+	}
+
+	var r0 []services.OpenCharge
+	var r1 money.Money
+	var r2 error
+	if rf, ok := ret.Get(0).(func(int, string) ([]services.OpenCharge, money.Money, error)); ok {
+		return rf(flatNo, email)
+	}
+	if rf, ok := ret.Get(0).(func(int, string) []services.OpenCharge); ok {
+		r0 = rf(flatNo, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.OpenCharge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string) money.Money); ok {
+		r1 = rf(flatNo, email)
+	} else {
+		r1 = ret.Get(1).(money.Money)
+	}
+
+	if rf, ok := ret.Get(2).(func(int, string) error); ok {
+		r2 = rf(flatNo, email)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetResidentFlat provides a mock function with given fields: flatNo, email
+func (_m *IService) GetResidentFlat(flatNo int, email string) (services.Apartment, error) {
+	ret := _m.Called(flatNo, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetResidentFlat")
+	}
+
+	var r0 services.Apartment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string) (services.Apartment, error)); ok {
+		return rf(flatNo, email)
+	}
+	if rf, ok := ret.Get(0).(func(int, string) services.Apartment); ok {
+		r0 = rf(flatNo, email)
+	} else {
+		r0 = ret.Get(0).(services.Apartment)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string) error); ok {
+		r1 = rf(flatNo, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetResidentPayments provides a mock function with given fields: flatNo, email
+func (_m *IService) GetResidentPayments(flatNo int, email string) ([]services.LedgerEntry, error) {
+	ret := _m.Called(flatNo, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetResidentPayments")
+	}
+
+	var r0 []services.LedgerEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string) ([]services.LedgerEntry, error)); ok {
+		return rf(flatNo, email)
+	}
+	if rf, ok := ret.Get(0).(func(int, string) []services.LedgerEntry); ok {
+		r0 = rf(flatNo, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.LedgerEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string) error); ok {
+		r1 = rf(flatNo, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetScheduledJobs provides a mock function with given fields:
+func (_m *IService) GetScheduledJobs() []services.ScheduledJob {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetScheduledJobs")
+	}
+
+	var r0 []services.ScheduledJob
+	if rf, ok := ret.Get(0).(func() []services.ScheduledJob); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.ScheduledJob)
+		}
+	}
+
+	return r0
+}
+
+// GetSettingHistory provides a mock function with given fields: key
+func (_m *IService) GetSettingHistory(key string) ([]services.SettingChange, error) {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSettingHistory")
+	}
+
+	var r0 []services.SettingChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]services.SettingChange, error)); ok {
+		return rf(key)
+	}
+	if rf, ok := ret.Get(0).(func(string) []services.SettingChange); ok {
+		r0 = rf(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.SettingChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatement provides a mock function with given fields: flatNo, from, to
+func (_m *IService) GetStatement(flatNo int, from time.Time, to time.Time) (services.Statement, error) {
+	ret := _m.Called(flatNo, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatement")
+	}
+
+	var r0 services.Statement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, time.Time, time.Time) (services.Statement, error)); ok {
+		return rf(flatNo, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(int, time.Time, time.Time) services.Statement); ok {
+		r0 = rf(flatNo, from, to)
+	} else {
+		r0 = ret.Get(0).(services.Statement)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, time.Time, time.Time) error); ok {
+		r1 = rf(flatNo, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatementByEmail provides a mock function with given fields: email, from, to
+func (_m *IService) GetStatementByEmail(email string, from time.Time, to time.Time) (services.Statement, error) {
+	ret := _m.Called(email, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatementByEmail")
+	}
+
+	var r0 services.Statement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time) (services.Statement, error)); ok {
+		return rf(email, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time) services.Statement); ok {
+		r0 = rf(email, from, to)
+	} else {
+		r0 = ret.Get(0).(services.Statement)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time, time.Time) error); ok {
+		r1 = rf(email, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUtilityBill provides a mock function with given fields: id
+func (_m *IService) GetUtilityBill(id int) (services.UtilityBill, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUtilityBill")
+	}
+
+	var r0 services.UtilityBill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (services.UtilityBill, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) services.UtilityBill); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(services.UtilityBill)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUtilityBills provides a mock function with given fields:
+func (_m *IService) GetUtilityBills() ([]services.UtilityBill, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUtilityBills")
+	}
+
+	var r0 []services.UtilityBill
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]services.UtilityBill, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []services.UtilityBill); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.UtilityBill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetVendors provides a mock function with given fields:
+func (_m *IService) GetVendors() ([]services.Vendor, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVendors")
+	}
+
+	var r0 []services.Vendor
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]services.Vendor, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []services.Vendor); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.Vendor)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IgnoreBankTransaction provides a mock function with given fields: id, resolvedBy
+func (_m *IService) IgnoreBankTransaction(id int, resolvedBy string) (services.BankTransaction, error) {
+	ret := _m.Called(id, resolvedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IgnoreBankTransaction")
+	}
+
+	var r0 services.BankTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string) (services.BankTransaction, error)); ok {
+		return rf(id, resolvedBy)
+	}
+	if rf, ok := ret.Get(0).(func(int, string) services.BankTransaction); ok {
+		r0 = rf(id, resolvedBy)
+	} else {
+		r0 = ret.Get(0).(services.BankTransaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string) error); ok {
+		r1 = rf(id, resolvedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportBankStatement provides a mock function with given fields: statement
+func (_m *IService) ImportBankStatement(statement services.BankStatement) (services.BankImport, error) {
+	ret := _m.Called(statement)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportBankStatement")
+	}
+
+	var r0 services.BankImport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(services.BankStatement) (services.BankImport, error)); ok {
+		return rf(statement)
+	}
+	if rf, ok := ret.Get(0).(func(services.BankStatement) services.BankImport); ok {
+		r0 = rf(statement)
+	} else {
+		r0 = ret.Get(0).(services.BankImport)
+	}
+
+	if rf, ok := ret.Get(1).(func(services.BankStatement) error); ok {
+		r1 = rf(statement)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportMeterReadings provides a mock function with given fields: content, importedBy
+func (_m *IService) ImportMeterReadings(content io.Reader, importedBy string) (int, error) {
+	ret := _m.Called(content, importedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportMeterReadings")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(io.Reader, string) (int, error)); ok {
+		return rf(content, importedBy)
+	}
+	if rf, ok := ret.Get(0).(func(io.Reader, string) int); ok {
+		r0 = rf(content, importedBy)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(io.Reader, string) error); ok {
+		r1 = rf(content, importedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginAdmin provides a mock function with given fields: password
+func (_m *IService) LoginAdmin(password string) (string, error) {
+	ret := _m.Called(password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoginAdmin")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(password)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(password)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginUser provides a mock function with given fields: flatNo, mail, password
+func (_m *IService) LoginUser(flatNo int, mail string, password string) (string, error) {
+	ret := _m.Called(flatNo, mail, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoginUser")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string, string) (string, error)); ok {
+		return rf(flatNo, mail, password)
+	}
+	if rf, ok := ret.Get(0).(func(int, string, string) string); ok {
+		r0 = rf(flatNo, mail, password)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string, string) error); ok {
+		r1 = rf(flatNo, mail, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MatchBankTransaction provides a mock function with given fields: id, flatNo, resolvedBy
+func (_m *IService) MatchBankTransaction(id int, flatNo int, resolvedBy string) (services.BankTransaction, error) {
+	ret := _m.Called(id, flatNo, resolvedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MatchBankTransaction")
+	}
+
+	var r0 services.BankTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int, string) (services.BankTransaction, error)); ok {
+		return rf(id, flatNo, resolvedBy)
+	}
+	if rf, ok := ret.Get(0).(func(int, int, string) services.BankTransaction); ok {
+		r0 = rf(id, flatNo, resolvedBy)
+	} else {
+		r0 = ret.Get(0).(services.BankTransaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int, string) error); ok {
+		r1 = rf(id, flatNo, resolvedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PaymentCallback provides a mock function with given fields: form
+func (_m *IService) PaymentCallback(form map[string]string) error {
+	ret := _m.Called(form)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PaymentCallback")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]string) error); ok {
+		r0 = rf(form)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecordManualPayment provides a mock function with given fields: receipt
+func (_m *IService) RecordManualPayment(receipt services.PaymentReceipt) (services.PaymentReceipt, error) {
+	ret := _m.Called(receipt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordManualPayment")
+	}
+
+	var r0 services.PaymentReceipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(services.PaymentReceipt) (services.PaymentReceipt, error)); ok {
+		return rf(receipt)
+	}
+	if rf, ok := ret.Get(0).(func(services.PaymentReceipt) services.PaymentReceipt); ok {
+		r0 = rf(receipt)
+	} else {
+		r0 = ret.Get(0).(services.PaymentReceipt)
+	}
+
+	if rf, ok := ret.Get(1).(func(services.PaymentReceipt) error); ok {
+		r1 = rf(receipt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefundPayment provides a mock function with given fields: merchantOID, amount, reason, refundedBy
+func (_m *IService) RefundPayment(merchantOID string, amount money.Money, reason string, refundedBy string) (services.PaymentTransaction, error) {
+	ret := _m.Called(merchantOID, amount, reason, refundedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefundPayment")
+	}
+
+	var r0 services.PaymentTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, money.Money, string, string) (services.PaymentTransaction, error)); ok {
+		return rf(merchantOID, amount, reason, refundedBy)
+	}
+	if rf, ok := ret.Get(0).(func(string, money.Money, string, string) services.PaymentTransaction); ok {
+		r0 = rf(merchantOID, amount, reason, refundedBy)
+	} else {
+		r0 = ret.Get(0).(services.PaymentTransaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, money.Money, string, string) error); ok {
+		r1 = rf(merchantOID, amount, reason, refundedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetryReceiptMail provides a mock function with given fields: id
+func (_m *IService) RetryReceiptMail(id int) (services.ReceiptMail, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryReceiptMail")
+	}
+
+	var r0 services.ReceiptMail
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (services.ReceiptMail, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) services.ReceiptMail); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(services.ReceiptMail)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SendDuesReminders provides a mock function with given fields: now
+func (_m *IService) SendDuesReminders(now time.Time) (int, error) {
+	ret := _m.Called(now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendDuesReminders")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (int, error)); ok {
+		return rf(now)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) int); ok {
+		r0 = rf(now)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SendMail provides a mock function with given fields: subject, body, mail
+func (_m *IService) SendMail(subject string, body string, mail string) error {
+	ret := _m.Called(subject, body, mail)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(subject, body, mail)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateDuesProfile provides a mock function with given fields: apartment
+func (_m *IService) UpdateDuesProfile(apartment services.Apartment) error {
+	ret := _m.Called(apartment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDuesProfile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(services.Apartment) error); ok {
+		r0 = rf(apartment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateExpense provides a mock function with given fields: expense
+func (_m *IService) UpdateExpense(expense services.Expense) error {
+	ret := _m.Called(expense)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateExpense")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(services.Expense) error); ok {
+		r0 = rf(expense)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateFlatOwner provides a mock function with given fields: apartment
+func (_m *IService) UpdateFlatOwner(apartment services.Apartment) error {
+	ret := _m.Called(apartment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateFlatOwner")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(services.Apartment) error); ok {
+		r0 = rf(apartment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateVendor provides a mock function with given fields: vendor
+func (_m *IService) UpdateVendor(vendor services.Vendor) error {
+	ret := _m.Called(vendor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateVendor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(services.Vendor) error); ok {
+		r0 = rf(vendor)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1,9 +1,22 @@
 package dto
 
-import "sync"
+import "github.com/pragmataW/apartment_management/pkg/money"
+
+// Defaults used until an admin stores a value in the settings table.
+const (
+	DefaultPayDay = 15
+
+	// Late fees stay off until a rate or a fee is configured.
+	DefaultPenaltyGraceDays = 10
+	DefaultPenaltyRate      = 0.0
+
+	// Reminders stay off until they are enabled.
+	DefaultRemindersEnabled     = false
+	DefaultReminderDays         = 0
+	DefaultReminderIntervalDays = 7
+)
 
 var (
-	Mutx      sync.Mutex
-	DuesPrice = 40.0
-	PayDay    = 15
-)
\ No newline at end of file
+	DefaultDuesPrice  = money.New(4000)
+	DefaultPenaltyFee = money.New(0)
+)
@@ -22,4 +22,147 @@ type ThereIsNoDues struct{
 
 func (e ThereIsNoDues) Error() string {
 	return e.Message
-}
\ No newline at end of file
+}
+type ThereIsNoSetting struct{
+	Message string
+}
+
+func (e ThereIsNoSetting) Error() string {
+	return e.Message
+}
+
+type PeriodAlreadyAccrued struct{
+	Message string
+}
+
+func (e PeriodAlreadyAccrued) Error() string {
+	return e.Message
+}
+
+type ThereIsNoAccrualRun struct{
+	Message string
+}
+
+func (e ThereIsNoAccrualRun) Error() string {
+	return e.Message
+}
+
+type ThereIsNoExpense struct{
+	Message string
+}
+
+func (e ThereIsNoExpense) Error() string {
+	return e.Message
+}
+
+type ThereIsNoReceipt struct{
+	Message string
+}
+
+func (e ThereIsNoReceipt) Error() string {
+	return e.Message
+}
+
+type ReceiptAlreadyCancelled struct{
+	Message string
+}
+
+func (e ReceiptAlreadyCancelled) Error() string {
+	return e.Message
+}
+
+type ThereIsNoBankTransaction struct{
+	Message string
+}
+
+func (e ThereIsNoBankTransaction) Error() string {
+	return e.Message
+}
+
+type BankTransactionResolved struct{
+	Message string
+}
+
+func (e BankTransactionResolved) Error() string {
+	return e.Message
+}
+
+type ForeignCurrencyTransfer struct{
+	Message string
+}
+
+func (e ForeignCurrencyTransfer) Error() string {
+	return e.Message
+}
+
+type ThereIsNoReferenceRule struct{
+	Message string
+}
+
+func (e ThereIsNoReferenceRule) Error() string {
+	return e.Message
+}
+
+type ThereIsNoMeter struct{
+	Message string
+}
+
+func (e ThereIsNoMeter) Error() string {
+	return e.Message
+}
+
+type ThereIsNoUtilityBill struct{
+	Message string
+}
+
+func (e ThereIsNoUtilityBill) Error() string {
+	return e.Message
+}
+
+type ThereIsNoReceiptMail struct{
+	Message string
+}
+
+func (e ThereIsNoReceiptMail) Error() string {
+	return e.Message
+}
+
+type ReceiptMailClaimed struct{
+	Message string
+}
+
+func (e ReceiptMailClaimed) Error() string {
+	return e.Message
+}
+
+type ThereIsNoPaymentTransaction struct{
+	Message string
+}
+
+func (e ThereIsNoPaymentTransaction) Error() string {
+	return e.Message
+}
+
+type PaymentStateConflict struct{
+	Message string
+}
+
+func (e PaymentStateConflict) Error() string {
+	return e.Message
+}
+
+type PaymentAlreadySettled struct{
+	Message string
+}
+
+func (e PaymentAlreadySettled) Error() string {
+	return e.Message
+}
+
+type PaymentAmountMismatch struct{
+	Message string
+}
+
+func (e PaymentAmountMismatch) Error() string {
+	return e.Message
+}
@@ -1,26 +1,14 @@
 package dto
 
-type PaymentSendReq struct {
-	MerchantId     string `json:"merchant_id"`
-	MerchantOID    string `json:"merchant_oid"`
-	PaymentAmount  string `json:"payment_amount"`
-	Currency       string `json:"currency"`
-	Email          string `json:"email"`
-	UserName       string `json:"user_name"`
-	UserAddress    string `json:"user_address"`
-	UserPhone      string `json:"user_phone"`
-	OkURL          string `json:"merchant_ok_url"`
-	FailURL        string `json:"merchant_fail_url"`
-	UserBasket     string `json:"user_basket"`
-	UserIP         string `json:"user_ip"`
-	TimeOutLimit   string `json:"timeout_limit"`
-	DebugOn        string `json:"debug_on"`
-	TestMode       string `json:"test_mode"`
-	NoInstallment  string `json:"no_installment"`
-	MaxInstallment string `json:"max_installment"`
-	PaytrToken     string `json:"paytr_token"`
-}
+import (
+	"time"
+
+	"github.com/pragmataW/apartment_management/pkg/money"
+)
 
+// PaymentGetReq starts an online payment. Months is how many months of dues
+// to pay, oldest open month first, and defaults to one. PayAll pays the whole
+// open balance and takes precedence over Months.
 type PaymentGetReq struct {
 	UserName    string          `json:"user_name" validate:"required"`
 	UserAddress string          `json:"user_address" validate:"required"`
@@ -28,6 +16,8 @@ type PaymentGetReq struct {
 	UserBasket  [][]interface{} `json:"user_basket" validate:"required"`
 	DebugOn     string          `json:"debug_on" validate:"required"`
 	TestMode    string          `json:"test_mode" validate:"required"`
+	Months      int             `json:"months" validate:"gte=0,lte=24"`
+	PayAll      bool            `json:"pay_all"`
 }
 
 type LoginAdminReq struct {
@@ -41,20 +31,39 @@ type LoginUserReq struct {
 }
 
 type ChangeDuesPriceReq struct {
-	Price float64 `json:"price" validate:"required"`
+	Price money.Money `json:"price" validate:"required"`
 }
 
 type ChangePayDayReq struct {
 	PayDay int `json:"payday" validate:"required"`
 }
 
+type PenaltyPolicyReq struct {
+	GraceDays int         `json:"grace_days" validate:"gte=0"`
+	Rate      float64     `json:"rate" validate:"gte=0"`
+	Fee       money.Money `json:"fee" validate:"gte=0"`
+}
+
+type ReminderPolicyReq struct {
+	Enabled         *bool `json:"enabled" validate:"required"`
+	DaysAfterPayDay int   `json:"days_after_pay_day" validate:"gte=0,lte=27"`
+	IntervalDays    int   `json:"interval_days" validate:"gte=1,lte=90"`
+}
+
 type ApartmentRequest struct {
 	FlatNo       int    `json:"flat_no" validate:"required"`
 	OwnerName    string `json:"owner_name" validate:"required,min=2"`
 	OwnerSurname string `json:"owner_surname" validate:"required,min=2"`
 	Mail         string `json:"mail" validate:"required,email"`
 	Password     string `json:"password" validate:"required,min=8"`
-	DuesCount    int    `json:"dues_count"`
+}
+
+type DuesProfileRequest struct {
+	FlatType        string       `json:"flat_type" validate:"required,oneof=residential duplex shop caretaker"`
+	Area            float64      `json:"area" validate:"gte=0"`
+	Share           float64      `json:"share" validate:"gte=0"`
+	DuesCoefficient float64      `json:"dues_coefficient" validate:"required,gt=0"`
+	DuesOverride    *money.Money `json:"dues_override" validate:"omitempty,gte=0"`
 }
 
 type AnnouncementRequest struct {
@@ -68,3 +77,90 @@ type MailRequest struct {
 	Subject string `json:"subject" validate:"required"`
 	Body    string `json:"body" validate:"required"`
 }
+
+type LedgerAdjustmentReq struct {
+	Amount money.Money `json:"amount" validate:"required"`
+	Note   string      `json:"note" validate:"required"`
+}
+
+type AssessmentReq struct {
+	Name         string      `json:"name" validate:"required"`
+	Description  string      `json:"description"`
+	Total        money.Money `json:"total" validate:"required,gt=0"`
+	SplitRule    string      `json:"split_rule" validate:"required,oneof=equal share area"`
+	Installments int         `json:"installments" validate:"gte=0,lte=60"`
+	FirstDueDate *time.Time  `json:"first_due_date"`
+}
+
+type ExpenseCategoryReq struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type VendorReq struct {
+	Name  string `json:"name" validate:"required"`
+	TaxNo string `json:"tax_no"`
+	Phone string `json:"phone"`
+	Email string `json:"email" validate:"omitempty,email"`
+}
+
+type ExpenseReq struct {
+	CategoryID  int         `json:"category_id" validate:"required,gt=0"`
+	VendorID    *int        `json:"vendor_id" validate:"omitempty,gt=0"`
+	Date        string      `json:"date" validate:"required,datetime=2006-01-02"`
+	Amount      money.Money `json:"amount" validate:"required,gt=0"`
+	Description string      `json:"description"`
+}
+
+type ManualPaymentReq struct {
+	Amount      money.Money `json:"amount" validate:"required,gt=0"`
+	Method      string      `json:"method" validate:"required,oneof=cash transfer cheque"`
+	PaidAt      string      `json:"paid_at" validate:"omitempty,datetime=2006-01-02"`
+	CollectedBy string      `json:"collected_by"`
+	Note        string      `json:"note"`
+}
+
+type CancelReceiptReq struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+// PaymentRefundReq refunds an online payment. A zero amount refunds all that
+// is left of it.
+type PaymentRefundReq struct {
+	Amount money.Money `json:"amount" validate:"gte=0"`
+	Reason string      `json:"reason" validate:"required"`
+}
+
+type BankMatchReq struct {
+	FlatNo int `json:"flat_no" validate:"required,gt=0"`
+}
+
+type ReferenceRuleReq struct {
+	Name     string `json:"name" validate:"required"`
+	Pattern  string `json:"pattern" validate:"required"`
+	FlatNo   *int   `json:"flat_no" validate:"omitempty,gt=0"`
+	Priority int    `json:"priority"`
+}
+
+type MeterReq struct {
+	FlatNo int    `json:"flat_no" validate:"required,gt=0"`
+	Type   string `json:"type" validate:"required,oneof=water heat gas electricity"`
+	Serial string `json:"serial" validate:"required"`
+	Unit   string `json:"unit"`
+}
+
+type MeterReadingReq struct {
+	Value  float64 `json:"value" validate:"gte=0"`
+	ReadAt string  `json:"read_at" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type UtilityBillReq struct {
+	Type        string      `json:"type" validate:"required,oneof=water heat gas electricity"`
+	Period      string      `json:"period"`
+	Total       money.Money `json:"total" validate:"required,gt=0"`
+	CommonShare float64     `json:"common_share" validate:"gte=0,lte=100"`
+	CommonRule  string      `json:"common_rule" validate:"omitempty,oneof=equal share area"`
+	From        string      `json:"from" validate:"required,datetime=2006-01-02"`
+	To          string      `json:"to" validate:"required,datetime=2006-01-02"`
+	DueDate     string      `json:"due_date" validate:"omitempty,datetime=2006-01-02"`
+	Note        string      `json:"note"`
+}
@@ -1,24 +1,417 @@
 package dto
 
+import (
+	"time"
+
+	"github.com/pragmataW/apartment_management/pkg/money"
+)
+
 type ApartmentResponse struct {
-	FlatNo       int    `json:"flat_no"`
-	OwnerName    string `json:"owner_name"`
-	OwnerSurname string `json:"owner_surname"`
-	Mail         string `json:"mail"`
-	Password     string `json:"password"`
-	DuesCount    int    `json:"dues_count"`
+	FlatNo          int          `json:"flat_no"`
+	OwnerName       string       `json:"owner_name"`
+	OwnerSurname    string       `json:"owner_surname"`
+	Mail            string       `json:"mail"`
+	Password        string       `json:"password"`
+	FlatType        string       `json:"flat_type"`
+	Area            float64      `json:"area"`
+	Share           float64      `json:"share"`
+	DuesCoefficient float64      `json:"dues_coefficient"`
+	DuesOverride    *money.Money `json:"dues_override,omitempty"`
+	MonthlyDues     money.Money  `json:"monthly_dues"`
+	DuesCount       int          `json:"dues_count"`
+	Penalties       money.Money  `json:"penalties"`
+	Credit          money.Money  `json:"credit"`
+	Assessments     money.Money  `json:"assessments"`
+	Balance         money.Money  `json:"balance"`
 }
 
-type DuesPriceResponse struct{
-	DuesPrice float64 `json:"dues_price"`
+type BalanceResponse struct {
+	FlatNo      int         `json:"flat_no"`
+	Balance     money.Money `json:"balance"`
+	Credit      money.Money `json:"credit"`
+	OpenDues    int         `json:"open_dues"`
+	Penalties   money.Money `json:"penalties"`
+	MonthlyDues money.Money `json:"monthly_dues"`
+}
+
+type DuesPriceResponse struct {
+	DuesPrice         money.Money  `json:"dues_price"`
+	NextDuesPrice     *money.Money `json:"next_dues_price,omitempty"`
+	NextEffectiveFrom *time.Time   `json:"next_effective_from,omitempty"`
 }
 
 type PayDayResponse struct {
 	PayDay int `json:"payday"`
 }
 
+type PenaltyPolicyResponse struct {
+	GraceDays int         `json:"grace_days"`
+	Rate      float64     `json:"rate"`
+	Fee       money.Money `json:"fee"`
+}
+
+type ReminderPolicyResponse struct {
+	Enabled         bool `json:"enabled"`
+	DaysAfterPayDay int  `json:"days_after_pay_day"`
+	IntervalDays    int  `json:"interval_days"`
+}
+
+type DuesReminderResponse struct {
+	ID     int         `json:"id"`
+	FlatNo int         `json:"flat_no"`
+	Mail   string      `json:"mail"`
+	Amount money.Money `json:"amount"`
+	Status string      `json:"status"`
+	Error  string      `json:"error,omitempty"`
+	SentAt time.Time   `json:"sent_at"`
+}
+
+type ReminderRunResponse struct {
+	Sent int `json:"sent"`
+}
+
 type AnnouncementResponse struct {
 	AnnouncementID int	`json:"announcement_id"`
 	Title          string `json:"title"`
 	Content        string `json:"content"`
-}
\ No newline at end of file
+}
+type LedgerEntryResponse struct {
+	ID        int         `json:"id"`
+	Period    string      `json:"period"`
+	Type      string      `json:"type"`
+	Amount    money.Money `json:"amount"`
+	Source    string      `json:"source"`
+	Reference string      `json:"reference,omitempty"`
+	Note      string      `json:"note,omitempty"`
+	DueDate   time.Time   `json:"due_date"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+type LedgerResponse struct {
+	FlatNo  int                   `json:"flat_no"`
+	Balance money.Money           `json:"balance"`
+	Entries []LedgerEntryResponse `json:"entries"`
+}
+
+type SettingChangeResponse struct {
+	Key           string    `json:"key"`
+	Value         string    `json:"value"`
+	PreviousValue string    `json:"previous_value"`
+	ChangedBy     string    `json:"changed_by"`
+	ChangedAt     time.Time `json:"changed_at"`
+	EffectiveFrom time.Time `json:"effective_from"`
+}
+
+type ScheduledJobResponse struct {
+	Name    string    `json:"name"`
+	NextRun time.Time `json:"next_run"`
+}
+
+type AccrualRunResponse struct {
+	Period    string      `json:"period"`
+	Amount    money.Money `json:"amount"`
+	FlatCount int         `json:"flat_count"`
+	DueDate   time.Time   `json:"due_date"`
+	RunAt     time.Time   `json:"run_at"`
+}
+
+type AssessmentResponse struct {
+	ID           int         `json:"id"`
+	Name         string      `json:"name"`
+	Description  string      `json:"description,omitempty"`
+	Total        money.Money `json:"total"`
+	SplitRule    string      `json:"split_rule"`
+	Installments int         `json:"installments"`
+	FirstDueDate time.Time   `json:"first_due_date"`
+	CreatedBy    string      `json:"created_by"`
+	CreatedAt    time.Time   `json:"created_at"`
+	Billed       money.Money `json:"billed"`
+	Collected    money.Money `json:"collected"`
+}
+
+type AssessmentChargeResponse struct {
+	FlatNo    int         `json:"flat_no"`
+	Period    string      `json:"period"`
+	Amount    money.Money `json:"amount"`
+	Remaining money.Money `json:"remaining"`
+	Note      string      `json:"note"`
+	DueDate   time.Time   `json:"due_date"`
+}
+
+type ExpenseCategoryResponse struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type VendorResponse struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	TaxNo string `json:"tax_no,omitempty"`
+	Phone string `json:"phone,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+type ExpenseResponse struct {
+	ID           int         `json:"id"`
+	CategoryID   int         `json:"category_id"`
+	CategoryName string      `json:"category_name"`
+	VendorID     *int        `json:"vendor_id,omitempty"`
+	VendorName   string      `json:"vendor_name,omitempty"`
+	Date         string      `json:"date"`
+	Amount       money.Money `json:"amount"`
+	Description  string      `json:"description,omitempty"`
+	HasReceipt   bool        `json:"has_receipt"`
+	CreatedBy    string      `json:"created_by"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+}
+
+type ReportResponse struct {
+	Period             string                  `json:"period"`
+	From               string                  `json:"from"`
+	To                 string                  `json:"to"`
+	DuesAccrued        money.Money             `json:"dues_accrued"`
+	PenaltiesAccrued   money.Money             `json:"penalties_accrued"`
+	AssessmentsAccrued money.Money             `json:"assessments_accrued"`
+	OtherCharges       money.Money             `json:"other_charges"`
+	TotalAccrued       money.Money             `json:"total_accrued"`
+	CollectedPaytr     money.Money             `json:"collected_paytr"`
+	CollectedManual    money.Money             `json:"collected_manual"`
+	TotalCollected     money.Money             `json:"total_collected"`
+	Waived             money.Money             `json:"waived"`
+	OtherCredits       money.Money             `json:"other_credits"`
+	Expenses           []ReportExpenseResponse `json:"expenses"`
+	TotalExpenses      money.Money             `json:"total_expenses"`
+	Balance            money.Money             `json:"balance"`
+	CollectionRate     float64                 `json:"collection_rate"`
+	PeriodOutstanding  money.Money             `json:"period_outstanding"`
+	TotalOutstanding   money.Money             `json:"total_outstanding"`
+}
+
+type ReportExpenseResponse struct {
+	Category string      `json:"category"`
+	Count    int         `json:"count"`
+	Amount   money.Money `json:"amount"`
+}
+
+type AgingResponse struct {
+	At     string               `json:"at"`
+	Totals AgingBucketsResponse `json:"totals"`
+	Flats  []AgingFlatResponse  `json:"flats"`
+}
+
+type AgingBucketsResponse struct {
+	Days0To30  money.Money `json:"0_30"`
+	Days31To60 money.Money `json:"31_60"`
+	Days61To90 money.Money `json:"61_90"`
+	Over90     money.Money `json:"90_plus"`
+	Total      money.Money `json:"total"`
+}
+
+type AgingFlatResponse struct {
+	FlatNo        int                  `json:"flat_no"`
+	OwnerName     string               `json:"owner_name,omitempty"`
+	Mail          string               `json:"mail,omitempty"`
+	Buckets       AgingBucketsResponse `json:"buckets"`
+	OldestDueDate string               `json:"oldest_due_date"`
+	DaysOverdue   int                  `json:"days_overdue"`
+}
+
+type StatementResponse struct {
+	FlatNo         int                     `json:"flat_no"`
+	OwnerName      string                  `json:"owner_name,omitempty"`
+	From           string                  `json:"from,omitempty"`
+	To             string                  `json:"to"`
+	OpeningBalance money.Money             `json:"opening_balance"`
+	TotalDebit     money.Money             `json:"total_debit"`
+	TotalCredit    money.Money             `json:"total_credit"`
+	ClosingBalance money.Money             `json:"closing_balance"`
+	Lines          []StatementLineResponse `json:"lines"`
+}
+
+type StatementLineResponse struct {
+	Date        time.Time   `json:"date"`
+	Period      string      `json:"period"`
+	Type        string      `json:"type"`
+	Description string      `json:"description"`
+	Debit       money.Money `json:"debit"`
+	Credit      money.Money `json:"credit"`
+	Balance     money.Money `json:"balance"`
+}
+
+type MyFlatResponse struct {
+	FlatNo          int         `json:"flat_no"`
+	FlatType        string      `json:"flat_type"`
+	Area            float64     `json:"area"`
+	Share           float64     `json:"share"`
+	DuesCoefficient float64     `json:"dues_coefficient"`
+	MonthlyDues     money.Money `json:"monthly_dues"`
+	OpenDues        int         `json:"open_dues"`
+	Penalties       money.Money `json:"penalties"`
+	Assessments     money.Money `json:"assessments"`
+	Credit          money.Money `json:"credit"`
+	Balance         money.Money `json:"balance"`
+}
+
+type ProfileResponse struct {
+	FlatNo       int    `json:"flat_no"`
+	OwnerName    string `json:"owner_name"`
+	OwnerSurname string `json:"owner_surname"`
+	Mail         string `json:"mail"`
+}
+
+type OpenChargeResponse struct {
+	ID        int         `json:"id"`
+	Period    string      `json:"period"`
+	Type      string      `json:"type"`
+	Note      string      `json:"note,omitempty"`
+	Amount    money.Money `json:"amount"`
+	Remaining money.Money `json:"remaining"`
+	DueDate   time.Time   `json:"due_date"`
+}
+
+type MyDuesResponse struct {
+	Total   money.Money          `json:"total"`
+	Charges []OpenChargeResponse `json:"charges"`
+}
+
+type ReceiptResponse struct {
+	ReceiptNo    int         `json:"receipt_no"`
+	Reference    string      `json:"reference"`
+	FlatNo       int         `json:"flat_no"`
+	Amount       money.Money `json:"amount"`
+	Method       string      `json:"method"`
+	PaidAt       string      `json:"paid_at"`
+	CollectedBy  string      `json:"collected_by"`
+	Note         string      `json:"note,omitempty"`
+	CreatedBy    string      `json:"created_by"`
+	CreatedAt    time.Time   `json:"created_at"`
+	Cancelled    bool        `json:"cancelled"`
+	CancelledAt  *time.Time  `json:"cancelled_at,omitempty"`
+	CancelledBy  string      `json:"cancelled_by,omitempty"`
+	CancelReason string      `json:"cancel_reason,omitempty"`
+}
+
+type BankImportResponse struct {
+	ID         int       `json:"id"`
+	FileName   string    `json:"file_name"`
+	Format     string    `json:"format"`
+	Lines      int       `json:"lines"`
+	Matched    int       `json:"matched"`
+	Unmatched  int       `json:"unmatched"`
+	Duplicates int       `json:"duplicates"`
+	Skipped    int       `json:"skipped"`
+	ImportedBy string    `json:"imported_by"`
+	ImportedAt time.Time `json:"imported_at"`
+}
+
+type BankTransactionResponse struct {
+	ID           int         `json:"id"`
+	ImportID     int         `json:"import_id"`
+	BankRef      string      `json:"bank_ref"`
+	Date         string      `json:"date"`
+	Amount       money.Money `json:"amount"`
+	Currency     string      `json:"currency"`
+	Description  string      `json:"description"`
+	Counterparty string      `json:"counterparty,omitempty"`
+	Status       string      `json:"status"`
+	FlatNo       *int        `json:"flat_no,omitempty"`
+	RuleID       *int        `json:"rule_id,omitempty"`
+	ResolvedBy   string      `json:"resolved_by,omitempty"`
+	ResolvedAt   *time.Time  `json:"resolved_at,omitempty"`
+}
+
+type ReferenceRuleResponse struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Pattern   string `json:"pattern"`
+	FlatNo    *int   `json:"flat_no,omitempty"`
+	Priority  int    `json:"priority"`
+	CreatedBy string `json:"created_by"`
+}
+
+type MeterResponse struct {
+	ID        int       `json:"id"`
+	FlatNo    int       `json:"flat_no"`
+	Type      string    `json:"type"`
+	Serial    string    `json:"serial"`
+	Unit      string    `json:"unit"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type MeterReadingResponse struct {
+	ID        int     `json:"id"`
+	MeterID   int     `json:"meter_id"`
+	ReadAt    string  `json:"read_at"`
+	Value     float64 `json:"value"`
+	Source    string  `json:"source"`
+	CreatedBy string  `json:"created_by"`
+}
+
+type MeterImportResponse struct {
+	Imported int `json:"imported"`
+}
+
+type UtilityBillResponse struct {
+	ID          int                       `json:"id"`
+	Type        string                    `json:"type"`
+	Period      string                    `json:"period"`
+	Total       money.Money               `json:"total"`
+	CommonShare float64                   `json:"common_share"`
+	CommonRule  string                    `json:"common_rule"`
+	From        string                    `json:"from"`
+	To          string                    `json:"to"`
+	DueDate     string                    `json:"due_date"`
+	Note        string                    `json:"note,omitempty"`
+	CreatedBy   string                    `json:"created_by"`
+	CreatedAt   time.Time                 `json:"created_at"`
+	Lines       []UtilityBillLineResponse `json:"lines,omitempty"`
+}
+
+type UtilityBillLineResponse struct {
+	FlatNo            int         `json:"flat_no"`
+	Consumption       float64     `json:"consumption"`
+	CommonAmount      money.Money `json:"common_amount"`
+	ConsumptionAmount money.Money `json:"consumption_amount"`
+	Amount            money.Money `json:"amount"`
+}
+
+type ReceiptMailResponse struct {
+	ID          int        `json:"id"`
+	ReceiptNo   int        `json:"receipt_no"`
+	Mail        string     `json:"mail"`
+	Status      string     `json:"status"`
+	Attempts    int        `json:"attempts"`
+	Error       string     `json:"error,omitempty"`
+	NextAttempt time.Time  `json:"next_attempt"`
+	SentAt      *time.Time `json:"sent_at,omitempty"`
+}
+
+type PaymentTransactionResponse struct {
+	MerchantOID string      `json:"merchant_oid"`
+	Provider    string      `json:"provider"`
+	FlatNo      int         `json:"flat_no"`
+	Email       string      `json:"email"`
+	Amount      money.Money `json:"amount"`
+	PaidAmount  money.Money `json:"paid_amount"`
+	Status      string      `json:"status"`
+	FailReason  string      `json:"fail_reason,omitempty"`
+	ReceiptNo   int         `json:"receipt_no,omitempty"`
+	ReviewedBy  string      `json:"reviewed_by,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+	CallbackAt  *time.Time  `json:"callback_at,omitempty"`
+
+	RefundedAmount money.Money `json:"refunded_amount"`
+}
+
+type PaymentRefundResponse struct {
+	ID          int         `json:"id"`
+	MerchantOID string      `json:"merchant_oid"`
+	Amount      money.Money `json:"amount"`
+	Reason      string      `json:"reason"`
+	RefundedBy  string      `json:"refunded_by"`
+	Status      string      `json:"status"`
+	Error       string      `json:"error,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
@@ -30,4 +30,131 @@ type SendMailError struct{
 
 func (e SendMailError) Error() string{
 	return e.Message
-}
\ No newline at end of file
+}
+type DuesPriceRangeError struct{
+	Message string
+}
+
+func (e DuesPriceRangeError) Error() string{
+	return e.Message
+}
+
+type PenaltyPolicyError struct{
+	Message string
+}
+
+func (e PenaltyPolicyError) Error() string{
+	return e.Message
+}
+
+type AssessmentError struct{
+	Message string
+}
+
+func (e AssessmentError) Error() string{
+	return e.Message
+}
+
+type ExpenseError struct{
+	Message string
+}
+
+func (e ExpenseError) Error() string{
+	return e.Message
+}
+
+type ReportPeriodError struct{
+	Message string
+}
+
+func (e ReportPeriodError) Error() string{
+	return e.Message
+}
+
+type StatementRangeError struct{
+	Message string
+}
+
+func (e StatementRangeError) Error() string{
+	return e.Message
+}
+
+type FlatAccessDenied struct{
+	Message string
+}
+
+func (e FlatAccessDenied) Error() string{
+	return e.Message
+}
+
+type ManualPaymentError struct{
+	Message string
+}
+
+func (e ManualPaymentError) Error() string{
+	return e.Message
+}
+
+type BankImportError struct{
+	Message string
+}
+
+func (e BankImportError) Error() string{
+	return e.Message
+}
+
+type AgingReportError struct{
+	Message string
+}
+
+func (e AgingReportError) Error() string{
+	return e.Message
+}
+
+type MeterError struct{
+	Message string
+}
+
+func (e MeterError) Error() string{
+	return e.Message
+}
+
+type ReminderPolicyError struct{
+	Message string
+}
+
+func (e ReminderPolicyError) Error() string{
+	return e.Message
+}
+
+type ReceiptMailError struct{
+	Message string
+}
+
+func (e ReceiptMailError) Error() string{
+	return e.Message
+}
+
+type PaymentCallbackError struct{
+	Message string
+}
+
+func (e PaymentCallbackError) Error() string{
+	return e.Message
+}
+
+type PaymentTransactionError struct{
+	Message string
+}
+
+func (e PaymentTransactionError) Error() string{
+	return e.Message
+}
+
+type PaymentProviderError struct{
+	Message string
+}
+
+func (e PaymentProviderError) Error() string{
+	return e.Message
+}
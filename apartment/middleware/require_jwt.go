@@ -72,6 +72,12 @@ func JwtMiddleware(jwtKey string, expectedRoles ...string) fiber.Handler {
         }
 
         c.Locals("email", email)
+        c.Locals("role", role)
+
+        // Admin token'ları -1 taşır, sakin token'ları kendi dairesini
+        if flatNo, ok := claims["flatNo"].(float64); ok {
+            c.Locals("flatNo", int(flatNo))
+        }
 
         // Middleware'i geç
         return c.Next()
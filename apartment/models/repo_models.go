@@ -1,12 +1,30 @@
 package models
 
+import (
+	"fmt"
+	"time"
+
+	"github.com/pragmataW/apartment_management/pkg/money"
+)
+
+const (
+	FlatResidential = "residential"
+	FlatDuplex      = "duplex"
+	FlatShop        = "shop"
+	FlatCaretaker   = "caretaker"
+)
+
 type Apartment struct {
-    FlatNo       int    `gorm:"primaryKey;column:flat_no"`
-    OwnerName    string `gorm:"column:owner_name"`
-    OwnerSurname string `gorm:"column:owner_surname"`
-    Mail         string `gorm:"column:mail;unique"`
-    Password     string `gorm:"column:password"`
-    DuesCount    int    `gorm:"column:dues_count"`
+    FlatNo          int          `gorm:"primaryKey;column:flat_no"`
+    OwnerName       string       `gorm:"column:owner_name"`
+    OwnerSurname    string       `gorm:"column:owner_surname"`
+    Mail            string       `gorm:"column:mail;unique"`
+    Password        string       `gorm:"column:password"`
+    FlatType        string       `gorm:"column:flat_type;not null;default:residential"`
+    Area            float64      `gorm:"column:area;type:numeric(10,2);not null;default:0"`
+    Share           float64      `gorm:"column:share;type:numeric(10,4);not null;default:0"`
+    DuesCoefficient float64      `gorm:"column:dues_coefficient;type:numeric(6,3);not null;default:1"`
+    DuesOverride    *money.Money `gorm:"column:dues_override;type:numeric(12,2)"`
 }
 
 func (Apartment) TableName() string {
@@ -23,11 +41,586 @@ func (Announcement) TableName() string{
 	return "announcements"
 }
 
-type Merchant struct {
-	MerchantID string `gorm:"primaryKey"`
-	Email      string `gorm:"column:email;not null"`
+const (
+	PaymentCreated   = "created"
+	PaymentPending   = "pending"
+	PaymentSucceeded = "succeeded"
+	PaymentFailed    = "failed"
+	PaymentExpired   = "expired"
+	PaymentRefunded  = "refunded"
+	PaymentReview    = "review"
+)
+
+// PaymentTransaction is an online payment from the moment its token is asked
+// for. It is created before the provider is called and is pending while the
+// resident pays. The provider's callback moves it to succeeded or failed, and
+// one that never hears back is expired. Amount is what the server asked the
+// resident to pay and PaidAmount what the provider reported as paid. When the
+// two differ the payment is held for review until an admin accepts it, and
+// PaidAmount is what gets booked, as EntryID. Refunds add up in
+// RefundedAmount, and a payment refunded in full is refunded.
+type PaymentTransaction struct {
+	MerchantOID string      `gorm:"primaryKey;column:merchant_oid"`
+	Provider    string      `gorm:"column:provider;not null"`
+	FlatNo      int         `gorm:"column:flat_no;not null;index"`
+	Email       string      `gorm:"column:email;not null"`
+	Amount      money.Money `gorm:"column:amount;type:numeric(12,2);not null;default:0"`
+	PaidAmount  money.Money `gorm:"column:paid_amount;type:numeric(12,2);not null;default:0"`
+	Status      string      `gorm:"column:status;not null;index"`
+	FailReason  string      `gorm:"column:fail_reason"`
+	ReceiptNo   int         `gorm:"column:receipt_no"`
+	EntryID     int         `gorm:"column:entry_id"`
+	ReviewedBy  string      `gorm:"column:reviewed_by"`
+	CreatedAt   time.Time   `gorm:"column:created_at;autoCreateTime"`
+	CallbackAt  *time.Time  `gorm:"column:callback_at"`
+
+	RefundedAmount money.Money `gorm:"column:refunded_amount;type:numeric(12,2);not null;default:0"`
+}
+
+func (PaymentTransaction) TableName() string {
+	return "payment_transactions"
+}
+
+const (
+	RefundPending = "pending"
+	RefundDone    = "done"
+	RefundFailed  = "failed"
+)
+
+// PaymentRefund is money given back on an online payment through its
+// provider. It is recorded as pending before the provider is asked, so that
+// refunds running at the same time cannot give back more than was paid. Once
+// the provider has made it, the payment entry standing for the transaction is
+// reversed in full, and what is kept, if anything, is booked again as
+// RebookEntryID. That settles the oldest months again, so a refund reopens
+// the newest ones.
+type PaymentRefund struct {
+	ID              int         `gorm:"primaryKey;column:id;autoIncrement"`
+	MerchantOID     string      `gorm:"column:merchant_oid;not null;index"`
+	Amount          money.Money `gorm:"column:amount;type:numeric(12,2);not null"`
+	Reason          string      `gorm:"column:reason"`
+	RefundedBy      string      `gorm:"column:refunded_by;not null"`
+	Status          string      `gorm:"column:status;not null;default:done"`
+	Error           string      `gorm:"column:error"`
+	ReversalEntryID *int        `gorm:"column:reversal_entry_id"`
+	RebookEntryID   *int        `gorm:"column:rebook_entry_id"`
+	CreatedAt       time.Time   `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (PaymentRefund) TableName() string {
+	return "payment_refunds"
+}
+
+// PaymentTransactionFilter narrows a transaction listing. Zero fields do not
+// filter; From and To bound the creation time.
+type PaymentTransactionFilter struct {
+	Status string
+	FlatNo int
+	From   time.Time
+	To     time.Time
+}
+
+const (
+	LedgerAccrual    = "accrual"
+	LedgerPayment    = "payment"
+	LedgerAdjustment = "adjustment"
+	LedgerWaiver     = "waiver"
+	LedgerPenalty    = "penalty"
+	LedgerAssessment = "assessment"
+	LedgerReversal   = "reversal"
+	LedgerUtility    = "utility"
+)
+
+const (
+	SourceAdmin     = "admin"
+	SourceScheduler = "scheduler"
+	SourcePaytr     = "paytr"
+	SourceMigration = "migration"
+	SourceManual    = "manual"
+	SourceBank      = "bank"
+	SourceImport    = "import"
+)
+
+// LedgerEntry is a single movement on a flat's account. Charges are stored
+// with a positive amount and credits (payments, waivers) with a negative one,
+// so the outstanding balance of a flat is the sum of its entries. Penalties
+// point at the overdue charge they were raised for through ChargeID, and a
+// charge gets at most one penalty per period. A reversal cancels a payment:
+// it is booked as a charge against the payment and takes back whatever the
+// payment had cleared.
+type LedgerEntry struct {
+	ID            int         `gorm:"primaryKey;column:id;autoIncrement"`
+	FlatNo        int         `gorm:"column:flat_no;not null;index"`
+	Period        string      `gorm:"column:period;not null;uniqueIndex:idx_ledger_penalty,priority:2"`
+	Type          string      `gorm:"column:type;not null"`
+	Amount        money.Money `gorm:"column:amount;type:numeric(12,2);not null"`
+	Source        string      `gorm:"column:source;not null"`
+	Reference     string      `gorm:"column:reference"`
+	Note          string      `gorm:"column:note"`
+	ChargeID      *int        `gorm:"column:charge_id;uniqueIndex:idx_ledger_penalty,priority:1"`
+	AssessmentID  *int        `gorm:"column:assessment_id;index"`
+	UtilityBillID *int        `gorm:"column:utility_bill_id;index"`
+	DueDate       time.Time   `gorm:"column:due_date;not null"`
+	CreatedAt     time.Time   `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (LedgerEntry) TableName() string {
+	return "ledger_entries"
+}
+
+// LedgerAllocation records which credit cleared which charge and by how much.
+type LedgerAllocation struct {
+	ID        int         `gorm:"primaryKey;column:id;autoIncrement"`
+	CreditID  int         `gorm:"column:credit_id;not null;index"`
+	ChargeID  int         `gorm:"column:charge_id;not null;index"`
+	Amount    money.Money `gorm:"column:amount;type:numeric(12,2);not null"`
+	CreatedAt time.Time   `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (LedgerAllocation) TableName() string {
+	return "ledger_allocations"
+}
+
+type OpenCharge struct {
+	LedgerEntry `gorm:"embedded"`
+	Remaining   money.Money `gorm:"column:remaining"`
+}
+
+// ChargeGroup is an open charge together with the late fees charged on it,
+// which a payment settles as one. Type and Period are those of the charge.
+type ChargeGroup struct {
+	Type   string
+	Period string
+	Amount money.Money
+}
+
+// OpenCredit is a payment or other credit with an amount not yet used to
+// clear any charge.
+type OpenCredit struct {
+	LedgerEntry `gorm:"embedded"`
+	Remaining   money.Money `gorm:"column:remaining"`
+}
+
+type DuesSummary struct {
+	FlatNo        int         `gorm:"column:flat_no"`
+	Balance       money.Money `gorm:"column:balance"`
+	OpenDues      int         `gorm:"column:open_dues"`
+	OpenPenalties money.Money `gorm:"column:open_penalties"`
+	Credit        money.Money `gorm:"column:credit"`
+	Assessments   money.Money `gorm:"column:open_assessments"`
+}
+
+const (
+	SettingDuesPrice = "dues_price"
+	SettingPayDay    = "pay_day"
+
+	SettingPenaltyGraceDays = "penalty_grace_days"
+	SettingPenaltyRate      = "penalty_rate"
+	SettingPenaltyFee       = "penalty_fee"
+
+	SettingRemindersEnabled     = "reminders_enabled"
+	SettingReminderDays         = "reminder_days"
+	SettingReminderIntervalDays = "reminder_interval_days"
+)
+
+// Setting is one version of a configurable value. The value in force at a
+// given time is the latest version whose effective_from is not after it.
+type Setting struct {
+	ID            int       `gorm:"primaryKey;column:id;autoIncrement"`
+	Key           string    `gorm:"column:key;not null;index"`
+	Value         string    `gorm:"column:value;not null"`
+	PreviousValue string    `gorm:"column:previous_value"`
+	ChangedBy     string    `gorm:"column:changed_by;not null"`
+	ChangedAt     time.Time `gorm:"column:changed_at;autoCreateTime"`
+	EffectiveFrom time.Time `gorm:"column:effective_from;not null"`
+}
+
+func (Setting) TableName() string {
+	return "settings"
+}
+
+// AccrualRun marks a billing period as charged. The period is the primary key
+// so a period can be accrued at most once, whichever replica gets there first.
+type AccrualRun struct {
+	Period    string      `gorm:"primaryKey;column:period"`
+	Amount    money.Money `gorm:"column:amount;type:numeric(12,2);not null"`
+	FlatCount int         `gorm:"column:flat_count;not null"`
+	DueDate   time.Time   `gorm:"column:due_date;not null"`
+	RunAt     time.Time   `gorm:"column:run_at;autoCreateTime"`
+}
+
+func (AccrualRun) TableName() string {
+	return "accrual_runs"
+}
+
+const (
+	SplitEqual = "equal"
+	SplitShare = "share"
+	SplitArea  = "area"
+)
+
+// Assessment is a one-off cost split among the flats. Each flat pays it in
+// installments, which are charged to the ledger as entries of type assessment
+// pointing back at it as they fall due.
+type Assessment struct {
+	ID           int         `gorm:"primaryKey;column:id;autoIncrement"`
+	Name         string      `gorm:"column:name;not null"`
+	Description  string      `gorm:"column:description"`
+	Total        money.Money `gorm:"column:total;type:numeric(12,2);not null"`
+	SplitRule    string      `gorm:"column:split_rule;not null"`
+	Installments int         `gorm:"column:installments;not null;default:1"`
+	FirstDueDate time.Time   `gorm:"column:first_due_date;not null"`
+	CreatedBy    string      `gorm:"column:created_by;not null"`
+	CreatedAt    time.Time   `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (Assessment) TableName() string {
+	return "assessments"
+}
+
+// AssessmentInstallment is one flat's installment of an assessment. It is
+// charged to the ledger on its due date, after which EntryID points at the
+// charge.
+type AssessmentInstallment struct {
+	ID           int         `gorm:"primaryKey;column:id;autoIncrement"`
+	AssessmentID int         `gorm:"column:assessment_id;not null;index"`
+	FlatNo       int         `gorm:"column:flat_no;not null"`
+	Period       string      `gorm:"column:period;not null"`
+	Amount       money.Money `gorm:"column:amount;type:numeric(12,2);not null"`
+	Note         string      `gorm:"column:note"`
+	DueDate      time.Time   `gorm:"column:due_date;not null;index"`
+	EntryID      *int        `gorm:"column:entry_id"`
+}
+
+func (AssessmentInstallment) TableName() string {
+	return "assessment_installments"
+}
+
+type AssessmentStatus struct {
+	Assessment `gorm:"embedded"`
+	Billed     money.Money `gorm:"column:billed"`
+	Collected  money.Money `gorm:"column:collected"`
+}
+
+type ExpenseCategory struct {
+	ID   int    `gorm:"primaryKey;column:id;autoIncrement"`
+	Name string `gorm:"column:name;not null;unique"`
+}
+
+func (ExpenseCategory) TableName() string {
+	return "expense_categories"
+}
+
+type Vendor struct {
+	ID    int    `gorm:"primaryKey;column:id;autoIncrement"`
+	Name  string `gorm:"column:name;not null;unique"`
+	TaxNo string `gorm:"column:tax_no"`
+	Phone string `gorm:"column:phone"`
+	Email string `gorm:"column:email"`
+}
+
+func (Vendor) TableName() string {
+	return "vendors"
+}
+
+// Expense is money spent on the building. ReceiptFile is the name of the
+// attached receipt in the receipt store, empty when none was uploaded.
+type Expense struct {
+	ID          int         `gorm:"primaryKey;column:id;autoIncrement"`
+	CategoryID  int         `gorm:"column:category_id;not null;index"`
+	VendorID    *int        `gorm:"column:vendor_id;index"`
+	Date        time.Time   `gorm:"column:date;type:date;not null;index"`
+	Amount      money.Money `gorm:"column:amount;type:numeric(12,2);not null"`
+	Description string      `gorm:"column:description"`
+	ReceiptFile string      `gorm:"column:receipt_file"`
+	CreatedBy   string      `gorm:"column:created_by;not null"`
+	CreatedAt   time.Time   `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time   `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (Expense) TableName() string {
+	return "expenses"
+}
+
+type ExpenseRecord struct {
+	Expense      `gorm:"embedded"`
+	CategoryName string `gorm:"column:category_name"`
+	VendorName   string `gorm:"column:vendor_name"`
+}
+
+// ExpenseFilter narrows an expense listing. Zero fields do not filter.
+type ExpenseFilter struct {
+	From       time.Time
+	To         time.Time
+	CategoryID int
+	VendorID   int
+}
+
+// ChargeTotal is what was charged for one ledger type over a range of
+// periods. Paid is the part cleared by payments and Outstanding the part
+// still open.
+type ChargeTotal struct {
+	Type        string      `gorm:"column:type"`
+	Charged     money.Money `gorm:"column:charged"`
+	Paid        money.Money `gorm:"column:paid"`
+	Outstanding money.Money `gorm:"column:outstanding"`
+}
+
+// CreditTotal is the sum of the credits of one type and source booked over a
+// range of dates, as a positive amount.
+type CreditTotal struct {
+	Type   string      `gorm:"column:type"`
+	Source string      `gorm:"column:source"`
+	Amount money.Money `gorm:"column:amount"`
+}
+
+type ExpenseTotal struct {
+	CategoryName string      `gorm:"column:category_name"`
+	Count        int         `gorm:"column:count"`
+	Amount       money.Money `gorm:"column:amount"`
+}
+
+type ReportTotals struct {
+	Charges          []ChargeTotal
+	Credits          []CreditTotal
+	Expenses         []ExpenseTotal
+	TotalOutstanding money.Money
+}
+
+const (
+	MethodCash     = "cash"
+	MethodTransfer = "transfer"
+	MethodCheque   = "cheque"
+	MethodOnline   = "online"
+)
+
+// PaymentReceipt is a payment taken by hand, in cash, by transfer or by
+// cheque, or online through PayTR. ReceiptNo runs without gaps. The payment itself is the ledger entry
+// EntryID; cancelling the receipt books a reversal entry, CancelEntryID,
+// instead of removing it.
+type PaymentReceipt struct {
+	ID            int         `gorm:"primaryKey;column:id;autoIncrement"`
+	ReceiptNo     int         `gorm:"column:receipt_no;not null;uniqueIndex"`
+	FlatNo        int         `gorm:"column:flat_no;not null;index"`
+	Amount        money.Money `gorm:"column:amount;type:numeric(12,2);not null"`
+	Method        string      `gorm:"column:method;not null"`
+	PaidAt        time.Time   `gorm:"column:paid_at;not null"`
+	CollectedBy   string      `gorm:"column:collected_by;not null"`
+	Note          string      `gorm:"column:note"`
+	EntryID       int         `gorm:"column:entry_id;not null"`
+	CancelledAt   *time.Time  `gorm:"column:cancelled_at"`
+	CancelledBy   string      `gorm:"column:cancelled_by"`
+	CancelReason  string      `gorm:"column:cancel_reason"`
+	CancelEntryID *int        `gorm:"column:cancel_entry_id"`
+	CreatedBy     string      `gorm:"column:created_by;not null"`
+	CreatedAt     time.Time   `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (PaymentReceipt) TableName() string {
+	return "payment_receipts"
+}
+
+// Reference is how the receipt number is written on the ledger and on paper.
+func (r PaymentReceipt) Reference() string {
+	return fmt.Sprintf("R-%06d", r.ReceiptNo)
+}
+
+const (
+	BankUnmatched = "unmatched"
+	BankMatched   = "matched"
+	BankIgnored   = "ignored"
+)
+
+// BankImport is one bank statement file taken in, with what became of its
+// lines. Debits are skipped since they are not payments.
+type BankImport struct {
+	ID         int       `gorm:"primaryKey;column:id;autoIncrement"`
+	FileName   string    `gorm:"column:file_name;not null"`
+	Format     string    `gorm:"column:format;not null"`
+	Lines      int       `gorm:"column:lines;not null"`
+	Matched    int       `gorm:"column:matched;not null"`
+	Unmatched  int       `gorm:"column:unmatched;not null"`
+	Duplicates int       `gorm:"column:duplicates;not null"`
+	Skipped    int       `gorm:"column:skipped;not null"`
+	ImportedBy string    `gorm:"column:imported_by;not null"`
+	ImportedAt time.Time `gorm:"column:imported_at;autoCreateTime"`
+}
+
+func (BankImport) TableName() string {
+	return "bank_imports"
+}
+
+// BankTransaction is an incoming credit read from a bank statement. BankRef
+// identifies the line at the bank so that overlapping statements do not book
+// a transfer twice. A matched transaction is a payment on the ledger,
+// EntryID; an unmatched one waits in the review queue until an admin assigns
+// it to a flat or ignores it.
+type BankTransaction struct {
+	ID           int         `gorm:"primaryKey;column:id;autoIncrement"`
+	ImportID     int         `gorm:"column:import_id;not null;index"`
+	BankRef      string      `gorm:"column:bank_ref;not null;uniqueIndex"`
+	Date         time.Time   `gorm:"column:date;type:date;not null"`
+	Amount       money.Money `gorm:"column:amount;type:numeric(12,2);not null"`
+	Currency     string      `gorm:"column:currency;not null;default:TRY"`
+	Description  string      `gorm:"column:description"`
+	Counterparty string      `gorm:"column:counterparty"`
+	Status       string      `gorm:"column:status;not null;index"`
+	FlatNo       *int        `gorm:"column:flat_no"`
+	RuleID       *int        `gorm:"column:rule_id"`
+	EntryID      *int        `gorm:"column:entry_id"`
+	ResolvedBy   string      `gorm:"column:resolved_by"`
+	ResolvedAt   *time.Time  `gorm:"column:resolved_at"`
+	CreatedAt    time.Time   `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (BankTransaction) TableName() string {
+	return "bank_transactions"
+}
+
+// ReferenceRule finds the flat a bank transfer is for. Pattern is a regular
+// expression run on the description and the sender's name; when FlatNo is set
+// a match goes to that flat, otherwise the first group of the match is read
+// as the flat number. Rules are tried by ascending Priority.
+type ReferenceRule struct {
+	ID        int       `gorm:"primaryKey;column:id;autoIncrement"`
+	Name      string    `gorm:"column:name;not null"`
+	Pattern   string    `gorm:"column:pattern;not null"`
+	FlatNo    *int      `gorm:"column:flat_no"`
+	Priority  int       `gorm:"column:priority;not null"`
+	CreatedBy string    `gorm:"column:created_by;not null"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (ReferenceRule) TableName() string {
+	return "reference_rules"
+}
+
+const (
+	MeterWater = "water"
+	MeterHeat  = "heat"
+	MeterGas   = "gas"
+	MeterPower = "electricity"
+)
+
+// Meter is a consumption meter in a flat. Serial is the number printed on
+// the meter and is unique across the building.
+type Meter struct {
+	ID        int       `gorm:"primaryKey;column:id;autoIncrement"`
+	FlatNo    int       `gorm:"column:flat_no;not null;index"`
+	Type      string    `gorm:"column:type;not null"`
+	Serial    string    `gorm:"column:serial;not null;uniqueIndex"`
+	Unit      string    `gorm:"column:unit;not null"`
+	CreatedBy string    `gorm:"column:created_by;not null"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (Meter) TableName() string {
+	return "meters"
+}
+
+// MeterReading is what a meter showed on a day. A meter has at most one
+// reading a day and its readings never go down as the days go on.
+type MeterReading struct {
+	ID        int       `gorm:"primaryKey;column:id;autoIncrement"`
+	MeterID   int       `gorm:"column:meter_id;not null;uniqueIndex:idx_meter_readings_day,priority:1"`
+	ReadAt    time.Time `gorm:"column:read_at;type:date;not null;uniqueIndex:idx_meter_readings_day,priority:2"`
+	Value     float64   `gorm:"column:value;type:numeric(14,3);not null"`
+	Source    string    `gorm:"column:source;not null"`
+	CreatedBy string    `gorm:"column:created_by;not null"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (MeterReading) TableName() string {
+	return "meter_readings"
+}
+
+// UtilityBill is a building-level bill for one meter type. CommonShare
+// percent of the total is split among all flats by CommonRule; the rest is
+// split by what each flat's meters used between the readings on or before
+// From and To.
+type UtilityBill struct {
+	ID          int         `gorm:"primaryKey;column:id;autoIncrement"`
+	Type        string      `gorm:"column:type;not null;uniqueIndex:idx_utility_bills_period,priority:1"`
+	Period      string      `gorm:"column:period;not null;uniqueIndex:idx_utility_bills_period,priority:2"`
+	Total       money.Money `gorm:"column:total;type:numeric(12,2);not null"`
+	CommonShare float64     `gorm:"column:common_share;type:numeric(5,2);not null"`
+	CommonRule  string      `gorm:"column:common_rule;not null"`
+	From        time.Time   `gorm:"column:from_date;type:date;not null"`
+	To          time.Time   `gorm:"column:to_date;type:date;not null"`
+	DueDate     time.Time   `gorm:"column:due_date;not null"`
+	Note        string      `gorm:"column:note"`
+	CreatedBy   string      `gorm:"column:created_by;not null"`
+	CreatedAt   time.Time   `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (UtilityBill) TableName() string {
+	return "utility_bills"
+}
+
+// UtilityBillLine is one flat's part of a utility bill: what its meters used
+// and the common and consumption parts of its charge.
+type UtilityBillLine struct {
+	ID                int         `gorm:"primaryKey;column:id;autoIncrement"`
+	BillID            int         `gorm:"column:bill_id;not null;index"`
+	FlatNo            int         `gorm:"column:flat_no;not null"`
+	Consumption       float64     `gorm:"column:consumption;type:numeric(14,3);not null"`
+	CommonAmount      money.Money `gorm:"column:common_amount;type:numeric(12,2);not null"`
+	ConsumptionAmount money.Money `gorm:"column:consumption_amount;type:numeric(12,2);not null"`
+	Amount            money.Money `gorm:"column:amount;type:numeric(12,2);not null"`
+}
+
+func (UtilityBillLine) TableName() string {
+	return "utility_bill_lines"
+}
+
+const (
+	ReminderSent   = "sent"
+	ReminderFailed = "failed"
+)
+
+// DuesReminder is one overdue dues reminder email. The log spaces reminders
+// to a flat out and shows who was reminded when; a failed send is kept with
+// its error and does not count as a reminder.
+type DuesReminder struct {
+	ID     int         `gorm:"primaryKey;column:id;autoIncrement"`
+	FlatNo int         `gorm:"column:flat_no;not null;index"`
+	Mail   string      `gorm:"column:mail;not null"`
+	Amount money.Money `gorm:"column:amount;type:numeric(12,2);not null"`
+	Status string      `gorm:"column:status;not null"`
+	Error  string      `gorm:"column:error"`
+	SentAt time.Time   `gorm:"column:sent_at;not null;index"`
+}
+
+func (DuesReminder) TableName() string {
+	return "dues_reminders"
+}
+
+const (
+	MailPending = "pending"
+	MailSending = "sending"
+	MailSent    = "sent"
+	MailFailed  = "failed"
+)
+
+// ReceiptMail is the receipt email of an online payment waiting to be sent.
+// It is queued with the payment, so a failed send is tried again from here
+// at NextAttempt rather than by processing the payment again. Balance is the
+// flat's balance right after the payment was booked. A sender claims the row
+// by moving it to sending, and NextAttempt then marks when an abandoned claim
+// may be taken over.
+type ReceiptMail struct {
+	ID          int         `gorm:"primaryKey;column:id;autoIncrement"`
+	ReceiptNo   int         `gorm:"column:receipt_no;not null;uniqueIndex"`
+	Mail        string      `gorm:"column:mail;not null"`
+	Balance     money.Money `gorm:"column:balance;type:numeric(12,2);not null"`
+	Status      string      `gorm:"column:status;not null;index"`
+	Attempts    int         `gorm:"column:attempts;not null;default:0"`
+	Error       string      `gorm:"column:error"`
+	NextAttempt time.Time   `gorm:"column:next_attempt;not null"`
+	SentAt      *time.Time  `gorm:"column:sent_at"`
+	CreatedAt   time.Time   `gorm:"column:created_at;autoCreateTime"`
 }
 
-func (Merchant) TableName() string {
-	return "merchants"
+func (ReceiptMail) TableName() string {
+	return "receipt_mails"
 }
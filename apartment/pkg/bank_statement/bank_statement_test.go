@@ -0,0 +1,157 @@
+package bankstatement
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCSV(t *testing.T) {
+	content := "\ufeffDate;Amount;Description;Reference;Counterparty\n" +
+		"01.10.2026;1.250,50;DAİRE NO:5 EKİM AİDAT;B-1;AYSE YILMAZ\n" +
+		";;;;\n" +
+		"02.10.2026; -300,00 ;temizlik;;\n"
+
+	lines, err := ParseCSV(strings.NewReader(content), DefaultMapping())
+	assert.NoError(t, err)
+	assert.Len(t, lines, 2)
+	assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local), lines[0].Date)
+	assert.Equal(t, money.New(125050), lines[0].Amount)
+	assert.Equal(t, "DAİRE NO:5 EKİM AİDAT", lines[0].Description)
+	assert.Equal(t, "B-1", lines[0].Reference)
+	assert.Equal(t, "AYSE YILMAZ", lines[0].Counterparty)
+	assert.Equal(t, money.New(-30000), lines[1].Amount)
+	assert.Empty(t, lines[1].Reference)
+}
+
+func TestParseCSVWithMapping(t *testing.T) {
+	content := "tarih,tutar,aciklama\n" +
+		"2026-10-01,\"1,250.50\",aidat\n"
+
+	lines, err := ParseCSV(strings.NewReader(content), Mapping{
+		Date:        "tarih",
+		Amount:      "tutar",
+		Description: "aciklama",
+		Reference:   "referans",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, lines, 1)
+	assert.Equal(t, money.New(125050), lines[0].Amount)
+	assert.Empty(t, lines[0].Reference)
+}
+
+func TestParseCSVMalformed(t *testing.T) {
+	cases := map[string]string{
+		"":                             "statement is empty",
+		"date;amount\n01.10.2026;10\n": `there is no "description" column`,
+		"date;amount;description\n2026-10-01;10;x\n":   "row 2: date",
+		"date;amount;description\n01.10.2026;on;x\n":   `row 2: amount "on" is not a number`,
+		"date;amount;description\n01.10.2026;\"10;x\n": "",
+	}
+	for content, message := range cases {
+		_, err := ParseCSV(strings.NewReader(content), DefaultMapping())
+		assert.Error(t, err, content)
+		if message != "" {
+			assert.Contains(t, err.Error(), message, content)
+		}
+	}
+
+	_, err := ParseCSV(strings.NewReader("date;amount;description\n"), Mapping{Date: "date", Amount: "amount", Comma: ';'})
+	assert.EqualError(t, err, "column mapping is incomplete")
+}
+
+const camtStatement = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+  <BkToCstmrStmt>
+    <Stmt>
+      <Ntry>
+        <Amt Ccy="TRY">500.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <BookgDt><Dt>2026-10-03</Dt></BookgDt>
+        <AcctSvcrRef>TRX-1</AcctSvcrRef>
+        <NtryDtls><TxDtls>
+          <RltdPties><Dbtr><Nm>AYSE YILMAZ</Nm></Dbtr></RltdPties>
+          <RmtInf><Ustrd>EKIM AIDAT</Ustrd></RmtInf>
+        </TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="TRY">1200.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <ValDt><DtTm>2026-10-04T10:30:00+03:00</DtTm></ValDt>
+        <AddtlNtryInf>ELEKTRIK FATURASI</AddtlNtryInf>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">150.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <BookgDt><Dt>2026-10-05</Dt></BookgDt>
+        <AddtlNtryInf>TOPLU ODEME</AddtlNtryInf>
+        <NtryDtls>
+          <TxDtls>
+            <Amt Ccy="EUR">100.00</Amt>
+            <Refs><EndToEndId>E2E-1</EndToEndId></Refs>
+            <RltdPties><Dbtr><Pty><Nm>JOHN DOE</Nm></Pty></Dbtr></RltdPties>
+          </TxDtls>
+          <TxDtls>
+            <AmtDtls><TxAmt><Amt Ccy="EUR">50.00</Amt></TxAmt></AmtDtls>
+            <Refs><AcctSvcrRef>TRX-3B</AcctSvcrRef><EndToEndId>NOTPROVIDED</EndToEndId></Refs>
+            <RmtInf><Strd><CdtrRefInf><Ref>RF18</Ref></CdtrRefInf></Strd></RmtInf>
+          </TxDtls>
+        </NtryDtls>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>`
+
+func TestParseCamt053(t *testing.T) {
+	lines, err := ParseCamt053(strings.NewReader(camtStatement))
+	assert.NoError(t, err)
+	assert.Len(t, lines, 4)
+
+	assert.Equal(t, time.Date(2026, 10, 3, 0, 0, 0, 0, time.Local), lines[0].Date)
+	assert.Equal(t, money.New(50000), lines[0].Amount)
+	assert.Equal(t, "TRY", lines[0].Currency)
+	assert.Equal(t, "EKIM AIDAT", lines[0].Description)
+	assert.Equal(t, "TRX-1", lines[0].Reference)
+	assert.Equal(t, "AYSE YILMAZ", lines[0].Counterparty)
+
+	assert.Equal(t, money.New(-120000), lines[1].Amount)
+	assert.Equal(t, "ELEKTRIK FATURASI", lines[1].Description)
+	assert.Equal(t, "2026-10-04", lines[1].Date.Format("2006-01-02"))
+
+	assert.Equal(t, money.New(10000), lines[2].Amount)
+	assert.Equal(t, "EUR", lines[2].Currency)
+	assert.Equal(t, "TOPLU ODEME", lines[2].Description)
+	assert.Equal(t, "E2E-1", lines[2].Reference)
+	assert.Equal(t, "JOHN DOE", lines[2].Counterparty)
+
+	assert.Equal(t, money.New(5000), lines[3].Amount)
+	assert.Equal(t, "EUR", lines[3].Currency)
+	assert.Equal(t, "RF18", lines[3].Description)
+	assert.Equal(t, "TRX-3B", lines[3].Reference)
+}
+
+func TestParseCamt053Malformed(t *testing.T) {
+	entry := func(body string) string {
+		return `<Document><BkToCstmrStmt><Stmt><Ntry>` + body + `</Ntry></Stmt></BkToCstmrStmt></Document>`
+	}
+	cases := map[string]string{
+		"not xml at all":            "statement is not valid camt.053",
+		"<Document><BkToCstmrStmt>": "statement is not valid camt.053",
+		entry(`<Amt Ccy="TRY">10.00</Amt><CdtDbtInd>CRDT</CdtDbtInd>`):                                       "entry 1: entry has no booking date",
+		entry(`<Amt Ccy="TRY">ten</Amt><CdtDbtInd>CRDT</CdtDbtInd><BookgDt><Dt>2026-10-03</Dt></BookgDt>`):   `entry 1: amount "ten" is not a number`,
+		entry(`<Amt Ccy="TRY">10.00</Amt><CdtDbtInd>RVSL</CdtDbtInd><BookgDt><Dt>2026-10-03</Dt></BookgDt>`): `entry 1: unknown credit/debit indicator "RVSL"`,
+		entry(`<Amt Ccy="TRY">10.00</Amt><CdtDbtInd>CRDT</CdtDbtInd><BookgDt><Dt>03.10.2026</Dt></BookgDt>`): "entry 1:",
+	}
+	for content, message := range cases {
+		_, err := ParseCamt053(strings.NewReader(content))
+		assert.Error(t, err, content)
+		assert.Contains(t, err.Error(), message, content)
+	}
+
+	lines, err := ParseCamt053(strings.NewReader(`<Document><BkToCstmrStmt><Stmt></Stmt></BkToCstmrStmt></Document>`))
+	assert.NoError(t, err)
+	assert.Empty(t, lines)
+}
@@ -0,0 +1,46 @@
+package bankstatement
+
+import (
+	"time"
+
+	"github.com/pragmataW/apartment_management/pkg/money"
+)
+
+// Line is one booking read from a bank statement. Credits are positive and
+// debits negative.
+type Line struct {
+	Date         time.Time
+	Amount       money.Money
+	Currency     string
+	Description  string
+	Reference    string
+	Counterparty string
+}
+
+// Mapping tells ParseCSV which header holds which field. Headers are matched
+// case-insensitively; Reference and Counterparty may be left empty when the
+// bank does not export them.
+type Mapping struct {
+	Date         string
+	Amount       string
+	Description  string
+	Reference    string
+	Counterparty string
+	DateLayout   string
+	Comma        rune
+	DecimalComma bool
+}
+
+// DefaultMapping is the layout most Turkish banks use for their CSV exports.
+func DefaultMapping() Mapping {
+	return Mapping{
+		Date:         "date",
+		Amount:       "amount",
+		Description:  "description",
+		Reference:    "reference",
+		Counterparty: "counterparty",
+		DateLayout:   "02.01.2006",
+		Comma:        ';',
+		DecimalComma: true,
+	}
+}
@@ -0,0 +1,193 @@
+package bankstatement
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pragmataW/apartment_management/pkg/money"
+)
+
+// camtDocument holds the parts of an ISO 20022 camt.053 statement that are
+// needed to book a payment. Tags are matched without their namespace so that
+// any version of the message is accepted.
+type camtDocument struct {
+	Statements []struct {
+		Entries []camtEntry `xml:"Ntry"`
+	} `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtEntry struct {
+	Amount      camtAmount `xml:"Amt"`
+	Indicator   string     `xml:"CdtDbtInd"`
+	BookingDate camtDate   `xml:"BookgDt"`
+	ValueDate   camtDate   `xml:"ValDt"`
+	Reference   string     `xml:"AcctSvcrRef"`
+	Info        string     `xml:"AddtlNtryInf"`
+	Details     []camtTx   `xml:"NtryDtls>TxDtls"`
+}
+
+type camtTx struct {
+	Amount       camtAmount `xml:"Amt"`
+	TxAmount     camtAmount `xml:"AmtDtls>TxAmt>Amt"`
+	Indicator    string     `xml:"CdtDbtInd"`
+	Reference    string     `xml:"Refs>AcctSvcrRef"`
+	EndToEndID   string     `xml:"Refs>EndToEndId"`
+	Unstructured []string   `xml:"RmtInf>Ustrd"`
+	Structured   []string   `xml:"RmtInf>Strd>CdtrRefInf>Ref"`
+	Debtor       string     `xml:"RltdPties>Dbtr>Nm"`
+	DebtorParty  string     `xml:"RltdPties>Dbtr>Pty>Nm"`
+	Info         string     `xml:"AddtlTxInf"`
+}
+
+type camtAmount struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+// ParseCamt053 reads an ISO 20022 camt.053 statement. An entry that batches
+// several transactions gives one line per transaction.
+func ParseCamt053(r io.Reader) ([]Line, error) {
+	var doc camtDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("statement is not valid camt.053: %v", err)
+	}
+
+	var lines []Line
+	for _, statement := range doc.Statements {
+		for i, entry := range statement.Entries {
+			date, err := entry.date()
+			if err != nil {
+				return nil, fmt.Errorf("entry %d: %v", i+1, err)
+			}
+
+			if len(entry.Details) <= 1 {
+				line, err := entry.line(date)
+				if err != nil {
+					return nil, fmt.Errorf("entry %d: %v", i+1, err)
+				}
+				lines = append(lines, line)
+				continue
+			}
+
+			for _, tx := range entry.Details {
+				line, err := tx.line(entry, date)
+				if err != nil {
+					return nil, fmt.Errorf("entry %d: %v", i+1, err)
+				}
+				lines = append(lines, line)
+			}
+		}
+	}
+	return lines, nil
+}
+
+func (e camtEntry) date() (time.Time, error) {
+	for _, d := range []camtDate{e.BookingDate, e.ValueDate} {
+		if d.Date != "" {
+			return time.ParseInLocation("2006-01-02", strings.TrimSpace(d.Date), time.Local)
+		}
+		if d.DateTime != "" {
+			t, err := time.Parse(time.RFC3339, strings.TrimSpace(d.DateTime))
+			if err != nil {
+				return time.Parse("2006-01-02T15:04:05", strings.TrimSpace(d.DateTime))
+			}
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("entry has no booking date")
+}
+
+func (e camtEntry) line(date time.Time) (Line, error) {
+	amount, err := signedAmount(e.Amount.Value, e.Indicator)
+	if err != nil {
+		return Line{}, err
+	}
+
+	line := Line{
+		Date:        date,
+		Amount:      amount,
+		Currency:    e.Amount.Currency,
+		Description: e.Info,
+		Reference:   strings.TrimSpace(e.Reference),
+	}
+	if len(e.Details) == 1 {
+		e.Details[0].fill(&line)
+	}
+	return line, nil
+}
+
+func (t camtTx) line(entry camtEntry, date time.Time) (Line, error) {
+	amount := t.Amount
+	if amount.Value == "" {
+		amount = t.TxAmount
+	}
+	indicator := t.Indicator
+	if indicator == "" {
+		indicator = entry.Indicator
+	}
+
+	value, err := signedAmount(amount.Value, indicator)
+	if err != nil {
+		return Line{}, err
+	}
+
+	line := Line{
+		Date:        date,
+		Amount:      value,
+		Currency:    amount.Currency,
+		Description: entry.Info,
+	}
+	t.fill(&line)
+	return line, nil
+}
+
+// fill takes the remittance text, debtor and the most specific reference of
+// the transaction into the line.
+func (t camtTx) fill(line *Line) {
+	var parts []string
+	for _, part := range append(t.Unstructured, t.Structured...) {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if info := strings.TrimSpace(t.Info); info != "" {
+		parts = append(parts, info)
+	}
+	if len(parts) > 0 {
+		line.Description = strings.Join(parts, " ")
+	}
+
+	line.Counterparty = strings.TrimSpace(t.Debtor)
+	if line.Counterparty == "" {
+		line.Counterparty = strings.TrimSpace(t.DebtorParty)
+	}
+
+	if ref := strings.TrimSpace(t.Reference); ref != "" {
+		line.Reference = ref
+	} else if ref := strings.TrimSpace(t.EndToEndID); ref != "" && ref != "NOTPROVIDED" && line.Reference == "" {
+		line.Reference = ref
+	}
+}
+
+func signedAmount(value string, indicator string) (money.Money, error) {
+	amount, err := money.Parse(value)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("amount %q is not a number", value)
+	}
+
+	switch strings.TrimSpace(indicator) {
+	case "CRDT":
+		return amount, nil
+	case "DBIT":
+		return amount.Neg(), nil
+	}
+	return money.Money{}, fmt.Errorf("unknown credit/debit indicator %q", indicator)
+}
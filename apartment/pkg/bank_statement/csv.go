@@ -0,0 +1,142 @@
+package bankstatement
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pragmataW/apartment_management/pkg/money"
+)
+
+// ParseCSV reads a statement exported as CSV. The first row must be the
+// header; empty rows are skipped.
+func ParseCSV(r io.Reader, mapping Mapping) ([]Line, error) {
+	reader := csv.NewReader(r)
+	if mapping.Comma != 0 {
+		reader.Comma = mapping.Comma
+	}
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, errors.New("statement is empty")
+		}
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.TrimPrefix(name, "\ufeff")
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	column := func(name string, required bool) (int, error) {
+		if name == "" {
+			if required {
+				return -1, errors.New("column mapping is incomplete")
+			}
+			return -1, nil
+		}
+		i, ok := columns[strings.ToLower(name)]
+		if !ok {
+			if required {
+				return -1, fmt.Errorf("there is no %q column", name)
+			}
+			return -1, nil
+		}
+		return i, nil
+	}
+
+	dateCol, err := column(mapping.Date, true)
+	if err != nil {
+		return nil, err
+	}
+	amountCol, err := column(mapping.Amount, true)
+	if err != nil {
+		return nil, err
+	}
+	descriptionCol, err := column(mapping.Description, true)
+	if err != nil {
+		return nil, err
+	}
+	referenceCol, _ := column(mapping.Reference, false)
+	counterpartyCol, _ := column(mapping.Counterparty, false)
+
+	layout := mapping.DateLayout
+	if layout == "" {
+		layout = "2006-01-02"
+	}
+
+	var lines []Line
+	row := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		row++
+		if err != nil {
+			return nil, err
+		}
+		if blank(record) {
+			continue
+		}
+
+		date, err := time.ParseInLocation(layout, field(record, dateCol), time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: date %q does not match %s", row, field(record, dateCol), layout)
+		}
+		amount, err := parseAmount(field(record, amountCol), mapping.DecimalComma)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %v", row, err)
+		}
+
+		lines = append(lines, Line{
+			Date:         date,
+			Amount:       amount,
+			Description:  field(record, descriptionCol),
+			Reference:    field(record, referenceCol),
+			Counterparty: field(record, counterpartyCol),
+		})
+	}
+	return lines, nil
+}
+
+func field(record []string, i int) string {
+	if i < 0 || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+func blank(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// parseAmount reads amounts like "1.250,50" when decimalComma is set and
+// "1,250.50" otherwise.
+func parseAmount(value string, decimalComma bool) (money.Money, error) {
+	clean := strings.ReplaceAll(value, " ", "")
+	if decimalComma {
+		clean = strings.ReplaceAll(clean, ".", "")
+		clean = strings.ReplaceAll(clean, ",", ".")
+	} else {
+		clean = strings.ReplaceAll(clean, ",", "")
+	}
+
+	amount, err := money.Parse(clean)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("amount %q is not a number", value)
+	}
+	return amount, nil
+}
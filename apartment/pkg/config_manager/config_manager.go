@@ -16,14 +16,33 @@ type configManager struct {
 	merchantSalt  string
 	okUrl         string
 	failUrl       string
+	paymentPage   string
+
+	paymentProvider     string
+	fakePaymentSecret   string
+	fakePaymentCallback string
+	fakePaymentStatus   string
 }
 
+// NewConfigManager reads the settings from the environment. PAYMENT_PROVIDER
+// picks "paytr", the default, or "fake" for the local provider, which needs
+// no merchant account.
 func NewConfigManager() configManager {
+	paymentProvider := os.Getenv("PAYMENT_PROVIDER")
+	if paymentProvider == "" {
+		paymentProvider = "paytr"
+	}
+
 	merchantId, err := strconv.Atoi(os.Getenv("MERCHANT_ID"))
-	if err != nil {
+	if err != nil && paymentProvider == "paytr" {
 		log.Fatal(err)
 	}
 
+	fakePaymentCallback := os.Getenv("FAKE_PAYMENT_CALLBACK_URL")
+	if fakePaymentCallback == "" {
+		fakePaymentCallback = "http://localhost:2009/payment/callback"
+	}
+
 	return configManager{
 		adminPassword: os.Getenv("ADMIN_PASS"),
 		jwtKey:        os.Getenv("JWT_KEY"),
@@ -34,6 +53,12 @@ func NewConfigManager() configManager {
 		merchantSalt:  os.Getenv("MERCHANT_SALT"),
 		okUrl:         os.Getenv("PAYMENT_OK_URL"),
 		failUrl:       os.Getenv("PAYMENT_FAIL_URL"),
+		paymentPage:   os.Getenv("PAYMENT_PAGE_URL"),
+
+		paymentProvider:     paymentProvider,
+		fakePaymentSecret:   os.Getenv("FAKE_PAYMENT_SECRET"),
+		fakePaymentCallback: fakePaymentCallback,
+		fakePaymentStatus:   os.Getenv("FAKE_PAYMENT_STATUS"),
 	}
 }
 
@@ -73,3 +98,22 @@ func (c configManager) GetFailUrl() string {
 	return c.failUrl
 }
 
+func (c configManager) GetPaymentPageUrl() string {
+	return c.paymentPage
+}
+
+func (c configManager) GetPaymentProvider() string {
+	return c.paymentProvider
+}
+
+func (c configManager) GetFakePaymentSecret() string {
+	return c.fakePaymentSecret
+}
+
+func (c configManager) GetFakePaymentCallbackUrl() string {
+	return c.fakePaymentCallback
+}
+
+func (c configManager) GetFakePaymentStatus() string {
+	return c.fakePaymentStatus
+}
@@ -0,0 +1,9 @@
+package filestore
+
+type fileStore struct {
+	dir string
+}
+
+func NewFileStore(dir string) *fileStore {
+	return &fileStore{dir: dir}
+}
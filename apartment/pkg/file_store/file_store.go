@@ -0,0 +1,63 @@
+package filestore
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Save writes content to the store under name, replacing any file already
+// stored under it. The directory is created on first use.
+func (f *fileStore) Save(name string, content io.Reader) error {
+	path, err := f.path(name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(f.dir, 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(file, content); err != nil {
+		file.Close()
+		os.Remove(path)
+		return err
+	}
+	return file.Close()
+}
+
+func (f *fileStore) Open(name string) (io.ReadCloser, error) {
+	path, err := f.path(name)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(path)
+}
+
+// Remove deletes the file stored under name. A file that is already gone is
+// not an error.
+func (f *fileStore) Remove(name string) error {
+	path, err := f.path(name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path keeps names inside the store directory.
+func (f *fileStore) path(name string) (string, error) {
+	if name == "" || name != filepath.Base(name) || name == "." || name == ".." {
+		return "", errors.New("invalid file name: " + name)
+	}
+	return filepath.Join(f.dir, name), nil
+}
@@ -0,0 +1,28 @@
+package money
+
+// TRY is the Turkish lira, whose minor unit is the kuruş. It is the currency
+// of every amount the building keeps.
+const TRY = "TRY"
+
+// Money is an exact amount held as a whole number of the currency's minor
+// unit, so 40.10 TRY is Money{Minor: 4010}. An empty Currency is read as TRY,
+// which keeps the zero value usable and equal to New(0). Amounts in different
+// currencies never mix: Add, Sub and Cmp panic on them, and only TRY amounts
+// are stored, as the columns carry no currency.
+//
+// Rounding rules:
+//   - Anything finer than a minor unit, whether parsed from text, taken from
+//     a float or produced by Mul, is rounded half away from zero: 40.105
+//     becomes 40.11 and -40.105 becomes -40.11.
+//   - Allocate never loses or invents minor units: every part is rounded
+//     down by integer division, and the units left over go one at a time to
+//     the parts with the largest remainders, the earlier part first on a tie.
+type Money struct {
+	Minor    int64
+	Currency string
+}
+
+// New returns minor units of TRY.
+func New(minor int64) Money {
+	return Money{Minor: minor}
+}
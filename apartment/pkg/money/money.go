@@ -0,0 +1,329 @@
+package money
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FromFloat converts an amount in major units. The float is read by its
+// shortest decimal form, so FromFloat(40.105) is 40.11 and not the 40.10 that
+// math.Round(40.105*100) would give.
+func FromFloat(amount float64) Money {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return New(0)
+	}
+	m, _ := Parse(strconv.FormatFloat(amount, 'f', -1, 64))
+	return m
+}
+
+// Parse reads a decimal amount in major units such as "1250.5" or "-40.10".
+// Digits past the minor unit are rounded half away from zero.
+func Parse(s string) (Money, error) {
+	text := strings.TrimSpace(s)
+	negative := false
+	switch {
+	case strings.HasPrefix(text, "-"):
+		negative = true
+		text = text[1:]
+	case strings.HasPrefix(text, "+"):
+		text = text[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(text, ".")
+	if whole == "" && fraction == "" || !digits(whole) || !digits(fraction) {
+		return Money{}, fmt.Errorf("money: %q is not an amount", s)
+	}
+
+	fraction += "000"
+	minor, err := strconv.ParseInt(whole+fraction[:2], 10, 64)
+	if whole == "" {
+		minor, err = strconv.ParseInt(fraction[:2], 10, 64)
+	}
+	if err != nil {
+		return Money{}, fmt.Errorf("money: %q is out of range", s)
+	}
+	if fraction[2] >= '5' {
+		minor++
+	}
+	if negative {
+		minor = -minor
+	}
+	return New(minor), nil
+}
+
+// ParseMinor reads a whole number of minor units, the way PayTR sends
+// payment_amount and total_amount.
+func ParseMinor(s string) (Money, error) {
+	minor, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("money: %q is not a number of minor units", s)
+	}
+	return New(minor), nil
+}
+
+func digits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (m Money) currency() string {
+	if m.Currency == "" {
+		return TRY
+	}
+	return m.Currency
+}
+
+// same panics when the amounts are in different currencies; adding lira to
+// euros is a programming error, not something to recover from.
+func (m Money) same(other Money) string {
+	if m.currency() != other.currency() {
+		panic("money: " + m.currency() + " and " + other.currency() + " do not mix")
+	}
+	if m.Currency == "" {
+		return other.Currency
+	}
+	return m.Currency
+}
+
+func (m Money) Add(other Money) Money {
+	return Money{Minor: m.Minor + other.Minor, Currency: m.same(other)}
+}
+
+func (m Money) Sub(other Money) Money {
+	return Money{Minor: m.Minor - other.Minor, Currency: m.same(other)}
+}
+
+func (m Money) Neg() Money {
+	return Money{Minor: -m.Minor, Currency: m.Currency}
+}
+
+func (m Money) Abs() Money {
+	if m.Minor < 0 {
+		return m.Neg()
+	}
+	return Money{Minor: m.Minor, Currency: m.Currency}
+}
+
+// Mul multiplies the amount by factor, such as a dues coefficient or a
+// penalty rate, and rounds half away from zero. The factor is read by its
+// shortest decimal form, like FromFloat does.
+func (m Money) Mul(factor float64) Money {
+	rat, ok := new(big.Rat).SetString(strconv.FormatFloat(factor, 'f', -1, 64))
+	if !ok {
+		return Money{Currency: m.Currency}
+	}
+	rat.Mul(rat, new(big.Rat).SetInt64(m.Minor))
+	return Money{Minor: roundRat(rat), Currency: m.Currency}
+}
+
+func roundRat(rat *big.Rat) int64 {
+	num := new(big.Int).Abs(rat.Num())
+	quo, rem := new(big.Int).QuoRem(num, rat.Denom(), new(big.Int))
+	if rem.Mul(rem, big.NewInt(2)).Cmp(rat.Denom()) >= 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+	if rat.Sign() < 0 {
+		quo.Neg(quo)
+	}
+	return quo.Int64()
+}
+
+// Allocate divides the amount in proportion to the weights. It returns nil
+// when a weight is negative or the weights add up to nothing.
+func (m Money) Allocate(weights []float64) []Money {
+	shares, ok := wholeWeights(weights)
+	if !ok {
+		return nil
+	}
+	total := new(big.Int)
+	for _, share := range shares {
+		total.Add(total, share)
+	}
+	if total.Sign() == 0 {
+		return nil
+	}
+
+	sign := int64(1)
+	units := m.Minor
+	if units < 0 {
+		sign, units = -1, -units
+	}
+
+	parts := make([]int64, len(shares))
+	remainders := make([]*big.Int, len(shares))
+	var assigned int64
+	for i, share := range shares {
+		product := new(big.Int).Mul(big.NewInt(units), share)
+		quo, rem := new(big.Int).QuoRem(product, total, new(big.Int))
+		parts[i] = quo.Int64()
+		remainders[i] = rem
+		assigned += parts[i]
+	}
+
+	order := make([]int, len(shares))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]].Cmp(remainders[order[b]]) > 0
+	})
+	for i := 0; assigned < units; i++ {
+		parts[order[i]]++
+		assigned++
+	}
+
+	amounts := make([]Money, len(parts))
+	for i, part := range parts {
+		amounts[i] = Money{Minor: sign * part, Currency: m.Currency}
+	}
+	return amounts
+}
+
+// wholeWeights scales the weights, each read by its shortest decimal form, to
+// whole numbers in the same proportion, so that Allocate can split with
+// integer division. It reports false for a negative or non-finite weight.
+func wholeWeights(weights []float64) ([]*big.Int, bool) {
+	rats := make([]*big.Rat, len(weights))
+	denom := big.NewInt(1)
+	for i, weight := range weights {
+		if weight < 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+			return nil, false
+		}
+		rat, ok := new(big.Rat).SetString(strconv.FormatFloat(weight, 'f', -1, 64))
+		if !ok {
+			return nil, false
+		}
+		rats[i] = rat
+		gcd := new(big.Int).GCD(nil, nil, denom, rat.Denom())
+		denom.Mul(denom, new(big.Int).Quo(rat.Denom(), gcd))
+	}
+
+	shares := make([]*big.Int, len(rats))
+	for i, rat := range rats {
+		shares[i] = new(big.Int).Mul(rat.Num(), new(big.Int).Quo(denom, rat.Denom()))
+	}
+	return shares, true
+}
+
+// Cmp returns -1, 0 or 1 as m is less than, equal to or more than other.
+func (m Money) Cmp(other Money) int {
+	m.same(other)
+	switch {
+	case m.Minor < other.Minor:
+		return -1
+	case m.Minor > other.Minor:
+		return 1
+	}
+	return 0
+}
+
+func (m Money) IsZero() bool {
+	return m.Minor == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.Minor > 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.Minor < 0
+}
+
+func Min(a Money, b Money) Money {
+	if a.Cmp(b) <= 0 {
+		return a
+	}
+	return b
+}
+
+func Sum(amounts ...Money) Money {
+	total := New(0)
+	for _, amount := range amounts {
+		total = total.Add(amount)
+	}
+	return total
+}
+
+// Float64 is the amount in major units. It is meant for ratios and display,
+// never for arithmetic on money.
+func (m Money) Float64() float64 {
+	return float64(m.Minor) / 100
+}
+
+// String writes the amount in major units with two decimals, like "-40.10".
+func (m Money) String() string {
+	minor := m.Minor
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, minor/100, minor%100)
+}
+
+// MinorString writes the amount as a whole number of minor units, the form
+// PayTR expects for payment_amount.
+func (m Money) MinorString() string {
+	return strconv.FormatInt(m.Minor, 10)
+}
+
+// MarshalJSON writes the amount as a JSON number in major units, so the API
+// keeps answering with amounts like 40.1.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON takes a JSON number or a quoted decimal and reads it without
+// going through a float.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	text := strings.Trim(string(data), `"`)
+	if text == "null" {
+		return nil
+	}
+	parsed, err := Parse(text)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Value stores the amount as a decimal string for a numeric column. Columns
+// hold lira only, so any other currency is refused rather than written as
+// if it were TRY.
+func (m Money) Value() (driver.Value, error) {
+	if m.currency() != TRY {
+		return nil, fmt.Errorf("money: %s amounts cannot be stored, only %s", m.Currency, TRY)
+	}
+	return m.String(), nil
+}
+
+// Scan reads a numeric column. Databases hand numerics over as text; floats
+// and integers are taken as major units.
+func (m *Money) Scan(src interface{}) error {
+	var err error
+	switch value := src.(type) {
+	case nil:
+		*m = New(0)
+	case string:
+		*m, err = Parse(value)
+	case []byte:
+		*m, err = Parse(string(value))
+	case float64:
+		*m = FromFloat(value)
+	case int64:
+		*m = New(value * 100)
+	default:
+		err = fmt.Errorf("money: cannot scan %T", src)
+	}
+	return err
+}
@@ -0,0 +1,153 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	cases := map[string]int64{
+		"40":       4000,
+		"40.1":     4010,
+		"40.10":    4010,
+		"+40.10":   4010,
+		"-40.10":   -4010,
+		".5":       50,
+		"40.104":   4010,
+		"40.105":   4011,
+		"-40.105":  -4011,
+		"0.005":    1,
+		"-0.005":   -1,
+		"0.00499":  0,
+		"99.995":   10000,
+		" 1250.50": 125050,
+	}
+	for text, minor := range cases {
+		m, err := Parse(text)
+		assert.NoError(t, err, text)
+		assert.Equal(t, New(minor), m, text)
+	}
+
+	for _, text := range []string{"", "-", ".", "40,10", "1.2.3", "1e3", "abc", "99999999999999999999"} {
+		_, err := Parse(text)
+		assert.Error(t, err, text)
+	}
+}
+
+func TestFromFloat(t *testing.T) {
+	assert.Equal(t, int64(4010), FromFloat(40.10).Minor)
+	assert.Equal(t, int64(4011), FromFloat(40.105).Minor)
+	assert.Equal(t, int64(-4011), FromFloat(-40.105).Minor)
+	assert.Equal(t, int64(30), FromFloat(0.1+0.2).Minor)
+	assert.Equal(t, int64(0), FromFloat(0).Minor)
+}
+
+func TestMinorUnits(t *testing.T) {
+	m, err := ParseMinor("4010")
+	assert.NoError(t, err)
+	assert.Equal(t, "40.10", m.String())
+	assert.Equal(t, "4010", m.MinorString())
+
+	_, err = ParseMinor("40.10")
+	assert.Error(t, err)
+
+	assert.Equal(t, "-0.05", New(-5).String())
+	assert.Equal(t, "0.00", Money{}.String())
+}
+
+func TestArithmetic(t *testing.T) {
+	a, b := New(4010), New(1995)
+	assert.Equal(t, New(6005), a.Add(b))
+	assert.Equal(t, New(2015), a.Sub(b))
+	assert.Equal(t, New(-4010), a.Neg())
+	assert.Equal(t, New(4010), a.Neg().Abs())
+	assert.Equal(t, b, Min(a, b))
+	assert.Equal(t, New(6005), Sum(a, b))
+	assert.Equal(t, New(0), Sum())
+	assert.Equal(t, 1, a.Cmp(b))
+	assert.Equal(t, New(4010), Money{Minor: 4000}.Add(New(10)))
+
+	euros := Money{Minor: 1, Currency: "EUR"}
+	assert.Panics(t, func() { a.Add(euros) })
+	assert.Panics(t, func() { a.Sub(euros) })
+	assert.Panics(t, func() { a.Cmp(euros) })
+	assert.Equal(t, Money{Minor: 2, Currency: "EUR"}, euros.Add(euros))
+}
+
+func TestMul(t *testing.T) {
+	assert.Equal(t, New(5013), New(4010).Mul(1.25))
+	assert.Equal(t, New(1), New(5).Mul(0.1))
+	assert.Equal(t, New(-1), New(-5).Mul(0.1))
+	assert.Equal(t, New(0), New(4).Mul(0.1))
+	assert.Equal(t, New(201), New(4010).Mul(0.05))
+}
+
+func TestAllocate(t *testing.T) {
+	parts := New(10000).Allocate([]float64{1, 1, 1})
+	assert.Equal(t, []Money{New(3334), New(3333), New(3333)}, parts)
+
+	parts = New(1001).Allocate([]float64{60, 40})
+	assert.Equal(t, []Money{New(601), New(400)}, parts)
+
+	parts = New(-1000).Allocate([]float64{1, 2})
+	assert.Equal(t, []Money{New(-333), New(-667)}, parts)
+	assert.Equal(t, New(-1000), Sum(parts...))
+
+	parts = New(100000).Allocate([]float64{0.1, 0.2, 0.3})
+	assert.Equal(t, []Money{New(16667), New(33333), New(50000)}, parts)
+
+	parts = New(1).Allocate([]float64{1, 1, 1})
+	assert.Equal(t, []Money{New(1), New(0), New(0)}, parts)
+
+	parts = New(999999999).Allocate([]float64{123.45, 67.8, 0.01})
+	assert.Equal(t, New(999999999), Sum(parts...))
+
+	assert.Nil(t, New(100).Allocate([]float64{0, 0}))
+	assert.Nil(t, New(100).Allocate(nil))
+	assert.Nil(t, New(100).Allocate([]float64{1, -1}))
+}
+
+func TestJSON(t *testing.T) {
+	var body struct {
+		Amount Money  `json:"amount"`
+		Quoted Money  `json:"quoted"`
+		Empty  *Money `json:"empty"`
+	}
+	err := json.Unmarshal([]byte(`{"amount":40.105,"quoted":"12.5","empty":null}`), &body)
+	assert.NoError(t, err)
+	assert.Equal(t, New(4011), body.Amount)
+	assert.Equal(t, New(1250), body.Quoted)
+	assert.Nil(t, body.Empty)
+
+	out, err := json.Marshal(body)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"amount":40.11,"quoted":12.50,"empty":null}`, string(out))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"amount":true}`), &body))
+}
+
+func TestScan(t *testing.T) {
+	var m Money
+	assert.NoError(t, m.Scan("40.1000"))
+	assert.Equal(t, New(4010), m)
+	assert.NoError(t, m.Scan([]byte("-3.5")))
+	assert.Equal(t, New(-350), m)
+	assert.NoError(t, m.Scan(int64(7)))
+	assert.Equal(t, New(700), m)
+	assert.NoError(t, m.Scan(nil))
+	assert.Equal(t, New(0), m)
+	assert.Error(t, m.Scan(true))
+
+	value, err := New(-4010).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "-40.10", value)
+
+	value, err = Money{Minor: 4010, Currency: TRY}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "40.10", value)
+
+	_, err = Money{Minor: 4010, Currency: "EUR"}.Value()
+	assert.Error(t, err)
+}
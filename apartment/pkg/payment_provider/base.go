@@ -0,0 +1,42 @@
+package paymentprovider
+
+import (
+	"errors"
+
+	"github.com/pragmataW/apartment_management/pkg/money"
+)
+
+const (
+	ProviderPaytr = "paytr"
+	ProviderFake  = "fake"
+)
+
+// ErrBadSignature is returned for a callback whose hash does not match, which
+// means it did not come from the provider or was changed on the way.
+var ErrBadSignature = errors.New("bad hash")
+
+// Payment is an online payment to start with a provider. OrderID is our own
+// id for it, which the provider sends back in its callback.
+type Payment struct {
+	OrderID     string
+	Email       string
+	Amount      money.Money
+	UserName    string
+	UserAddress string
+	UserPhone   string
+	UserIP      string
+	Basket      [][]interface{}
+	DebugOn     bool
+	TestMode    bool
+}
+
+// Notice is what a provider's callback says about a payment once its
+// signature has been checked. TotalAmount is passed on as sent, in minor
+// units.
+type Notice struct {
+	OrderID     string
+	Success     bool
+	Status      string
+	TotalAmount string
+	FailReason  string
+}
@@ -0,0 +1,112 @@
+package paymentprovider
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pragmataW/apartment_management/pkg/money"
+)
+
+// FakeConfig sets up the local provider. Every payment ends with Status,
+// "success" by default, and its callback is posted to CallbackURL after
+// Delay.
+type FakeConfig struct {
+	Secret      string
+	CallbackURL string
+	Status      string
+	Delay       time.Duration
+}
+
+// fake stands in for PayTR in development and integration tests. It hands
+// out tokens without charging anyone and reports back through the same
+// callback PayTR uses, signed with its own secret.
+type fake struct {
+	cfg    FakeConfig
+	client *http.Client
+}
+
+func NewFake(cfg FakeConfig) *fake {
+	if cfg.Secret == "" {
+		cfg.Secret = "fake-secret"
+	}
+	if cfg.Status == "" {
+		cfg.Status = "success"
+	}
+	return &fake{cfg: cfg, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (f *fake) Name() string {
+	return ProviderFake
+}
+
+// GetToken returns a token at once and sends the payment's callback in the
+// background, the way PayTR does once the resident has paid.
+func (f *fake) GetToken(payment Payment) (string, error) {
+	go func() {
+		time.Sleep(f.cfg.Delay)
+		if err := f.SendCallback(payment); err != nil {
+			log.Println("fake payment " + payment.OrderID + ": " + err.Error())
+		}
+	}()
+	return "fake-" + payment.OrderID, nil
+}
+
+// SendCallback posts the signed callback of the payment to CallbackURL and
+// expects the "OK" PayTR expects.
+func (f *fake) SendCallback(payment Payment) error {
+	total := payment.Amount.MinorString()
+	form := url.Values{
+		"merchant_oid": {payment.OrderID},
+		"status":       {f.cfg.Status},
+		"total_amount": {total},
+		"hash":         {FakeSignature(f.cfg.Secret, payment.OrderID, f.cfg.Status, total)},
+	}
+	if f.cfg.Status != "success" {
+		form.Set("failed_reason_msg", "declined by the fake provider")
+	}
+
+	resp, err := f.client.PostForm(f.cfg.CallbackURL, form)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if answer := strings.TrimSpace(string(body)); answer != "OK" {
+		return fmt.Errorf("callback answered %q", answer)
+	}
+	return nil
+}
+
+// Refund always succeeds, there being no money to give back.
+func (f *fake) Refund(orderID string, amount money.Money) error {
+	log.Println("fake refund of " + amount.String() + " on payment " + orderID)
+	return nil
+}
+
+func (f *fake) VerifyCallback(form map[string]string) (Notice, error) {
+	expected := FakeSignature(f.cfg.Secret, form["merchant_oid"], form["status"], form["total_amount"])
+	if !hmac.Equal([]byte(form["hash"]), []byte(expected)) {
+		return Notice{}, ErrBadSignature
+	}
+	return noticeFromForm(form), nil
+}
+
+// FakeSignature is the hash the fake provider puts on a callback, so tests
+// can post callbacks of their own.
+func FakeSignature(secret string, orderID string, status string, totalAmount string) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(orderID + status + totalAmount))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
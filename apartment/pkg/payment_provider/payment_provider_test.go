@@ -0,0 +1,111 @@
+package paymentprovider
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaytrGetToken(t *testing.T) {
+	var form map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		form = map[string]string{}
+		for key := range r.PostForm {
+			form[key] = r.PostForm.Get(key)
+		}
+		w.Write([]byte(`{"status":"success","token":"iframe"}`))
+	}))
+	defer server.Close()
+
+	provider := NewPaytr(PaytrConfig{MerchantID: "123", MerchantKey: "key", MerchantSalt: "salt", TokenURL: server.URL})
+	token, err := provider.GetToken(Payment{OrderID: "oid", Email: "a@mail.com", Amount: money.New(12000), TestMode: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "iframe", token)
+	assert.Equal(t, "12000", form["payment_amount"])
+	assert.Equal(t, "1", form["test_mode"])
+	assert.NotEmpty(t, form["paytr_token"])
+}
+
+func TestPaytrRefund(t *testing.T) {
+	var form map[string]string
+	answer := `{"status":"success","merchant_oid":"oid","return_amount":"40.00"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		form = map[string]string{}
+		for key := range r.PostForm {
+			form[key] = r.PostForm.Get(key)
+		}
+		w.Write([]byte(answer))
+	}))
+	defer server.Close()
+
+	provider := NewPaytr(PaytrConfig{MerchantID: "123", MerchantKey: "key", MerchantSalt: "salt", RefundURL: server.URL})
+	err := provider.Refund("oid", money.New(4000))
+	assert.NoError(t, err)
+	assert.Equal(t, "40.00", form["return_amount"])
+
+	h := hmac.New(sha256.New, []byte("key"))
+	h.Write([]byte("123" + "oid" + "40.00" + "salt"))
+	assert.Equal(t, base64.StdEncoding.EncodeToString(h.Sum(nil)), form["paytr_token"])
+
+	answer = `{"status":"error","err_no":"005","err_msg":"refund amount is more than the payment"}`
+	err = provider.Refund("oid", money.New(40000))
+	assert.EqualError(t, err, "refund failed: refund amount is more than the payment")
+}
+
+func TestPaytrVerifyCallback(t *testing.T) {
+	provider := NewPaytr(PaytrConfig{MerchantKey: "key", MerchantSalt: "salt"})
+
+	h := hmac.New(sha256.New, []byte("key"))
+	h.Write([]byte("oid" + "salt" + "success" + "12000"))
+	form := map[string]string{
+		"merchant_oid": "oid",
+		"status":       "success",
+		"total_amount": "12000",
+		"hash":         base64.StdEncoding.EncodeToString(h.Sum(nil)),
+	}
+
+	notice, err := provider.VerifyCallback(form)
+	assert.NoError(t, err)
+	assert.Equal(t, Notice{OrderID: "oid", Success: true, Status: "success", TotalAmount: "12000"}, notice)
+
+	form["total_amount"] = "1"
+	_, err = provider.VerifyCallback(form)
+	assert.Equal(t, ErrBadSignature, err)
+}
+
+func TestFakeSendsSignedCallback(t *testing.T) {
+	var provider *fake
+	var notice Notice
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		form := map[string]string{}
+		for key := range r.PostForm {
+			form[key] = r.PostForm.Get(key)
+		}
+
+		var err error
+		notice, err = provider.VerifyCallback(form)
+		if err != nil {
+			w.Write([]byte(err.Error()))
+			return
+		}
+		w.Write([]byte("OK"))
+	}))
+	defer server.Close()
+
+	provider = NewFake(FakeConfig{CallbackURL: server.URL, Status: "failed"})
+	err := provider.SendCallback(Payment{OrderID: "oid", Amount: money.New(12000)})
+	assert.NoError(t, err)
+	assert.Equal(t, "oid", notice.OrderID)
+	assert.False(t, notice.Success)
+	assert.Equal(t, "12000", notice.TotalAmount)
+	assert.NotEmpty(t, notice.FailReason)
+}
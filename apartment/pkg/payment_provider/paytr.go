@@ -0,0 +1,194 @@
+package paymentprovider
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pragmataW/apartment_management/pkg/money"
+)
+
+const (
+	paytrTokenURL  = "https://www.paytr.com/odeme/api/get-token"
+	paytrRefundURL = "https://www.paytr.com/odeme/iade"
+)
+
+// PaytrConfig holds the merchant credentials and the pages PayTR sends the
+// resident back to. TokenURL and RefundURL default to PayTR's own endpoints.
+type PaytrConfig struct {
+	MerchantID   string
+	MerchantKey  string
+	MerchantSalt string
+	OkURL        string
+	FailURL      string
+	TokenURL     string
+	RefundURL    string
+}
+
+type paytr struct {
+	cfg    PaytrConfig
+	client *http.Client
+}
+
+func NewPaytr(cfg PaytrConfig) *paytr {
+	if cfg.TokenURL == "" {
+		cfg.TokenURL = paytrTokenURL
+	}
+	if cfg.RefundURL == "" {
+		cfg.RefundURL = paytrRefundURL
+	}
+	return &paytr{cfg: cfg, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+func (p *paytr) Name() string {
+	return ProviderPaytr
+}
+
+// GetToken asks PayTR for the iframe token of the payment.
+func (p *paytr) GetToken(payment Payment) (string, error) {
+	basketJSON, err := json.Marshal(payment.Basket)
+	if err != nil {
+		return "", err
+	}
+	basket := base64.StdEncoding.EncodeToString(basketJSON)
+
+	amount := payment.Amount.MinorString()
+	noInstallment := "1"
+	maxInstallment := "0"
+	currency := "TL"
+	testMode := flag(payment.TestMode)
+
+	hashStr := strings.Join([]string{
+		p.cfg.MerchantID, payment.UserIP, payment.OrderID, payment.Email, amount,
+		basket, noInstallment, maxInstallment, currency, testMode,
+	}, "")
+	h := hmac.New(sha256.New, []byte(p.cfg.MerchantKey))
+	h.Write([]byte(hashStr))
+	h.Write([]byte(p.cfg.MerchantSalt))
+	token := base64.StdEncoding.EncodeToString(h.Sum(nil))
+
+	params := url.Values{
+		"merchant_id":       {p.cfg.MerchantID},
+		"user_ip":           {payment.UserIP},
+		"merchant_oid":      {payment.OrderID},
+		"email":             {payment.Email},
+		"payment_amount":    {amount},
+		"paytr_token":       {token},
+		"user_basket":       {basket},
+		"debug_on":          {flag(payment.DebugOn)},
+		"no_installment":    {noInstallment},
+		"max_installment":   {maxInstallment},
+		"user_name":         {payment.UserName},
+		"user_address":      {payment.UserAddress},
+		"user_phone":        {payment.UserPhone},
+		"merchant_ok_url":   {p.cfg.OkURL},
+		"merchant_fail_url": {p.cfg.FailURL},
+		"timeout_limit":     {"30"},
+		"currency":          {currency},
+		"test_mode":         {testMode},
+	}
+
+	resp, err := p.client.PostForm(p.cfg.TokenURL, params)
+	if err != nil {
+		return "", fmt.Errorf("post request error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("read response body error: %v", err)
+	}
+
+	var res map[string]interface{}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return "", fmt.Errorf("decode response body error: %v", err)
+	}
+
+	if status, ok := res["status"].(string); !ok || status != "success" {
+		return "", fmt.Errorf("status not ok: %s", string(body))
+	}
+	iframeToken, ok := res["token"].(string)
+	if !ok {
+		return "", fmt.Errorf("there is no token in response")
+	}
+	return iframeToken, nil
+}
+
+// Refund gives back the amount, or part of it, of a completed payment through
+// PayTR's refund API, which takes the amount in lira rather than kuruş.
+func (p *paytr) Refund(orderID string, amount money.Money) error {
+	returnAmount := amount.String()
+
+	h := hmac.New(sha256.New, []byte(p.cfg.MerchantKey))
+	h.Write([]byte(p.cfg.MerchantID + orderID + returnAmount + p.cfg.MerchantSalt))
+	token := base64.StdEncoding.EncodeToString(h.Sum(nil))
+
+	params := url.Values{
+		"merchant_id":   {p.cfg.MerchantID},
+		"merchant_oid":  {orderID},
+		"return_amount": {returnAmount},
+		"paytr_token":   {token},
+	}
+
+	resp, err := p.client.PostForm(p.cfg.RefundURL, params)
+	if err != nil {
+		return fmt.Errorf("post request error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response body error: %v", err)
+	}
+
+	var res map[string]interface{}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return fmt.Errorf("decode response body error: %v", err)
+	}
+
+	if status, ok := res["status"].(string); !ok || status != "success" {
+		if message, ok := res["err_msg"].(string); ok {
+			return fmt.Errorf("refund failed: %s", message)
+		}
+		return fmt.Errorf("status not ok: %s", string(body))
+	}
+	return nil
+}
+
+// VerifyCallback checks the hash PayTR puts on its callback, made from the
+// order id, the salt, the status and the total amount.
+func (p *paytr) VerifyCallback(form map[string]string) (Notice, error) {
+	hashStr := form["merchant_oid"] + p.cfg.MerchantSalt + form["status"] + form["total_amount"]
+	h := hmac.New(sha256.New, []byte(p.cfg.MerchantKey))
+	h.Write([]byte(hashStr))
+	expected := base64.StdEncoding.EncodeToString(h.Sum(nil))
+
+	if !hmac.Equal([]byte(form["hash"]), []byte(expected)) {
+		return Notice{}, ErrBadSignature
+	}
+	return noticeFromForm(form), nil
+}
+
+func noticeFromForm(form map[string]string) Notice {
+	return Notice{
+		OrderID:     form["merchant_oid"],
+		Success:     form["status"] == "success",
+		Status:      form["status"],
+		TotalAmount: form["total_amount"],
+		FailReason:  form["failed_reason_msg"],
+	}
+}
+
+func flag(on bool) string {
+	if on {
+		return "1"
+	}
+	return "0"
+}
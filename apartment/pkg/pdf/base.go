@@ -0,0 +1,22 @@
+package pdf
+
+import "bytes"
+
+// document is a plain text A4 document set in Courier. A fixed-width font
+// keeps the writer free of font metrics and lets tables line up by padding.
+type document struct {
+	pages []*bytes.Buffer
+	y     float64
+}
+
+// Column is one column of a table row, Width characters wide.
+type Column struct {
+	Width int
+	Right bool
+}
+
+func NewDocument() *document {
+	d := &document{}
+	d.addPage()
+	return d
+}
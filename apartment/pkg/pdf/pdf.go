@@ -0,0 +1,126 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	pageWidth  = 595.28
+	pageHeight = 841.89
+	margin     = 40.0
+	lineHeight = 1.4
+)
+
+// Text writes a line of text at the left margin, starting a new page when
+// the current one is full.
+func (d *document) Text(text string, size float64, bold bool) {
+	if d.y-size*lineHeight < margin {
+		d.addPage()
+	}
+	d.y -= size * lineHeight
+
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(d.pages[len(d.pages)-1], "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, margin, d.y, escape(text))
+}
+
+// Row writes cells padded to their columns as a single line. A cell longer
+// than its column is cut short.
+func (d *document) Row(columns []Column, cells []string, size float64, bold bool) {
+	var line strings.Builder
+	for i, column := range columns {
+		cell := ""
+		if i < len(cells) {
+			cell = cells[i]
+		}
+		if n := utf8.RuneCountInString(cell); n > column.Width {
+			cell = string([]rune(cell)[:column.Width])
+		}
+
+		padding := strings.Repeat(" ", column.Width-utf8.RuneCountInString(cell))
+		if i > 0 {
+			line.WriteString(" ")
+		}
+		if column.Right {
+			line.WriteString(padding + cell)
+		} else {
+			line.WriteString(cell + padding)
+		}
+	}
+	d.Text(strings.TrimRight(line.String(), " "), size, bold)
+}
+
+// Space leaves points of empty space below the last line.
+func (d *document) Space(points float64) {
+	d.y -= points
+}
+
+// Bytes renders the document as a PDF file.
+func (d *document) Bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier-Bold /Encoding /WinAnsiEncoding >>")
+	for i, page := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>", pageWidth, pageHeight, 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+func (d *document) addPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pageHeight - margin
+}
+
+// turkish maps the Turkish letters WinAnsiEncoding lacks to their closest
+// ASCII letter. The others (ç, ö, ü) are in the encoding.
+var turkish = map[rune]byte{
+	'ş': 's', 'Ş': 'S', 'ğ': 'g', 'Ğ': 'G', 'ı': 'i', 'İ': 'I',
+}
+
+// escape turns text into the bytes of a PDF string in WinAnsiEncoding.
+func escape(text string) string {
+	var out strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			out.WriteByte('\\')
+			out.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			out.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&out, "\\%03o", r)
+		case turkish[r] != 0:
+			out.WriteByte(turkish[r])
+		default:
+			out.WriteByte('?')
+		}
+	}
+	return out.String()
+}
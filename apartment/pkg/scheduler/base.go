@@ -0,0 +1,20 @@
+package scheduler
+
+import (
+	"sync"
+
+	"github.com/robfig/cron/v3"
+)
+
+type scheduler struct {
+	cron    *cron.Cron
+	mu      sync.Mutex
+	entries map[string]cron.EntryID
+}
+
+func NewScheduler() *scheduler {
+	return &scheduler{
+		cron:    cron.New(),
+		entries: make(map[string]cron.EntryID),
+	}
+}
@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+)
+
+// Schedule registers job under name. A job already registered under the same
+// name is replaced, which is how a job is moved to a new spec at runtime.
+func (s *scheduler) Schedule(name string, spec string, job func()) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, err := s.cron.AddFunc(spec, job)
+	if err != nil {
+		return err
+	}
+
+	if old, ok := s.entries[name]; ok {
+		s.cron.Remove(old)
+	}
+	s.entries[name] = id
+	return nil
+}
+
+func (s *scheduler) NextRuns() map[string]time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	runs := make(map[string]time.Time, len(s.entries))
+	for name, id := range s.entries {
+		entry := s.cron.Entry(id)
+		next := entry.Next
+		if next.IsZero() && entry.Schedule != nil {
+			next = entry.Schedule.Next(now)
+		}
+		runs[name] = next
+	}
+	return runs
+}
+
+func (s *scheduler) Start() {
+	s.cron.Start()
+}
+
+// Stop prevents new runs and returns a context that is done once the jobs
+// that were already running have finished.
+func (s *scheduler) Stop() context.Context {
+	return s.cron.Stop()
+}
@@ -0,0 +1,117 @@
+package repo
+
+import (
+	"time"
+
+	"github.com/pragmataW/apartment_management/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CreateAssessment stores the assessment and its installment schedule in one
+// transaction, charging the installments that are already due.
+func (r repo) CreateAssessment(assessment *models.Assessment, installments []models.AssessmentInstallment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(assessment).Error; err != nil {
+			return err
+		}
+
+		for i := range installments {
+			installments[i].AssessmentID = assessment.ID
+		}
+		if len(installments) > 0 {
+			if err := tx.Create(&installments).Error; err != nil {
+				return err
+			}
+		}
+
+		_, err := chargeDueInstallments(tx, time.Now())
+		return err
+	})
+}
+
+// ChargeDueInstallments charges every installment that has fallen due by at
+// and returns how many it charged.
+func (r repo) ChargeDueInstallments(at time.Time) (int, error) {
+	charged := 0
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		charged, err = chargeDueInstallments(tx, at)
+		return err
+	})
+	return charged, err
+}
+
+// chargeDueInstallments writes the ledger charge of each uncharged installment
+// due by at, oldest first. The installments are locked so that a concurrent
+// run skips them instead of charging them twice.
+func chargeDueInstallments(tx *gorm.DB, at time.Time) (int, error) {
+	var installments []models.AssessmentInstallment
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("entry_id IS NULL AND due_date <= ?", at).
+		Order("due_date, id").
+		Find(&installments)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	for _, installment := range installments {
+		if _, err := lockFlat(tx, "flat_no = ?", installment.FlatNo); err != nil {
+			return 0, err
+		}
+
+		assessmentID := installment.AssessmentID
+		charge := models.LedgerEntry{
+			FlatNo:       installment.FlatNo,
+			Period:       installment.Period,
+			Type:         models.LedgerAssessment,
+			Amount:       installment.Amount,
+			Source:       models.SourceScheduler,
+			Note:         installment.Note,
+			AssessmentID: &assessmentID,
+			DueDate:      installment.DueDate,
+		}
+		if err := insertLedgerEntry(tx, &charge); err != nil {
+			return 0, err
+		}
+
+		result := tx.Model(&models.AssessmentInstallment{}).Where("id = ?", installment.ID).Update("entry_id", charge.ID)
+		if result.Error != nil {
+			return 0, result.Error
+		}
+	}
+	return len(installments), nil
+}
+
+// GetAssessments lists the assessments newest first with how much of each has
+// been billed and collected so far. Only payments count as collected; a
+// waiver or adjustment settles a charge without bringing any money in.
+func (r repo) GetAssessments() ([]models.AssessmentStatus, error) {
+	var assessments []models.AssessmentStatus
+	result := r.db.Table("assessments AS s").
+		Select(`s.*,
+			COALESCE((SELECT SUM(e.amount) FROM ledger_entries e WHERE e.assessment_id = s.id), 0) AS billed,
+			COALESCE((SELECT SUM(a.amount) FROM ledger_entries e JOIN ledger_allocations a ON a.charge_id = e.id
+				JOIN ledger_entries p ON p.id = a.credit_id WHERE e.assessment_id = s.id AND p.type = ?), 0) AS collected`, models.LedgerPayment).
+		Order("s.id DESC").
+		Scan(&assessments)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return assessments, nil
+}
+
+func (r repo) GetAssessmentCharges(assessmentID int) ([]models.OpenCharge, error) {
+	var charges []models.OpenCharge
+	result := r.db.Table("ledger_entries AS e").
+		Select("e.*, e.amount - COALESCE(SUM(a.amount), 0) AS remaining").
+		Joins("LEFT JOIN ledger_allocations a ON a.charge_id = e.id").
+		Where("e.assessment_id = ?", assessmentID).
+		Group("e.id").
+		Order("e.flat_no, e.due_date, e.id").
+		Scan(&charges)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return charges, nil
+}
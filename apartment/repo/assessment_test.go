@@ -0,0 +1,135 @@
+package repo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAssessment(t *testing.T) {
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{}, models.Assessment{}, models.AssessmentInstallment{}, models.PaymentReceipt{})
+	repo := NewRepo(db)
+
+	result := db.Create(&[]models.Apartment{{FlatNo: 1, Mail: "a@mail.com"}, {FlatNo: 2, Mail: "b@mail.com"}})
+	assert.NoError(t, result.Error)
+
+	dueDate := time.Date(2026, 9, 1, 0, 0, 0, 0, time.Local)
+	nextDueDate := time.Now().AddDate(0, 1, 0)
+	assessment := models.Assessment{Name: "roof", Total: money.New(200000), SplitRule: models.SplitEqual, Installments: 2, FirstDueDate: dueDate, CreatedBy: "admin"}
+	installments := []models.AssessmentInstallment{
+		{FlatNo: 1, Period: "2026-09", Amount: money.New(50000), Note: "roof (1/2)", DueDate: dueDate},
+		{FlatNo: 1, Period: nextDueDate.Format("2006-01"), Amount: money.New(50000), Note: "roof (2/2)", DueDate: nextDueDate},
+		{FlatNo: 2, Period: "2026-09", Amount: money.New(50000), Note: "roof (1/2)", DueDate: dueDate},
+		{FlatNo: 2, Period: nextDueDate.Format("2006-01"), Amount: money.New(50000), Note: "roof (2/2)", DueDate: nextDueDate},
+	}
+	err := repo.CreateAssessment(&assessment, installments)
+	assert.NoError(t, err)
+	assert.NotZero(t, assessment.ID)
+
+	payment := models.PaymentReceipt{FlatNo: 1, Amount: money.New(80000), Method: models.MethodCash, PaidAt: time.Now(), CollectedBy: "manager", CreatedBy: "admin"}
+	err = repo.AddManualPayment(&payment)
+	assert.NoError(t, err)
+
+	assessments, err := repo.GetAssessments()
+	assert.NoError(t, err)
+	assert.Len(t, assessments, 1)
+	assert.Equal(t, "roof", assessments[0].Name)
+	assert.Equal(t, money.New(100000), assessments[0].Billed)
+	assert.Equal(t, money.New(50000), assessments[0].Collected)
+
+	assessmentCharges, err := repo.GetAssessmentCharges(assessment.ID)
+	assert.NoError(t, err)
+	assert.Len(t, assessmentCharges, 2)
+	assert.Equal(t, money.New(0), assessmentCharges[0].Remaining)
+	assert.Equal(t, money.New(50000), assessmentCharges[1].Remaining)
+
+	summary, err := repo.GetDuesSummary(1)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(0), summary.Assessments)
+	assert.Equal(t, money.New(30000), summary.Credit)
+
+	summary, err = repo.GetDuesSummary(2)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(50000), summary.Assessments)
+	assert.Equal(t, money.New(50000), summary.Balance)
+	assert.Equal(t, 0, summary.OpenDues)
+}
+
+func TestGetAssessmentsCollectedOnlyPayments(t *testing.T) {
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{}, models.Assessment{}, models.AssessmentInstallment{}, models.PaymentReceipt{})
+	repo := NewRepo(db)
+
+	result := db.Create(&[]models.Apartment{{FlatNo: 1, Mail: "a@mail.com"}, {FlatNo: 2, Mail: "b@mail.com"}})
+	assert.NoError(t, result.Error)
+
+	dueDate := time.Date(2026, 9, 1, 0, 0, 0, 0, time.Local)
+	assessment := models.Assessment{Name: "roof", Total: money.New(100000), SplitRule: models.SplitEqual, Installments: 1, FirstDueDate: dueDate, CreatedBy: "admin"}
+	installments := []models.AssessmentInstallment{
+		{FlatNo: 1, Period: "2026-09", Amount: money.New(50000), Note: "roof", DueDate: dueDate},
+		{FlatNo: 2, Period: "2026-09", Amount: money.New(50000), Note: "roof", DueDate: dueDate},
+	}
+	err := repo.CreateAssessment(&assessment, installments)
+	assert.NoError(t, err)
+
+	payment := models.PaymentReceipt{FlatNo: 1, Amount: money.New(30000), Method: models.MethodCash, PaidAt: time.Now(), CollectedBy: "manager", CreatedBy: "admin"}
+	err = repo.AddManualPayment(&payment)
+	assert.NoError(t, err)
+	err = repo.AddLedgerEntry(models.LedgerEntry{FlatNo: 2, Period: "2026-09", Type: models.LedgerWaiver, Amount: money.New(-50000), Source: models.SourceAdmin})
+	assert.NoError(t, err)
+
+	assessments, err := repo.GetAssessments()
+	assert.NoError(t, err)
+	assert.Len(t, assessments, 1)
+	assert.Equal(t, money.New(100000), assessments[0].Billed)
+	assert.Equal(t, money.New(30000), assessments[0].Collected)
+
+	summary, err := repo.GetDuesSummary(2)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(0), summary.Assessments)
+}
+
+func TestChargeDueInstallments(t *testing.T) {
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{}, models.Assessment{}, models.AssessmentInstallment{}, models.PaymentReceipt{})
+	repo := NewRepo(db)
+
+	result := db.Create(&models.Apartment{FlatNo: 1, Mail: "a@mail.com"})
+	assert.NoError(t, result.Error)
+
+	payment := models.PaymentReceipt{FlatNo: 1, Amount: money.New(30000), Method: models.MethodCash, PaidAt: time.Now(), CollectedBy: "manager", CreatedBy: "admin"}
+	err := repo.AddManualPayment(&payment)
+	assert.NoError(t, err)
+
+	dueDate := time.Now().AddDate(0, 1, 0)
+	assessment := models.Assessment{Name: "lift", Total: money.New(50000), SplitRule: models.SplitEqual, Installments: 1, FirstDueDate: dueDate, CreatedBy: "admin"}
+	installments := []models.AssessmentInstallment{
+		{FlatNo: 1, Period: dueDate.Format("2006-01"), Amount: money.New(50000), Note: "lift", DueDate: dueDate},
+	}
+	err = repo.CreateAssessment(&assessment, installments)
+	assert.NoError(t, err)
+
+	summary, err := repo.GetDuesSummary(1)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(-30000), summary.Balance)
+	assert.Equal(t, money.New(30000), summary.Credit)
+
+	charged, err := repo.ChargeDueInstallments(dueDate.AddDate(0, 0, -1))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, charged)
+
+	charged, err = repo.ChargeDueInstallments(dueDate)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, charged)
+
+	charged, err = repo.ChargeDueInstallments(dueDate)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, charged)
+
+	summary, err = repo.GetDuesSummary(1)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(20000), summary.Balance)
+	assert.Equal(t, money.New(20000), summary.Assessments)
+	assert.Equal(t, money.New(0), summary.Credit)
+}
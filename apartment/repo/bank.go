@@ -0,0 +1,211 @@
+package repo
+
+import (
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ImportBankTransactions records the import and its transactions in one go.
+// A transaction that comes with a flat is booked as that flat's payment; one
+// whose flat does not exist or that is not in TRY is left unmatched. Lines
+// already taken in by an earlier import are counted as duplicates and
+// skipped.
+func (r repo) ImportBankTransactions(bankImport *models.BankImport, transactions []models.BankTransaction) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(bankImport).Error; err != nil {
+			return err
+		}
+
+		for _, transaction := range transactions {
+			transaction.ImportID = bankImport.ID
+			transaction.Status = models.BankUnmatched
+			if transaction.FlatNo != nil && !foreignCurrency(transaction) {
+				_, err := lockFlat(tx, "flat_no = ?", *transaction.FlatNo)
+				switch err.(type) {
+				case nil:
+					transaction.Status = models.BankMatched
+				case dto.ThereIsNoFlat:
+					transaction.FlatNo = nil
+					transaction.RuleID = nil
+				default:
+					return err
+				}
+			}
+
+			result := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "bank_ref"}},
+				DoNothing: true,
+			}).Create(&transaction)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				bankImport.Duplicates++
+				continue
+			}
+
+			if transaction.Status == models.BankUnmatched {
+				bankImport.Unmatched++
+				continue
+			}
+			if err := bookBankTransaction(tx, &transaction); err != nil {
+				return err
+			}
+			result = tx.Model(&transaction).Update("entry_id", transaction.EntryID)
+			if result.Error != nil {
+				return result.Error
+			}
+			bankImport.Matched++
+		}
+
+		return tx.Model(bankImport).
+			Select("matched", "unmatched", "duplicates").
+			Updates(bankImport).Error
+	})
+}
+
+func (r repo) GetBankImports() ([]models.BankImport, error) {
+	var imports []models.BankImport
+	result := r.db.Order("id DESC").Find(&imports)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return imports, nil
+}
+
+// GetBankTransactions lists the transactions in the given status, or all of
+// them when status is empty, oldest first.
+func (r repo) GetBankTransactions(status string) ([]models.BankTransaction, error) {
+	query := r.db.Order("date, id")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var transactions []models.BankTransaction
+	result := query.Find(&transactions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return transactions, nil
+}
+
+// MatchBankTransaction assigns an unmatched transaction to the flat and books
+// it as the flat's payment.
+func (r repo) MatchBankTransaction(id int, flatNo int, resolvedBy string) (models.BankTransaction, error) {
+	var transaction models.BankTransaction
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockUnmatched(tx, id, &transaction); err != nil {
+			return err
+		}
+		if _, err := lockFlat(tx, "flat_no = ?", flatNo); err != nil {
+			return err
+		}
+
+		if foreignCurrency(transaction) {
+			return dto.ForeignCurrencyTransfer{Message: "bank transaction is in " + transaction.Currency + ", only " + money.TRY + " transfers can be booked"}
+		}
+
+		transaction.FlatNo = &flatNo
+		if err := bookBankTransaction(tx, &transaction); err != nil {
+			return err
+		}
+		return resolveBankTransaction(tx, &transaction, models.BankMatched, resolvedBy)
+	})
+	if err != nil {
+		return models.BankTransaction{}, err
+	}
+	return transaction, nil
+}
+
+// IgnoreBankTransaction takes an unmatched transaction off the review queue
+// without booking it, for transfers that are not dues payments.
+func (r repo) IgnoreBankTransaction(id int, resolvedBy string) (models.BankTransaction, error) {
+	var transaction models.BankTransaction
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockUnmatched(tx, id, &transaction); err != nil {
+			return err
+		}
+		return resolveBankTransaction(tx, &transaction, models.BankIgnored, resolvedBy)
+	})
+	if err != nil {
+		return models.BankTransaction{}, err
+	}
+	return transaction, nil
+}
+
+func (r repo) CreateReferenceRule(rule *models.ReferenceRule) error {
+	return r.db.Create(rule).Error
+}
+
+func (r repo) GetReferenceRules() ([]models.ReferenceRule, error) {
+	var rules []models.ReferenceRule
+	result := r.db.Order("priority, id").Find(&rules)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return rules, nil
+}
+
+func (r repo) DeleteReferenceRule(id int) error {
+	result := r.db.Delete(&models.ReferenceRule{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return dto.ThereIsNoReferenceRule{Message: "there is no reference rule"}
+	}
+	return nil
+}
+
+// bookBankTransaction books the transaction as a payment of its flat, which
+// must already be locked.
+func bookBankTransaction(tx *gorm.DB, transaction *models.BankTransaction) error {
+	payment := models.LedgerEntry{
+		FlatNo:    *transaction.FlatNo,
+		Amount:    transaction.Amount.Neg(),
+		Source:    models.SourceBank,
+		Reference: transaction.BankRef,
+		Note:      "bank transfer: " + transaction.Description,
+		CreatedAt: transaction.Date,
+	}
+	if err := bookPayment(tx, &payment); err != nil {
+		return err
+	}
+	transaction.EntryID = &payment.ID
+	return nil
+}
+
+func lockUnmatched(tx *gorm.DB, id int, transaction *models.BankTransaction) error {
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(transaction, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return dto.ThereIsNoBankTransaction{Message: "there is no bank transaction"}
+		}
+		return result.Error
+	}
+	if transaction.Status != models.BankUnmatched {
+		return dto.BankTransactionResolved{Message: "bank transaction is already " + transaction.Status}
+	}
+	return nil
+}
+
+// foreignCurrency reports whether the transfer is in a currency other than
+// the lira the ledger is kept in. Such a transfer is never booked.
+func foreignCurrency(transaction models.BankTransaction) bool {
+	return transaction.Currency != "" && transaction.Currency != money.TRY
+}
+
+func resolveBankTransaction(tx *gorm.DB, transaction *models.BankTransaction, status string, resolvedBy string) error {
+	now := time.Now()
+	transaction.Status = status
+	transaction.ResolvedBy = resolvedBy
+	transaction.ResolvedAt = &now
+	return tx.Model(transaction).
+		Select("status", "flat_no", "entry_id", "resolved_by", "resolved_at").
+		Updates(transaction).Error
+}
@@ -0,0 +1,92 @@
+package repo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportBankTransactions(t *testing.T) {
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{}, models.BankImport{}, models.BankTransaction{})
+	repo := NewRepo(db)
+
+	result := db.Create(&models.Apartment{FlatNo: 1, Mail: "ornek@email.com"})
+	assert.NoError(t, result.Error)
+	assert.NoError(t, repo.AddDues(1, "2026-10", money.New(4000)))
+
+	date := time.Date(2026, 10, 3, 0, 0, 0, 0, time.Local)
+	one, nine := 1, 9
+	transactions := []models.BankTransaction{
+		{BankRef: "A1", Date: date, Amount: money.New(4000), Description: "daire 1", FlatNo: &one},
+		{BankRef: "A2", Date: date, Amount: money.New(6000), Description: "daire 9", FlatNo: &nine},
+		{BankRef: "A3", Date: date, Amount: money.New(2500), Description: "aidat"},
+		{BankRef: "A4", Date: date, Amount: money.New(4000), Currency: "EUR", Description: "daire 1", FlatNo: &one},
+	}
+
+	bankImport := models.BankImport{FileName: "ekstre.csv", Format: "csv", Lines: 4, ImportedBy: "admin"}
+	assert.NoError(t, repo.ImportBankTransactions(&bankImport, transactions))
+	assert.Equal(t, 1, bankImport.Matched)
+	assert.Equal(t, 3, bankImport.Unmatched)
+
+	duesCount, err := repo.GetDuesCount(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, duesCount)
+
+	again := models.BankImport{FileName: "ekstre.csv", Format: "csv", Lines: 4, ImportedBy: "admin"}
+	assert.NoError(t, repo.ImportBankTransactions(&again, transactions))
+	assert.Equal(t, 4, again.Duplicates)
+	assert.Equal(t, 0, again.Matched)
+
+	queue, err := repo.GetBankTransactions(models.BankUnmatched)
+	assert.NoError(t, err)
+	assert.Len(t, queue, 3)
+	assert.Nil(t, queue[0].FlatNo)
+	assert.Equal(t, money.TRY, queue[0].Currency)
+	assert.Equal(t, "EUR", queue[2].Currency)
+
+	_, err = repo.MatchBankTransaction(queue[2].ID, 1, "admin")
+	assert.IsType(t, dto.ForeignCurrencyTransfer{}, err)
+
+	assert.NoError(t, repo.AddDues(1, "2026-11", money.New(4000)))
+	matched, err := repo.MatchBankTransaction(queue[0].ID, 1, "admin")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BankMatched, matched.Status)
+	assert.NotNil(t, matched.EntryID)
+
+	summary, err := repo.GetDuesSummary(1)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(-2000), summary.Balance)
+
+	_, err = repo.MatchBankTransaction(queue[0].ID, 1, "admin")
+	assert.IsType(t, dto.BankTransactionResolved{}, err)
+
+	ignored, err := repo.IgnoreBankTransaction(queue[1].ID, "admin")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BankIgnored, ignored.Status)
+
+	_, err = repo.IgnoreBankTransaction(99, "admin")
+	assert.IsType(t, dto.ThereIsNoBankTransaction{}, err)
+}
+
+func TestReferenceRules(t *testing.T) {
+	db := setupDb(models.ReferenceRule{})
+	repo := NewRepo(db)
+
+	flatNo := 4
+	assert.NoError(t, repo.CreateReferenceRule(&models.ReferenceRule{Name: "sender", Pattern: "AYSE YILMAZ", FlatNo: &flatNo, Priority: 2, CreatedBy: "admin"}))
+	first := models.ReferenceRule{Name: "daire", Pattern: `daire (\d+)`, Priority: 1, CreatedBy: "admin"}
+	assert.NoError(t, repo.CreateReferenceRule(&first))
+
+	rules, err := repo.GetReferenceRules()
+	assert.NoError(t, err)
+	assert.Len(t, rules, 2)
+	assert.Equal(t, "daire", rules[0].Name)
+
+	assert.NoError(t, repo.DeleteReferenceRule(first.ID))
+	err = repo.DeleteReferenceRule(first.ID)
+	assert.IsType(t, dto.ThereIsNoReferenceRule{}, err)
+}
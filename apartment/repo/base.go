@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"log"
 	"sync"
+	"time"
 
+	"github.com/pragmataW/apartment_management/dto"
 	"github.com/pragmataW/apartment_management/models"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -45,7 +47,59 @@ func NewDb(conf DBConfig) (*gorm.DB) {
 		if err != nil{
 			log.Fatal(err)
 		}
-		err = db.AutoMigrate(&models.Merchant{})
+		err = db.AutoMigrate(&models.PaymentTransaction{}, &models.PaymentRefund{})
+		if err != nil{
+			log.Fatal(err)
+		}
+		err = migrateMerchants(db)
+		if err != nil{
+			log.Fatal(err)
+		}
+		err = db.AutoMigrate(&models.LedgerEntry{}, &models.LedgerAllocation{})
+		if err != nil{
+			log.Fatal(err)
+		}
+		err = db.AutoMigrate(&models.Setting{})
+		if err != nil{
+			log.Fatal(err)
+		}
+		err = migrateDuesCount(db)
+		if err != nil{
+			log.Fatal(err)
+		}
+		err = db.AutoMigrate(&models.AccrualRun{})
+		if err != nil{
+			log.Fatal(err)
+		}
+		err = seedAccrualRuns(db)
+		if err != nil{
+			log.Fatal(err)
+		}
+		err = db.AutoMigrate(&models.Assessment{}, &models.AssessmentInstallment{})
+		if err != nil{
+			log.Fatal(err)
+		}
+		err = db.AutoMigrate(&models.ExpenseCategory{}, &models.Vendor{}, &models.Expense{})
+		if err != nil{
+			log.Fatal(err)
+		}
+		err = db.AutoMigrate(&models.PaymentReceipt{})
+		if err != nil{
+			log.Fatal(err)
+		}
+		err = db.AutoMigrate(&models.BankImport{}, &models.BankTransaction{}, &models.ReferenceRule{})
+		if err != nil{
+			log.Fatal(err)
+		}
+		err = db.AutoMigrate(&models.Meter{}, &models.MeterReading{}, &models.UtilityBill{}, &models.UtilityBillLine{})
+		if err != nil{
+			log.Fatal(err)
+		}
+		err = db.AutoMigrate(&models.DuesReminder{})
+		if err != nil{
+			log.Fatal(err)
+		}
+		err = db.AutoMigrate(&models.ReceiptMail{})
 		if err != nil{
 			log.Fatal(err)
 		}
@@ -58,3 +112,77 @@ func NewRepo(db *gorm.DB) repo {
 		db: db,
 	}
 }
+
+// migrateDuesCount moves the legacy dues_count counter into the ledger as one
+// accrual per owed month, counting back from the current month, and then
+// drops the column.
+func migrateDuesCount(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&models.Apartment{}, "dues_count") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var flats []struct {
+			FlatNo    int
+			DuesCount int
+		}
+		result := tx.Table("apartments").Select("flat_no, dues_count").Where("dues_count > 0").Scan(&flats)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		now := time.Now()
+		for _, flat := range flats {
+			for i := flat.DuesCount; i > 0; i-- {
+				month := time.Date(now.Year(), now.Month()-time.Month(i-1), 1, 0, 0, 0, 0, now.Location())
+				entry := models.LedgerEntry{
+					FlatNo:  flat.FlatNo,
+					Period:  month.Format("2006-01"),
+					Type:    models.LedgerAccrual,
+					Amount:  dto.DefaultDuesPrice,
+					Source:  models.SourceMigration,
+					DueDate: month,
+				}
+				if err := tx.Create(&entry).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		return tx.Migrator().DropColumn(&models.Apartment{}, "dues_count")
+	})
+}
+
+// migrateMerchants moves the payments started before transactions were
+// tracked from the legacy merchants table into payment_transactions as
+// pending, so a callback still on its way finds them, and then drops the
+// table. A row whose email no longer belongs to a flat could never be booked
+// and is left out.
+func migrateMerchants(db *gorm.DB) error {
+	if !db.Migrator().HasTable("merchants") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		stmt := `INSERT INTO payment_transactions (merchant_oid, provider, flat_no, email, amount, status, created_at)
+			SELECT m.merchant_id, ?, ap.flat_no, m.email, m.amount, ?, ?
+			FROM merchants m JOIN apartments ap ON ap.mail = m.email
+			ON CONFLICT DO NOTHING`
+		if err := tx.Exec(stmt, models.SourcePaytr, models.PaymentPending, time.Now()).Error; err != nil {
+			return err
+		}
+		return tx.Migrator().DropTable("merchants")
+	})
+}
+
+// seedAccrualRuns records the periods that were charged by the scheduler, or
+// carried over from dues_count, before accrual runs were tracked, so catch-up
+// starts after them and does not charge them again.
+func seedAccrualRuns(db *gorm.DB) error {
+	stmt := `INSERT INTO accrual_runs (period, amount, flat_count, due_date, run_at)
+		SELECT period, MAX(amount), COUNT(*), MIN(due_date), MIN(created_at)
+		FROM ledger_entries WHERE type = ? AND source IN (?, ?)
+		GROUP BY period
+		ON CONFLICT DO NOTHING`
+	return db.Exec(stmt, models.LedgerAccrual, models.SourceScheduler, models.SourceMigration).Error
+}
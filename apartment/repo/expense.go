@@ -0,0 +1,239 @@
+package repo
+
+import (
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r repo) CreateExpenseCategory(category *models.ExpenseCategory) error {
+	var count int64
+	result := r.db.Model(&models.ExpenseCategory{}).Where("name = ?", category.Name).Count(&count)
+	if result.Error != nil {
+		return result.Error
+	}
+	if count > 0 {
+		return dto.ExpenseError{Message: "expense category already exists"}
+	}
+
+	return r.db.Create(category).Error
+}
+
+func (r repo) GetExpenseCategories() ([]models.ExpenseCategory, error) {
+	var categories []models.ExpenseCategory
+	result := r.db.Order("name").Find(&categories)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return categories, nil
+}
+
+// DeleteExpenseCategory removes a category that no expense is filed under.
+func (r repo) DeleteExpenseCategory(id int) error {
+	var count int64
+	result := r.db.Model(&models.Expense{}).Where("category_id = ?", id).Count(&count)
+	if result.Error != nil {
+		return result.Error
+	}
+	if count > 0 {
+		return dto.ExpenseError{Message: "expense category is in use"}
+	}
+
+	result = r.db.Delete(&models.ExpenseCategory{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return dto.ExpenseError{Message: "there is no expense category"}
+	}
+	return nil
+}
+
+func (r repo) CreateVendor(vendor *models.Vendor) error {
+	var count int64
+	result := r.db.Model(&models.Vendor{}).Where("name = ?", vendor.Name).Count(&count)
+	if result.Error != nil {
+		return result.Error
+	}
+	if count > 0 {
+		return dto.ExpenseError{Message: "vendor already exists"}
+	}
+
+	return r.db.Create(vendor).Error
+}
+
+func (r repo) GetVendors() ([]models.Vendor, error) {
+	var vendors []models.Vendor
+	result := r.db.Order("name").Find(&vendors)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return vendors, nil
+}
+
+func (r repo) UpdateVendor(vendor models.Vendor) error {
+	result := r.db.Model(&models.Vendor{}).
+		Where("id = ?", vendor.ID).
+		Select("name", "tax_no", "phone", "email").
+		Updates(&vendor)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return dto.ExpenseError{Message: "there is no vendor"}
+	}
+	return nil
+}
+
+// DeleteVendor removes a vendor that no expense was paid to.
+func (r repo) DeleteVendor(id int) error {
+	var count int64
+	result := r.db.Model(&models.Expense{}).Where("vendor_id = ?", id).Count(&count)
+	if result.Error != nil {
+		return result.Error
+	}
+	if count > 0 {
+		return dto.ExpenseError{Message: "vendor is in use"}
+	}
+
+	result = r.db.Delete(&models.Vendor{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return dto.ExpenseError{Message: "there is no vendor"}
+	}
+	return nil
+}
+
+func (r repo) CreateExpense(expense *models.Expense) error {
+	if err := checkExpenseRefs(r.db, *expense); err != nil {
+		return err
+	}
+	return r.db.Create(expense).Error
+}
+
+func (r repo) UpdateExpense(expense models.Expense) error {
+	if err := checkExpenseRefs(r.db, expense); err != nil {
+		return err
+	}
+
+	result := r.db.Model(&models.Expense{}).
+		Where("id = ?", expense.ID).
+		Select("category_id", "vendor_id", "date", "amount", "description").
+		Updates(&expense)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return dto.ThereIsNoExpense{Message: "there is no expense"}
+	}
+	return nil
+}
+
+// DeleteExpense removes the expense and returns it, so that the caller can
+// clean up its receipt.
+func (r repo) DeleteExpense(id int) (models.Expense, error) {
+	var expense models.Expense
+	result := r.db.Take(&expense, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return models.Expense{}, dto.ThereIsNoExpense{Message: "there is no expense"}
+		}
+		return models.Expense{}, result.Error
+	}
+
+	if err := r.db.Delete(&models.Expense{}, id).Error; err != nil {
+		return models.Expense{}, err
+	}
+	return expense, nil
+}
+
+func (r repo) GetExpense(id int) (models.ExpenseRecord, error) {
+	var expenses []models.ExpenseRecord
+	result := expenseQuery(r.db).Where("x.id = ?", id).Scan(&expenses)
+	if result.Error != nil {
+		return models.ExpenseRecord{}, result.Error
+	}
+	if len(expenses) == 0 {
+		return models.ExpenseRecord{}, dto.ThereIsNoExpense{Message: "there is no expense"}
+	}
+	return expenses[0], nil
+}
+
+// GetExpenses lists the expenses matching the filter, newest first. From and
+// To are inclusive dates.
+func (r repo) GetExpenses(filter models.ExpenseFilter) ([]models.ExpenseRecord, error) {
+	query := expenseQuery(r.db)
+	if !filter.From.IsZero() {
+		query = query.Where("x.date >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("x.date <= ?", filter.To)
+	}
+	if filter.CategoryID != 0 {
+		query = query.Where("x.category_id = ?", filter.CategoryID)
+	}
+	if filter.VendorID != 0 {
+		query = query.Where("x.vendor_id = ?", filter.VendorID)
+	}
+
+	var expenses []models.ExpenseRecord
+	result := query.Order("x.date DESC, x.id DESC").Scan(&expenses)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return expenses, nil
+}
+
+// SetExpenseReceipt points the expense at a newly stored receipt and returns
+// the receipt it pointed at before.
+func (r repo) SetExpenseReceipt(id int, receiptFile string) (string, error) {
+	var previous string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var expense models.Expense
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(&expense, id)
+		if result.Error != nil {
+			if result.Error == gorm.ErrRecordNotFound {
+				return dto.ThereIsNoExpense{Message: "there is no expense"}
+			}
+			return result.Error
+		}
+		previous = expense.ReceiptFile
+
+		return tx.Model(&models.Expense{}).Where("id = ?", id).Update("receipt_file", receiptFile).Error
+	})
+	if err != nil {
+		return "", err
+	}
+	return previous, nil
+}
+
+func expenseQuery(db *gorm.DB) *gorm.DB {
+	return db.Table("expenses AS x").
+		Select("x.*, c.name AS category_name, COALESCE(v.name, '') AS vendor_name").
+		Joins("JOIN expense_categories c ON c.id = x.category_id").
+		Joins("LEFT JOIN vendors v ON v.id = x.vendor_id")
+}
+
+func checkExpenseRefs(db *gorm.DB, expense models.Expense) error {
+	var count int64
+	if err := db.Model(&models.ExpenseCategory{}).Where("id = ?", expense.CategoryID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return dto.ExpenseError{Message: "there is no expense category"}
+	}
+
+	if expense.VendorID == nil {
+		return nil
+	}
+	if err := db.Model(&models.Vendor{}).Where("id = ?", *expense.VendorID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return dto.ExpenseError{Message: "there is no vendor"}
+	}
+	return nil
+}
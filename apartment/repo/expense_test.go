@@ -0,0 +1,81 @@
+package repo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpenses(t *testing.T) {
+	db := setupDb(models.ExpenseCategory{}, models.Vendor{}, models.Expense{})
+	repo := NewRepo(db)
+
+	cleaning := models.ExpenseCategory{Name: "cleaning"}
+	assert.NoError(t, repo.CreateExpenseCategory(&cleaning))
+	err := repo.CreateExpenseCategory(&models.ExpenseCategory{Name: "cleaning"})
+	assert.IsType(t, dto.ExpenseError{}, err)
+
+	electricity := models.ExpenseCategory{Name: "electricity"}
+	assert.NoError(t, repo.CreateExpenseCategory(&electricity))
+
+	vendor := models.Vendor{Name: "Parlak Temizlik"}
+	assert.NoError(t, repo.CreateVendor(&vendor))
+
+	october := models.Expense{CategoryID: cleaning.ID, VendorID: &vendor.ID, Date: time.Date(2026, 10, 5, 0, 0, 0, 0, time.Local), Amount: money.New(150000), CreatedBy: "admin"}
+	assert.NoError(t, repo.CreateExpense(&october))
+	september := models.Expense{CategoryID: electricity.ID, Date: time.Date(2026, 9, 20, 0, 0, 0, 0, time.Local), Amount: money.New(82050), CreatedBy: "admin"}
+	assert.NoError(t, repo.CreateExpense(&september))
+
+	err = repo.CreateExpense(&models.Expense{CategoryID: 999, Date: time.Now(), Amount: money.New(100), CreatedBy: "admin"})
+	assert.IsType(t, dto.ExpenseError{}, err)
+
+	expenses, err := repo.GetExpenses(models.ExpenseFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, expenses, 2)
+	assert.Equal(t, october.ID, expenses[0].ID)
+	assert.Equal(t, "cleaning", expenses[0].CategoryName)
+	assert.Equal(t, "Parlak Temizlik", expenses[0].VendorName)
+	assert.Equal(t, "", expenses[1].VendorName)
+
+	expenses, err = repo.GetExpenses(models.ExpenseFilter{To: time.Date(2026, 9, 30, 0, 0, 0, 0, time.Local)})
+	assert.NoError(t, err)
+	assert.Len(t, expenses, 1)
+	assert.Equal(t, september.ID, expenses[0].ID)
+
+	expenses, err = repo.GetExpenses(models.ExpenseFilter{VendorID: vendor.ID})
+	assert.NoError(t, err)
+	assert.Len(t, expenses, 1)
+
+	previous, err := repo.SetExpenseReceipt(october.ID, "first.pdf")
+	assert.NoError(t, err)
+	assert.Equal(t, "", previous)
+	previous, err = repo.SetExpenseReceipt(october.ID, "second.pdf")
+	assert.NoError(t, err)
+	assert.Equal(t, "first.pdf", previous)
+
+	err = repo.DeleteExpenseCategory(cleaning.ID)
+	assert.IsType(t, dto.ExpenseError{}, err)
+	err = repo.DeleteVendor(vendor.ID)
+	assert.IsType(t, dto.ExpenseError{}, err)
+
+	october.Amount = money.New(175000)
+	assert.NoError(t, repo.UpdateExpense(october))
+	record, err := repo.GetExpense(october.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(175000), record.Amount)
+	assert.Equal(t, "second.pdf", record.ReceiptFile)
+
+	deleted, err := repo.DeleteExpense(october.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "second.pdf", deleted.ReceiptFile)
+
+	_, err = repo.GetExpense(october.ID)
+	assert.IsType(t, dto.ThereIsNoExpense{}, err)
+
+	assert.NoError(t, repo.DeleteVendor(vendor.ID))
+	assert.NoError(t, repo.DeleteExpenseCategory(cleaning.ID))
+}
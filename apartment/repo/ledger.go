@@ -0,0 +1,273 @@
+package repo
+
+import (
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const duesSummaryQuery = `
+WITH open_charges AS (
+	SELECT e.flat_no, e.type, e.amount - COALESCE(SUM(a.amount), 0) AS remaining FROM ledger_entries e
+	LEFT JOIN ledger_allocations a ON a.charge_id = e.id
+	WHERE e.amount > 0
+	GROUP BY e.id
+	HAVING e.amount - COALESCE(SUM(a.amount), 0) > 0
+), open_credits AS (
+	SELECT e.flat_no, -e.amount - COALESCE(SUM(a.amount), 0) AS remaining FROM ledger_entries e
+	LEFT JOIN ledger_allocations a ON a.credit_id = e.id
+	WHERE e.amount < 0
+	GROUP BY e.id
+	HAVING -e.amount - COALESCE(SUM(a.amount), 0) > 0
+)
+SELECT ap.flat_no,
+	COALESCE((SELECT SUM(l.amount) FROM ledger_entries l WHERE l.flat_no = ap.flat_no), 0) AS balance,
+	(SELECT COUNT(*) FROM open_charges o WHERE o.flat_no = ap.flat_no AND o.type = ?) AS open_dues,
+	COALESCE((SELECT SUM(o.remaining) FROM open_charges o WHERE o.flat_no = ap.flat_no AND o.type = ?), 0) AS open_penalties,
+	COALESCE((SELECT SUM(c.remaining) FROM open_credits c WHERE c.flat_no = ap.flat_no), 0) AS credit,
+	COALESCE((SELECT SUM(o.remaining) FROM open_charges o WHERE o.flat_no = ap.flat_no AND o.type = ?), 0) AS open_assessments
+FROM apartments ap`
+
+func (r repo) AddLedgerEntry(entry models.LedgerEntry) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := lockFlat(tx, "flat_no = ?", entry.FlatNo); err != nil {
+			return err
+		}
+		return insertLedgerEntry(tx, &entry)
+	})
+}
+
+func (r repo) GetLedgerEntries(flatNo int) ([]models.LedgerEntry, error) {
+	var entries []models.LedgerEntry
+	result := r.db.Where("flat_no = ?", flatNo).Order("created_at, id").Find(&entries)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return entries, nil
+}
+
+// GetPayments lists the flat's payments that still stand, newest first. A
+// payment that was cancelled or refunded is left out; what was kept of a
+// partly refunded one is booked again and listed instead.
+func (r repo) GetPayments(flatNo int) ([]models.LedgerEntry, error) {
+	var payments []models.LedgerEntry
+	result := r.db.Where("flat_no = ? AND type = ?", flatNo, models.LedgerPayment).
+		Where(`NOT EXISTS (SELECT 1 FROM ledger_allocations a JOIN ledger_entries r ON r.id = a.charge_id
+			WHERE a.credit_id = ledger_entries.id AND r.type = ?)`, models.LedgerReversal).
+		Order("created_at DESC, id DESC").
+		Find(&payments)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return payments, nil
+}
+
+// GetOpenCharges lists the flat's charges that are not fully settled yet,
+// oldest due first.
+func (r repo) GetOpenCharges(flatNo int) ([]models.OpenCharge, error) {
+	return openCharges(r.db, flatNo)
+}
+
+func (r repo) GetDuesSummary(flatNo int) (models.DuesSummary, error) {
+	return duesSummary(r.db, flatNo)
+}
+
+func duesSummary(db *gorm.DB, flatNo int) (models.DuesSummary, error) {
+	var summary models.DuesSummary
+	result := db.Raw(duesSummaryQuery+" WHERE ap.flat_no = ?", models.LedgerAccrual, models.LedgerPenalty, models.LedgerAssessment, flatNo).Scan(&summary)
+	if result.Error != nil {
+		return models.DuesSummary{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.DuesSummary{}, dto.ThereIsNoFlat{Message: "there is no flat"}
+	}
+	return summary, nil
+}
+
+func (r repo) GetDuesSummaries() ([]models.DuesSummary, error) {
+	var summaries []models.DuesSummary
+	result := r.db.Raw(duesSummaryQuery+" ORDER BY ap.flat_no", models.LedgerAccrual, models.LedgerPenalty, models.LedgerAssessment).Scan(&summaries)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return summaries, nil
+}
+
+// lockFlat takes a row lock on the flat so that concurrent ledger writes for
+// the same flat are serialised until the surrounding transaction ends.
+func lockFlat(tx *gorm.DB, query string, args ...interface{}) (models.Apartment, error) {
+	var flat models.Apartment
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(query, args...).Take(&flat)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return models.Apartment{}, dto.ThereIsNoFlat{Message: "there is no flat"}
+		}
+		return models.Apartment{}, result.Error
+	}
+	return flat, nil
+}
+
+// insertLedgerEntry writes the entry and settles it against the other side
+// of the flat's account: a credit is allocated to the open charges oldest
+// first, and a charge is paid from whatever credit the flat still holds.
+func insertLedgerEntry(tx *gorm.DB, entry *models.LedgerEntry) error {
+	if err := createLedgerEntry(tx, entry); err != nil {
+		return err
+	}
+
+	if entry.Amount.IsNegative() {
+		charges, err := openCharges(tx, entry.FlatNo)
+		if err != nil {
+			return err
+		}
+		return allocateCredit(tx, *entry, charges)
+	}
+	if entry.Amount.IsPositive() {
+		return applyCredits(tx, *entry)
+	}
+	return nil
+}
+
+func createLedgerEntry(tx *gorm.DB, entry *models.LedgerEntry) error {
+	if entry.DueDate.IsZero() {
+		entry.DueDate = time.Now()
+	}
+	return tx.Create(entry).Error
+}
+
+// allocateCredit clears the given open charges in order with the credit
+// entry. Whatever cannot be allocated stays on the credit.
+func allocateCredit(tx *gorm.DB, credit models.LedgerEntry, charges []models.OpenCharge) error {
+	left := credit.Amount.Neg()
+	for _, charge := range charges {
+		if !left.IsPositive() {
+			break
+		}
+
+		amount := money.Min(left, charge.Remaining)
+		allocation := models.LedgerAllocation{
+			CreditID: credit.ID,
+			ChargeID: charge.ID,
+			Amount:   amount,
+		}
+		if err := tx.Create(&allocation).Error; err != nil {
+			return err
+		}
+		left = left.Sub(amount)
+	}
+	return nil
+}
+
+// applyCredits pays the charge from the flat's unused credits, oldest first.
+func applyCredits(tx *gorm.DB, charge models.LedgerEntry) error {
+	credits, err := openCredits(tx, charge.FlatNo)
+	if err != nil {
+		return err
+	}
+
+	left := charge.Amount
+	for _, credit := range credits {
+		if !left.IsPositive() {
+			break
+		}
+
+		amount := money.Min(left, credit.Remaining)
+		allocation := models.LedgerAllocation{
+			CreditID: credit.ID,
+			ChargeID: charge.ID,
+			Amount:   amount,
+		}
+		if err := tx.Create(&allocation).Error; err != nil {
+			return err
+		}
+		left = left.Sub(amount)
+	}
+	return nil
+}
+
+// reversePayment cancels a payment of a flat that is already locked. The
+// charges the payment cleared are opened again through negative allocations,
+// and the reversal is booked as a charge that the payment itself clears, so
+// that both sides stay on the ledger and the balance goes back up.
+func reversePayment(tx *gorm.DB, payment models.LedgerEntry, reversal *models.LedgerEntry) error {
+	var allocations []models.LedgerAllocation
+	result := tx.Where("credit_id = ?", payment.ID).Order("id").Find(&allocations)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	var chargeIDs []int
+	cleared := map[int]money.Money{}
+	for _, allocation := range allocations {
+		if _, ok := cleared[allocation.ChargeID]; !ok {
+			chargeIDs = append(chargeIDs, allocation.ChargeID)
+		}
+		cleared[allocation.ChargeID] = cleared[allocation.ChargeID].Add(allocation.Amount)
+	}
+
+	reversal.FlatNo = payment.FlatNo
+	reversal.Period = payment.Period
+	reversal.Type = models.LedgerReversal
+	reversal.Amount = payment.Amount.Neg()
+	if err := createLedgerEntry(tx, reversal); err != nil {
+		return err
+	}
+
+	for _, chargeID := range chargeIDs {
+		amount := cleared[chargeID]
+		if !amount.IsPositive() {
+			continue
+		}
+		allocation := models.LedgerAllocation{
+			CreditID: payment.ID,
+			ChargeID: chargeID,
+			Amount:   amount.Neg(),
+		}
+		if err := tx.Create(&allocation).Error; err != nil {
+			return err
+		}
+	}
+
+	allocation := models.LedgerAllocation{
+		CreditID: payment.ID,
+		ChargeID: reversal.ID,
+		Amount:   reversal.Amount,
+	}
+	return tx.Create(&allocation).Error
+}
+
+func openCredits(tx *gorm.DB, flatNo int) ([]models.OpenCredit, error) {
+	var credits []models.OpenCredit
+	result := tx.Table("ledger_entries AS e").
+		Select("e.*, -e.amount - COALESCE(SUM(a.amount), 0) AS remaining").
+		Joins("LEFT JOIN ledger_allocations a ON a.credit_id = e.id").
+		Where("e.flat_no = ? AND e.amount < 0", flatNo).
+		Group("e.id").
+		Having("-e.amount - COALESCE(SUM(a.amount), 0) > 0").
+		Order("e.created_at, e.id").
+		Scan(&credits)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return credits, nil
+}
+
+func openCharges(tx *gorm.DB, flatNo int) ([]models.OpenCharge, error) {
+	var charges []models.OpenCharge
+	result := tx.Table("ledger_entries AS e").
+		Select("e.*, e.amount - COALESCE(SUM(a.amount), 0) AS remaining").
+		Joins("LEFT JOIN ledger_allocations a ON a.charge_id = e.id").
+		Where("e.flat_no = ? AND e.amount > 0", flatNo).
+		Group("e.id").
+		Having("e.amount - COALESCE(SUM(a.amount), 0) > 0").
+		Order("e.due_date, e.id").
+		Scan(&charges)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return charges, nil
+}
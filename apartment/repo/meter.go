@@ -0,0 +1,226 @@
+package repo
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const readingDateLayout = "2006-01-02"
+
+func (r repo) CreateMeter(meter *models.Meter) error {
+	var count int64
+	result := r.db.Model(&models.Apartment{}).Where("flat_no = ?", meter.FlatNo).Count(&count)
+	if result.Error != nil {
+		return result.Error
+	}
+	if count == 0 {
+		return dto.ThereIsNoFlat{Message: "there is no flat"}
+	}
+
+	result = r.db.Model(&models.Meter{}).Where("serial = ?", meter.Serial).Count(&count)
+	if result.Error != nil {
+		return result.Error
+	}
+	if count > 0 {
+		return dto.MeterError{Message: "there is already a meter with serial " + meter.Serial}
+	}
+
+	return r.db.Create(meter).Error
+}
+
+// GetMeters lists the meters of the flat, or of every flat when flatNo is 0.
+func (r repo) GetMeters(flatNo int) ([]models.Meter, error) {
+	var meters []models.Meter
+	query := r.db.Order("flat_no, type, id")
+	if flatNo != 0 {
+		query = query.Where("flat_no = ?", flatNo)
+	}
+	result := query.Find(&meters)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return meters, nil
+}
+
+func (r repo) GetMeter(id int) (models.Meter, error) {
+	var meter models.Meter
+	result := r.db.Take(&meter, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return models.Meter{}, dto.ThereIsNoMeter{Message: "there is no meter"}
+		}
+		return models.Meter{}, result.Error
+	}
+	return meter, nil
+}
+
+// DeleteMeter removes a meter that has no readings yet. A meter that has been
+// read is kept so that past bills can still be explained.
+func (r repo) DeleteMeter(id int) error {
+	var count int64
+	result := r.db.Model(&models.MeterReading{}).Where("meter_id = ?", id).Count(&count)
+	if result.Error != nil {
+		return result.Error
+	}
+	if count > 0 {
+		return dto.MeterError{Message: "meter has readings"}
+	}
+
+	result = r.db.Delete(&models.Meter{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return dto.ThereIsNoMeter{Message: "there is no meter"}
+	}
+	return nil
+}
+
+// AddMeterReadings stores the readings in one transaction, so an import
+// either goes in whole or not at all. Each reading has to be at least the
+// meter's reading before it and at most the one after it; the meter row is
+// locked while this is checked.
+func (r repo) AddMeterReadings(readings []models.MeterReading) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i := range readings {
+			reading := &readings[i]
+
+			var meter models.Meter
+			result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(&meter, reading.MeterID)
+			if result.Error != nil {
+				if result.Error == gorm.ErrRecordNotFound {
+					return dto.ThereIsNoMeter{Message: "there is no meter " + strconv.Itoa(reading.MeterID)}
+				}
+				return result.Error
+			}
+
+			var previous []models.MeterReading
+			result = tx.Where("meter_id = ? AND read_at <= ?", meter.ID, reading.ReadAt).Order("read_at DESC").Limit(1).Find(&previous)
+			if result.Error != nil {
+				return result.Error
+			}
+			if len(previous) > 0 {
+				if sameDay(previous[0].ReadAt, reading.ReadAt) {
+					return dto.MeterError{Message: fmt.Sprintf("meter %s was already read on %s", meter.Serial, reading.ReadAt.Format(readingDateLayout))}
+				}
+				if reading.Value < previous[0].Value {
+					return dto.MeterError{Message: fmt.Sprintf("meter %s read %v on %s, lower than the %v read on %s",
+						meter.Serial, reading.Value, reading.ReadAt.Format(readingDateLayout), previous[0].Value, previous[0].ReadAt.Format(readingDateLayout))}
+				}
+			}
+
+			var next []models.MeterReading
+			result = tx.Where("meter_id = ? AND read_at > ?", meter.ID, reading.ReadAt).Order("read_at").Limit(1).Find(&next)
+			if result.Error != nil {
+				return result.Error
+			}
+			if len(next) > 0 && reading.Value > next[0].Value {
+				return dto.MeterError{Message: fmt.Sprintf("meter %s read %v on %s, higher than the %v read later on %s",
+					meter.Serial, reading.Value, reading.ReadAt.Format(readingDateLayout), next[0].Value, next[0].ReadAt.Format(readingDateLayout))}
+			}
+
+			if err := tx.Create(reading).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r repo) GetMeterReadings(meterID int) ([]models.MeterReading, error) {
+	var readings []models.MeterReading
+	result := r.db.Where("meter_id = ?", meterID).Order("read_at").Find(&readings)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return readings, nil
+}
+
+// GetLatestReadings returns, for every meter of the type, its last reading on
+// or before the given day. Meters with no such reading are left out.
+func (r repo) GetLatestReadings(meterType string, on time.Time) ([]models.MeterReading, error) {
+	var readings []models.MeterReading
+	result := r.db.Raw(`SELECT DISTINCT ON (r.meter_id) r.* FROM meter_readings r
+		JOIN meters m ON m.id = r.meter_id
+		WHERE m.type = ? AND r.read_at <= ?
+		ORDER BY r.meter_id, r.read_at DESC`, meterType, on).Scan(&readings)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return readings, nil
+}
+
+// CreateUtilityBill stores the bill, its per-flat lines and the charges in
+// one transaction. There can be one bill of a type per period.
+func (r repo) CreateUtilityBill(bill *models.UtilityBill, lines []models.UtilityBillLine, charges []models.LedgerEntry) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		result := tx.Model(&models.UtilityBill{}).Where("type = ? AND period = ?", bill.Type, bill.Period).Count(&count)
+		if result.Error != nil {
+			return result.Error
+		}
+		if count > 0 {
+			return dto.MeterError{Message: "there is already a " + bill.Type + " bill for " + bill.Period}
+		}
+
+		if err := tx.Create(bill).Error; err != nil {
+			return err
+		}
+
+		for _, line := range lines {
+			line.BillID = bill.ID
+			if err := tx.Create(&line).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, charge := range charges {
+			if _, err := lockFlat(tx, "flat_no = ?", charge.FlatNo); err != nil {
+				return err
+			}
+
+			charge.UtilityBillID = &bill.ID
+			if err := insertLedgerEntry(tx, &charge); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r repo) GetUtilityBills() ([]models.UtilityBill, error) {
+	var bills []models.UtilityBill
+	result := r.db.Order("id DESC").Find(&bills)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return bills, nil
+}
+
+func (r repo) GetUtilityBill(id int) (models.UtilityBill, []models.UtilityBillLine, error) {
+	var bill models.UtilityBill
+	result := r.db.Take(&bill, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return models.UtilityBill{}, nil, dto.ThereIsNoUtilityBill{Message: "there is no utility bill"}
+		}
+		return models.UtilityBill{}, nil, result.Error
+	}
+
+	var lines []models.UtilityBillLine
+	result = r.db.Where("bill_id = ?", id).Order("flat_no").Find(&lines)
+	if result.Error != nil {
+		return models.UtilityBill{}, nil, result.Error
+	}
+	return bill, lines, nil
+}
+
+func sameDay(a time.Time, b time.Time) bool {
+	return a.Format(readingDateLayout) == b.Format(readingDateLayout)
+}
@@ -0,0 +1,67 @@
+package repo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddMeterReadingsOnlyGoUp(t *testing.T) {
+	db := setupDb(models.Apartment{}, models.Meter{}, models.MeterReading{})
+	repo := NewRepo(db)
+
+	result := db.Create(&models.Apartment{FlatNo: 1, Mail: "a@mail.com"})
+	assert.NoError(t, result.Error)
+
+	meter := models.Meter{FlatNo: 1, Type: models.MeterWater, Serial: "W-1", Unit: "m3"}
+	assert.NoError(t, repo.CreateMeter(&meter))
+	assert.IsType(t, dto.MeterError{}, repo.CreateMeter(&models.Meter{FlatNo: 1, Type: models.MeterWater, Serial: "W-1"}))
+
+	day := time.Date(2026, 9, 1, 0, 0, 0, 0, time.Local)
+	err := repo.AddMeterReadings([]models.MeterReading{
+		{MeterID: meter.ID, ReadAt: day, Value: 100, Source: models.SourceAdmin},
+		{MeterID: meter.ID, ReadAt: day.AddDate(0, 0, 29), Value: 130, Source: models.SourceAdmin},
+	})
+	assert.NoError(t, err)
+
+	err = repo.AddMeterReadings([]models.MeterReading{{MeterID: meter.ID, ReadAt: day.AddDate(0, 0, 10), Value: 140}})
+	assert.IsType(t, dto.MeterError{}, err)
+	err = repo.AddMeterReadings([]models.MeterReading{{MeterID: meter.ID, ReadAt: day.AddDate(0, 1, 0), Value: 90}})
+	assert.IsType(t, dto.MeterError{}, err)
+
+	latest, err := repo.GetLatestReadings(models.MeterWater, day.AddDate(0, 0, 15))
+	assert.NoError(t, err)
+	assert.Len(t, latest, 1)
+	assert.Equal(t, float64(100), latest[0].Value)
+
+	assert.IsType(t, dto.MeterError{}, repo.DeleteMeter(meter.ID))
+}
+
+func TestCreateUtilityBill(t *testing.T) {
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{}, models.UtilityBill{}, models.UtilityBillLine{})
+	repo := NewRepo(db)
+
+	result := db.Create(&models.Apartment{FlatNo: 1, Mail: "a@mail.com"})
+	assert.NoError(t, result.Error)
+
+	bill := models.UtilityBill{Type: models.MeterWater, Period: "2026-09", Total: money.New(50000), CommonRule: models.SplitEqual, DueDate: time.Now()}
+	lines := []models.UtilityBillLine{{FlatNo: 1, Consumption: 12, Amount: money.New(50000)}}
+	charges := []models.LedgerEntry{{FlatNo: 1, Period: "2026-09", Type: models.LedgerUtility, Amount: money.New(50000), DueDate: time.Now()}}
+	assert.NoError(t, repo.CreateUtilityBill(&bill, lines, charges))
+	assert.IsType(t, dto.MeterError{}, repo.CreateUtilityBill(&models.UtilityBill{Type: models.MeterWater, Period: "2026-09"}, nil, nil))
+
+	saved, savedLines, err := repo.GetUtilityBill(bill.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(50000), saved.Total)
+	assert.Len(t, savedLines, 1)
+
+	var entry models.LedgerEntry
+	result = db.Where("utility_bill_id = ?", bill.ID).Take(&entry)
+	assert.NoError(t, result.Error)
+	assert.Equal(t, money.New(50000), entry.Amount)
+	assert.Equal(t, models.LedgerUtility, entry.Type)
+}
@@ -0,0 +1,236 @@
+package repo
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r repo) CreatePaymentTransaction(transaction *models.PaymentTransaction) error {
+	return r.db.Create(transaction).Error
+}
+
+func (r repo) GetPaymentTransaction(merchantOID string) (models.PaymentTransaction, error) {
+	var transaction models.PaymentTransaction
+	result := r.db.Where("merchant_oid = ?", merchantOID).Take(&transaction)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return models.PaymentTransaction{}, dto.ThereIsNoPaymentTransaction{Message: "there is no payment transaction"}
+		}
+		return models.PaymentTransaction{}, result.Error
+	}
+	return transaction, nil
+}
+
+func (r repo) GetPaymentTransactions(filter models.PaymentTransactionFilter) ([]models.PaymentTransaction, error) {
+	query := r.db.Order("created_at DESC")
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.FlatNo != 0 {
+		query = query.Where("flat_no = ?", filter.FlatNo)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To.AddDate(0, 0, 1))
+	}
+
+	var transactions []models.PaymentTransaction
+	result := query.Find(&transactions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return transactions, nil
+}
+
+// MovePaymentTransaction moves the transaction to its Status, as long as it
+// is still in one of the from states. A transaction that has moved on in the
+// meantime, such as one whose callback came first, is left as it is and a
+// PaymentStateConflict is returned.
+func (r repo) MovePaymentTransaction(transaction models.PaymentTransaction, from []string) error {
+	result := r.db.Model(&transaction).
+		Where("status IN ?", from).
+		Select("status", "fail_reason", "receipt_no", "callback_at").
+		Updates(&transaction)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return dto.PaymentStateConflict{Message: "payment transaction is not " + strings.Join(from, " or ")}
+	}
+	return nil
+}
+
+// ExpirePaymentTransactions expires the transactions created before the given
+// time that have not heard back from their provider, and returns how many.
+func (r repo) ExpirePaymentTransactions(before time.Time) (int, error) {
+	result := r.db.Model(&models.PaymentTransaction{}).
+		Where("status IN ? AND created_at < ?", []string{models.PaymentCreated, models.PaymentPending}, before).
+		Update("status", models.PaymentExpired)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return int(result.RowsAffected), nil
+}
+
+// ReservePaymentRefund records the refund as pending on a succeeded payment
+// before the provider is asked to make it. Refunds still pending count as
+// given back, so two refunds at the same time cannot exceed the payment. A
+// zero amount reserves all that is left.
+func (r repo) ReservePaymentRefund(refund *models.PaymentRefund) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		transaction, err := lockPaymentTransaction(tx, refund.MerchantOID)
+		if err != nil {
+			return err
+		}
+		if transaction.Status != models.PaymentSucceeded {
+			return dto.PaymentStateConflict{Message: "payment transaction is not succeeded"}
+		}
+
+		var pending money.Money
+		result := tx.Model(&models.PaymentRefund{}).
+			Select("COALESCE(SUM(amount), 0)").
+			Where("merchant_oid = ? AND status = ?", refund.MerchantOID, models.RefundPending).
+			Scan(&pending)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		left := transaction.PaidAmount.Sub(transaction.RefundedAmount).Sub(pending)
+		if refund.Amount.IsZero() {
+			refund.Amount = left
+		}
+		if !refund.Amount.IsPositive() {
+			return dto.PaymentStateConflict{Message: "nothing is left of the payment to refund"}
+		}
+		if refund.Amount.Sub(left).IsPositive() {
+			return dto.PaymentStateConflict{Message: "refund is more than the " + left.String() + " TL left of the payment"}
+		}
+
+		refund.Status = models.RefundPending
+		return tx.Create(refund).Error
+	})
+}
+
+// FailPaymentRefund marks a pending refund the provider turned down as
+// failed, which frees its amount for another refund.
+func (r repo) FailPaymentRefund(id int, reason string) error {
+	result := r.db.Model(&models.PaymentRefund{}).
+		Where("id = ? AND status = ?", id, models.RefundPending).
+		Updates(map[string]interface{}{"status": models.RefundFailed, "error": reason})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return dto.PaymentStateConflict{Message: "payment refund is not pending"}
+	}
+	return nil
+}
+
+// CompletePaymentRefund books a pending refund the provider has made. The
+// payment entry of the transaction is reversed, which opens the charges it
+// settled again, and what is left of the payment after the refund is booked
+// anew against the oldest of them, with the receipt moved over to it. A
+// payment left with nothing is refunded.
+func (r repo) CompletePaymentRefund(id int) (models.PaymentTransaction, error) {
+	var transaction models.PaymentTransaction
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var refund models.PaymentRefund
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(&refund, id).Error; err != nil {
+			return err
+		}
+		if refund.Status != models.RefundPending {
+			return dto.PaymentStateConflict{Message: "payment refund is not pending"}
+		}
+
+		var err error
+		transaction, err = lockPaymentTransaction(tx, refund.MerchantOID)
+		if err != nil {
+			return err
+		}
+
+		left := transaction.PaidAmount.Sub(transaction.RefundedAmount).Sub(refund.Amount)
+		if left.IsNegative() {
+			return dto.PaymentStateConflict{Message: "refund is more than what is left of the payment"}
+		}
+
+		flat, err := lockFlat(tx, "flat_no = ?", transaction.FlatNo)
+		if err != nil {
+			return err
+		}
+
+		var payment models.LedgerEntry
+		if err := tx.Take(&payment, transaction.EntryID).Error; err != nil {
+			return err
+		}
+
+		reversal := models.LedgerEntry{
+			Source:    models.SourcePaytr,
+			Reference: transaction.MerchantOID,
+			Note:      "refund of PayTR order " + transaction.MerchantOID + ": " + refund.Reason,
+		}
+		if err := reversePayment(tx, payment, &reversal); err != nil {
+			return err
+		}
+		refund.ReversalEntryID = &reversal.ID
+
+		if left.IsPositive() {
+			rebook := models.LedgerEntry{
+				FlatNo:    flat.FlatNo,
+				Amount:    left.Neg(),
+				Source:    models.SourcePaytr,
+				Reference: transaction.MerchantOID,
+				Note:      "kept of PayTR order " + transaction.MerchantOID + " after a refund",
+			}
+			if err := bookPayment(tx, &rebook); err != nil {
+				return err
+			}
+			refund.RebookEntryID = &rebook.ID
+			transaction.EntryID = rebook.ID
+
+			result := tx.Model(&models.PaymentReceipt{}).
+				Where("receipt_no = ?", transaction.ReceiptNo).
+				Update("entry_id", rebook.ID)
+			if result.Error != nil {
+				return result.Error
+			}
+		} else {
+			transaction.Status = models.PaymentRefunded
+		}
+
+		refund.Status = models.RefundDone
+		err = tx.Model(&refund).
+			Select("status", "reversal_entry_id", "rebook_entry_id").
+			Updates(&refund).Error
+		if err != nil {
+			return err
+		}
+
+		transaction.RefundedAmount = transaction.RefundedAmount.Add(refund.Amount)
+		return tx.Model(&transaction).Updates(map[string]interface{}{
+			"status":          transaction.Status,
+			"entry_id":        transaction.EntryID,
+			"refunded_amount": transaction.RefundedAmount,
+		}).Error
+	})
+	if err != nil {
+		return models.PaymentTransaction{}, err
+	}
+	return transaction, nil
+}
+
+func (r repo) GetPaymentRefunds(merchantOID string) ([]models.PaymentRefund, error) {
+	var refunds []models.PaymentRefund
+	result := r.db.Where("merchant_oid = ?", merchantOID).Order("id").Find(&refunds)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return refunds, nil
+}
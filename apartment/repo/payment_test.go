@@ -0,0 +1,166 @@
+package repo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMovePaymentTransaction(t *testing.T) {
+	db := setupDb(models.PaymentTransaction{})
+	repo := NewRepo(db)
+
+	transaction := models.PaymentTransaction{MerchantOID: "oid", Provider: "fake", FlatNo: 1, Email: "a@mail.com", Amount: money.New(12000), Status: models.PaymentCreated}
+	assert.NoError(t, repo.CreatePaymentTransaction(&transaction))
+
+	now := time.Now()
+	transaction.Status = models.PaymentSucceeded
+	transaction.ReceiptNo = 4
+	transaction.CallbackAt = &now
+	err := repo.MovePaymentTransaction(transaction, []string{models.PaymentCreated, models.PaymentPending})
+	assert.NoError(t, err)
+
+	// The token answer comes after the callback and must not undo it.
+	transaction.Status = models.PaymentPending
+	err = repo.MovePaymentTransaction(transaction, []string{models.PaymentCreated})
+	assert.IsType(t, dto.PaymentStateConflict{}, err)
+
+	stored, err := repo.GetPaymentTransaction("oid")
+	assert.NoError(t, err)
+	assert.Equal(t, models.PaymentSucceeded, stored.Status)
+	assert.Equal(t, 4, stored.ReceiptNo)
+	assert.NotNil(t, stored.CallbackAt)
+}
+
+func TestExpirePaymentTransactions(t *testing.T) {
+	db := setupDb(models.PaymentTransaction{})
+	repo := NewRepo(db)
+
+	old := time.Now().Add(-2 * time.Hour)
+	db.Create(&models.PaymentTransaction{MerchantOID: "old", Provider: "fake", FlatNo: 1, Email: "a@mail.com", Status: models.PaymentPending, CreatedAt: old})
+	db.Create(&models.PaymentTransaction{MerchantOID: "paid", Provider: "fake", FlatNo: 1, Email: "a@mail.com", Status: models.PaymentSucceeded, CreatedAt: old})
+	db.Create(&models.PaymentTransaction{MerchantOID: "new", Provider: "fake", FlatNo: 2, Email: "b@mail.com", Status: models.PaymentPending})
+
+	expired, err := repo.ExpirePaymentTransactions(time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, expired)
+
+	transactions, err := repo.GetPaymentTransactions(models.PaymentTransactionFilter{Status: models.PaymentExpired})
+	assert.NoError(t, err)
+	assert.Len(t, transactions, 1)
+	assert.Equal(t, "old", transactions[0].MerchantOID)
+
+	transactions, err = repo.GetPaymentTransactions(models.PaymentTransactionFilter{FlatNo: 2})
+	assert.NoError(t, err)
+	assert.Len(t, transactions, 1)
+}
+
+func TestMigrateMerchants(t *testing.T) {
+	db := setupDb(models.Apartment{}, models.PaymentTransaction{})
+	repo := NewRepo(db)
+
+	db.Create(&models.Apartment{FlatNo: 3, Mail: "a@mail.com"})
+	assert.NoError(t, db.Exec("CREATE TABLE merchants (merchant_id TEXT PRIMARY KEY, email TEXT NOT NULL, amount NUMERIC(12,2) NOT NULL DEFAULT 0)").Error)
+	assert.NoError(t, db.Exec("INSERT INTO merchants VALUES ('oid', 'a@mail.com', 120.00), ('gone', 'gone@mail.com', 40.00)").Error)
+
+	err := migrateMerchants(db)
+	assert.NoError(t, err)
+	assert.False(t, db.Migrator().HasTable("merchants"))
+
+	transaction, err := repo.GetPaymentTransaction("oid")
+	assert.NoError(t, err)
+	assert.Equal(t, models.PaymentPending, transaction.Status)
+	assert.Equal(t, models.SourcePaytr, transaction.Provider)
+	assert.Equal(t, 3, transaction.FlatNo)
+	assert.Equal(t, money.New(12000), transaction.Amount)
+
+	_, err = repo.GetPaymentTransaction("gone")
+	assert.IsType(t, dto.ThereIsNoPaymentTransaction{}, err)
+
+	err = migrateMerchants(db)
+	assert.NoError(t, err)
+}
+
+func TestRefundOnlinePayment(t *testing.T) {
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{}, models.PaymentReceipt{}, models.ReceiptMail{}, models.PaymentTransaction{}, models.PaymentRefund{})
+	repo := NewRepo(db)
+
+	db.Create(&models.Apartment{FlatNo: 1, Mail: "ornek@email.com"})
+	db.Create(&models.PaymentTransaction{MerchantOID: "oid", Provider: "paytr", FlatNo: 1, Email: "ornek@email.com", Amount: money.New(8000), Status: models.PaymentPending})
+	assert.NoError(t, repo.AddDues(1, "2026-09", money.New(4000)))
+	assert.NoError(t, repo.AddDues(1, "2026-10", money.New(4000)))
+
+	_, err := repo.SettleOnlinePayment("oid", money.New(8000), time.Now())
+	assert.NoError(t, err)
+
+	first := models.PaymentRefund{MerchantOID: "oid", Amount: money.New(4000), Reason: "paid twice", RefundedBy: "admin"}
+	assert.NoError(t, repo.ReservePaymentRefund(&first))
+	assert.Equal(t, models.RefundPending, first.Status)
+
+	// A pending refund holds its amount until the provider answers.
+	err = repo.ReservePaymentRefund(&models.PaymentRefund{MerchantOID: "oid", Amount: money.New(5000), Reason: "too much", RefundedBy: "admin"})
+	assert.IsType(t, dto.PaymentStateConflict{}, err)
+
+	transaction, err := repo.CompletePaymentRefund(first.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.PaymentSucceeded, transaction.Status)
+	assert.Equal(t, money.New(4000), transaction.RefundedAmount)
+
+	_, err = repo.CompletePaymentRefund(first.ID)
+	assert.IsType(t, dto.PaymentStateConflict{}, err)
+
+	// Only what was kept is listed, and the receipt covers what it still pays.
+	payments, err := repo.GetPayments(1)
+	assert.NoError(t, err)
+	assert.Len(t, payments, 1)
+	assert.Equal(t, money.New(-4000), payments[0].Amount)
+	receipt, err := repo.GetPaymentReceipt(transaction.ReceiptNo)
+	assert.NoError(t, err)
+	assert.Equal(t, payments[0].ID, receipt.EntryID)
+	periods, err := repo.GetPaymentPeriods(receipt.EntryID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2026-09"}, periods)
+
+	// The refund opens the newest month again.
+	charges, err := repo.GetOpenCharges(1)
+	assert.NoError(t, err)
+	assert.Len(t, charges, 1)
+	assert.Equal(t, "2026-10", charges[0].Period)
+
+	failed := models.PaymentRefund{MerchantOID: "oid", Reason: "paid twice", RefundedBy: "admin"}
+	assert.NoError(t, repo.ReservePaymentRefund(&failed))
+	assert.Equal(t, money.New(4000), failed.Amount)
+	assert.NoError(t, repo.FailPaymentRefund(failed.ID, "provider is down"))
+
+	last := models.PaymentRefund{MerchantOID: "oid", Reason: "paid twice", RefundedBy: "admin"}
+	assert.NoError(t, repo.ReservePaymentRefund(&last))
+	assert.Equal(t, money.New(4000), last.Amount)
+	transaction, err = repo.CompletePaymentRefund(last.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.PaymentRefunded, transaction.Status)
+
+	summary, err := repo.GetDuesSummary(1)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(8000), summary.Balance)
+
+	payments, err = repo.GetPayments(1)
+	assert.NoError(t, err)
+	assert.Empty(t, payments)
+	periods, err = repo.GetPaymentPeriods(receipt.EntryID)
+	assert.NoError(t, err)
+	assert.Empty(t, periods)
+
+	refunds, err := repo.GetPaymentRefunds("oid")
+	assert.NoError(t, err)
+	assert.Len(t, refunds, 3)
+	assert.Equal(t, models.RefundDone, refunds[0].Status)
+	assert.NotNil(t, refunds[0].RebookEntryID)
+	assert.Equal(t, models.RefundFailed, refunds[1].Status)
+	assert.Equal(t, "provider is down", refunds[1].Error)
+	assert.Equal(t, models.RefundDone, refunds[2].Status)
+	assert.Nil(t, refunds[2].RebookEntryID)
+}
@@ -0,0 +1,60 @@
+package repo
+
+import (
+	"time"
+
+	"github.com/pragmataW/apartment_management/models"
+	"gorm.io/gorm"
+)
+
+// GetOverdueCharges returns the open dues charges of every flat that fell due
+// before the given time.
+func (r repo) GetOverdueCharges(before time.Time) ([]models.OpenCharge, error) {
+	var charges []models.OpenCharge
+	result := r.db.Table("ledger_entries AS e").
+		Select("e.*, e.amount - COALESCE(SUM(a.amount), 0) AS remaining").
+		Joins("LEFT JOIN ledger_allocations a ON a.charge_id = e.id").
+		Where("e.type = ? AND e.amount > 0 AND e.due_date < ?", models.LedgerAccrual, before).
+		Group("e.id").
+		Having("e.amount - COALESCE(SUM(a.amount), 0) > 0").
+		Order("e.flat_no, e.due_date, e.id").
+		Scan(&charges)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return charges, nil
+}
+
+// AddPenalties writes the given penalty entries and skips those whose charge
+// already has a penalty for the same period. It returns how many were added.
+func (r repo) AddPenalties(penalties []models.LedgerEntry) (int, error) {
+	added := 0
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, penalty := range penalties {
+			if _, err := lockFlat(tx, "flat_no = ?", penalty.FlatNo); err != nil {
+				return err
+			}
+
+			var count int64
+			result := tx.Model(&models.LedgerEntry{}).
+				Where("charge_id = ? AND period = ?", penalty.ChargeID, penalty.Period).
+				Count(&count)
+			if result.Error != nil {
+				return result.Error
+			}
+			if count > 0 {
+				continue
+			}
+
+			if err := insertLedgerEntry(tx, &penalty); err != nil {
+				return err
+			}
+			added++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return added, nil
+}
@@ -0,0 +1,101 @@
+package repo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOverdueCharges(t *testing.T) {
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{})
+	repo := NewRepo(db)
+
+	result := db.Create(&models.Apartment{FlatNo: 1, Mail: "yciftci@gmail.com"})
+	assert.NoError(t, result.Error)
+
+	september := time.Date(2026, 9, 15, 0, 0, 0, 0, time.Local)
+	october := time.Date(2026, 10, 15, 0, 0, 0, 0, time.Local)
+	err := repo.AddLedgerEntry(models.LedgerEntry{FlatNo: 1, Period: "2026-09", Type: models.LedgerAccrual, Amount: money.New(4000), Source: models.SourceScheduler, DueDate: september})
+	assert.NoError(t, err)
+	err = repo.AddLedgerEntry(models.LedgerEntry{FlatNo: 1, Period: "2026-10", Type: models.LedgerAccrual, Amount: money.New(4000), Source: models.SourceScheduler, DueDate: october})
+	assert.NoError(t, err)
+	err = repo.AddLedgerEntry(models.LedgerEntry{FlatNo: 1, Period: "2026-09", Type: models.LedgerPayment, Amount: money.New(-1500), Source: models.SourceAdmin})
+	assert.NoError(t, err)
+
+	charges, err := repo.GetOverdueCharges(time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local))
+	assert.NoError(t, err)
+	assert.Len(t, charges, 1)
+	assert.Equal(t, "2026-09", charges[0].Period)
+	assert.Equal(t, money.New(2500), charges[0].Remaining)
+}
+
+func TestAddPenalties(t *testing.T) {
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{})
+	repo := NewRepo(db)
+
+	result := db.Create(&models.Apartment{FlatNo: 1, Mail: "yciftci@gmail.com"})
+	assert.NoError(t, result.Error)
+
+	charge := models.LedgerEntry{FlatNo: 1, Period: "2026-09", Type: models.LedgerAccrual, Amount: money.New(4000), Source: models.SourceScheduler, DueDate: time.Date(2026, 9, 15, 0, 0, 0, 0, time.Local)}
+	result = db.Create(&charge)
+	assert.NoError(t, result.Error)
+
+	penalties := []models.LedgerEntry{
+		{FlatNo: 1, Period: "2026-09", Type: models.LedgerPenalty, Amount: money.New(200), Source: models.SourceScheduler, ChargeID: &charge.ID},
+		{FlatNo: 1, Period: "2026-10", Type: models.LedgerPenalty, Amount: money.New(200), Source: models.SourceScheduler, ChargeID: &charge.ID},
+	}
+	added, err := repo.AddPenalties(penalties)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, added)
+
+	added, err = repo.AddPenalties(penalties)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, added)
+
+	summary, err := repo.GetDuesSummary(1)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(4400), summary.Balance)
+	assert.Equal(t, 1, summary.OpenDues)
+	assert.Equal(t, money.New(400), summary.OpenPenalties)
+}
+
+func TestSettleOldestChargeWithPenalties(t *testing.T) {
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{}, models.PaymentReceipt{})
+	repo := NewRepo(db)
+
+	result := db.Create(&models.Apartment{FlatNo: 1, Mail: "yciftci@gmail.com"})
+	assert.NoError(t, result.Error)
+
+	september := models.LedgerEntry{FlatNo: 1, Period: "2026-09", Type: models.LedgerAccrual, Amount: money.New(4000), Source: models.SourceScheduler, DueDate: time.Date(2026, 9, 15, 0, 0, 0, 0, time.Local)}
+	result = db.Create(&september)
+	assert.NoError(t, result.Error)
+	october := models.LedgerEntry{FlatNo: 1, Period: "2026-10", Type: models.LedgerAccrual, Amount: money.New(4000), Source: models.SourceScheduler, DueDate: time.Date(2026, 10, 15, 0, 0, 0, 0, time.Local)}
+	result = db.Create(&october)
+	assert.NoError(t, result.Error)
+
+	_, err := repo.AddPenalties([]models.LedgerEntry{
+		{FlatNo: 1, Period: "2026-09", Type: models.LedgerPenalty, Amount: money.New(200), Source: models.SourceScheduler, ChargeID: &september.ID, DueDate: time.Date(2026, 9, 25, 0, 0, 0, 0, time.Local)},
+		{FlatNo: 1, Period: "2026-10", Type: models.LedgerPenalty, Amount: money.New(200), Source: models.SourceScheduler, ChargeID: &september.ID, DueDate: time.Date(2026, 10, 25, 0, 0, 0, 0, time.Local)},
+	})
+	assert.NoError(t, err)
+
+	groups, err := repo.GetOpenChargeGroups(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ChargeGroup{
+		{Type: models.LedgerAccrual, Period: "2026-09", Amount: money.New(4400)},
+		{Type: models.LedgerAccrual, Period: "2026-10", Amount: money.New(4000)},
+	}, groups)
+
+	payment := models.PaymentReceipt{FlatNo: 1, Amount: money.New(4400), Method: models.MethodCash, PaidAt: time.Now(), CollectedBy: "manager", CreatedBy: "admin"}
+	err = repo.AddManualPayment(&payment)
+	assert.NoError(t, err)
+
+	summary, err := repo.GetDuesSummary(1)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(4000), summary.Balance)
+	assert.Equal(t, 1, summary.OpenDues)
+	assert.Equal(t, money.New(0), summary.OpenPenalties)
+}
@@ -0,0 +1,402 @@
+package repo
+
+import (
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AddManualPayment books a payment taken by hand and issues its receipt. The
+// payment settles the flat's open months oldest first, like an online one.
+func (r repo) AddManualPayment(receipt *models.PaymentReceipt) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := lockFlat(tx, "flat_no = ?", receipt.FlatNo); err != nil {
+			return err
+		}
+
+		receiptNo, err := nextReceiptNo(tx)
+		if err != nil {
+			return err
+		}
+		receipt.ReceiptNo = receiptNo
+
+		payment := models.LedgerEntry{
+			FlatNo:    receipt.FlatNo,
+			Amount:    receipt.Amount.Neg(),
+			Source:    models.SourceManual,
+			Reference: receipt.Reference(),
+			Note:      receipt.Method + " payment collected by " + receipt.CollectedBy,
+			CreatedAt: receipt.PaidAt,
+		}
+		if err := bookPayment(tx, &payment); err != nil {
+			return err
+		}
+
+		receipt.EntryID = payment.ID
+		return tx.Create(receipt).Error
+	})
+}
+
+// CancelManualPayment cancels the receipt by booking a reversal of its
+// payment. The receipt and the payment stay on record.
+func (r repo) CancelManualPayment(receiptNo int, cancelledBy string, reason string) (models.PaymentReceipt, error) {
+	var receipt models.PaymentReceipt
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("receipt_no = ?", receiptNo).Take(&receipt)
+		if result.Error != nil {
+			if result.Error == gorm.ErrRecordNotFound {
+				return dto.ThereIsNoReceipt{Message: "there is no receipt"}
+			}
+			return result.Error
+		}
+		if receipt.CancelledAt != nil {
+			return dto.ReceiptAlreadyCancelled{Message: "receipt is already cancelled"}
+		}
+		if receipt.Method == models.MethodOnline {
+			return dto.ManualPaymentError{Message: "an online payment is refunded, not cancelled"}
+		}
+
+		if _, err := lockFlat(tx, "flat_no = ?", receipt.FlatNo); err != nil {
+			return err
+		}
+
+		var payment models.LedgerEntry
+		if err := tx.Take(&payment, receipt.EntryID).Error; err != nil {
+			return err
+		}
+
+		reversal := models.LedgerEntry{
+			Source:    models.SourceManual,
+			Reference: payment.Reference,
+			Note:      "cancelled receipt " + payment.Reference + ": " + reason,
+		}
+		if err := reversePayment(tx, payment, &reversal); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		receipt.CancelledAt = &now
+		receipt.CancelledBy = cancelledBy
+		receipt.CancelReason = reason
+		receipt.CancelEntryID = &reversal.ID
+		return tx.Model(&receipt).
+			Select("cancelled_at", "cancelled_by", "cancel_reason", "cancel_entry_id").
+			Updates(&receipt).Error
+	})
+	if err != nil {
+		return models.PaymentReceipt{}, err
+	}
+	return receipt, nil
+}
+
+func (r repo) GetPaymentReceipt(receiptNo int) (models.PaymentReceipt, error) {
+	var receipt models.PaymentReceipt
+	result := r.db.Where("receipt_no = ?", receiptNo).Take(&receipt)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return models.PaymentReceipt{}, dto.ThereIsNoReceipt{Message: "there is no receipt"}
+		}
+		return models.PaymentReceipt{}, result.Error
+	}
+	return receipt, nil
+}
+
+// SettleOnlinePayment books a successful online payment for the flat that
+// started it, issues its receipt from the same sequence as manual payments and
+// marks the transaction succeeded, all in one database transaction. The
+// transaction row stays locked until then, so a payment is booked once no
+// matter how often or how concurrently its callback comes; later callbacks get
+// a PaymentAlreadySettled. A paid amount other than the one asked for books
+// nothing: the transaction is held for review and a PaymentAmountMismatch is
+// returned.
+func (r repo) SettleOnlinePayment(merchantOID string, paid money.Money, at time.Time) (models.PaymentReceipt, error) {
+	var receipt models.PaymentReceipt
+	mismatch := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		transaction, err := lockPaymentTransaction(tx, merchantOID)
+		if err != nil {
+			return err
+		}
+		switch transaction.Status {
+		case models.PaymentSucceeded, models.PaymentRefunded:
+			return dto.PaymentAlreadySettled{Message: "payment is already " + transaction.Status}
+		case models.PaymentReview:
+			return dto.PaymentAlreadySettled{Message: "payment is already held for review"}
+		}
+
+		if paid != transaction.Amount {
+			mismatch = true
+			return tx.Model(&transaction).Updates(map[string]interface{}{
+				"status":      models.PaymentReview,
+				"paid_amount": paid,
+				"fail_reason": "paid " + paid.String() + ", expected " + transaction.Amount.String(),
+				"callback_at": at,
+			}).Error
+		}
+
+		receipt, err = settlePaymentTransaction(tx, transaction, paid, at)
+		if err != nil {
+			return err
+		}
+		return tx.Model(&transaction).Update("callback_at", at).Error
+	})
+	if err != nil {
+		return models.PaymentReceipt{}, err
+	}
+	if mismatch {
+		return models.PaymentReceipt{}, dto.PaymentAmountMismatch{Message: "paid amount does not match the payment"}
+	}
+	return receipt, nil
+}
+
+// AcceptPaymentReview books a payment held for review at the amount that was
+// actually paid and marks it succeeded.
+func (r repo) AcceptPaymentReview(merchantOID string, reviewedBy string, at time.Time) (models.PaymentReceipt, error) {
+	var receipt models.PaymentReceipt
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		transaction, err := lockPaymentTransaction(tx, merchantOID)
+		if err != nil {
+			return err
+		}
+		if transaction.Status != models.PaymentReview {
+			return dto.PaymentStateConflict{Message: "payment transaction is not held for review"}
+		}
+
+		receipt, err = settlePaymentTransaction(tx, transaction, transaction.PaidAmount, at)
+		if err != nil {
+			return err
+		}
+		return tx.Model(&transaction).Update("reviewed_by", reviewedBy).Error
+	})
+	if err != nil {
+		return models.PaymentReceipt{}, err
+	}
+	return receipt, nil
+}
+
+func lockPaymentTransaction(tx *gorm.DB, merchantOID string) (models.PaymentTransaction, error) {
+	var transaction models.PaymentTransaction
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("merchant_oid = ?", merchantOID).Take(&transaction)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return models.PaymentTransaction{}, dto.ThereIsNoPaymentTransaction{Message: "there is no payment transaction"}
+		}
+		return models.PaymentTransaction{}, result.Error
+	}
+	return transaction, nil
+}
+
+// settlePaymentTransaction books the amount for the flat that started the
+// payment, issues its receipt, queues the receipt email with the balance the
+// payment left and marks the transaction succeeded.
+func settlePaymentTransaction(tx *gorm.DB, transaction models.PaymentTransaction, amount money.Money, at time.Time) (models.PaymentReceipt, error) {
+	flat, err := lockFlat(tx, "flat_no = ?", transaction.FlatNo)
+	if err != nil {
+		return models.PaymentReceipt{}, err
+	}
+
+	receiptNo, err := nextReceiptNo(tx)
+	if err != nil {
+		return models.PaymentReceipt{}, err
+	}
+
+	payment := models.LedgerEntry{
+		FlatNo:    flat.FlatNo,
+		Amount:    amount.Neg(),
+		Source:    models.SourcePaytr,
+		Reference: transaction.MerchantOID,
+	}
+	if err := bookPayment(tx, &payment); err != nil {
+		return models.PaymentReceipt{}, err
+	}
+
+	receipt := models.PaymentReceipt{
+		ReceiptNo:   receiptNo,
+		FlatNo:      flat.FlatNo,
+		Amount:      amount,
+		Method:      models.MethodOnline,
+		PaidAt:      at,
+		CollectedBy: models.SourcePaytr,
+		Note:        "PayTR order " + transaction.MerchantOID,
+		EntryID:     payment.ID,
+		CreatedBy:   models.SourcePaytr,
+	}
+	if err := tx.Create(&receipt).Error; err != nil {
+		return models.PaymentReceipt{}, err
+	}
+
+	summary, err := duesSummary(tx, flat.FlatNo)
+	if err != nil {
+		return models.PaymentReceipt{}, err
+	}
+
+	mail := models.ReceiptMail{
+		ReceiptNo:   receiptNo,
+		Mail:        transaction.Email,
+		Balance:     summary.Balance,
+		Status:      models.MailPending,
+		NextAttempt: at,
+	}
+	if err := tx.Create(&mail).Error; err != nil {
+		return models.PaymentReceipt{}, err
+	}
+
+	err = tx.Model(&transaction).Updates(map[string]interface{}{
+		"status":      models.PaymentSucceeded,
+		"paid_amount": amount,
+		"fail_reason": "",
+		"receipt_no":  receiptNo,
+		"entry_id":    payment.ID,
+	}).Error
+	if err != nil {
+		return models.PaymentReceipt{}, err
+	}
+	return receipt, nil
+}
+
+// GetPaymentReceipts lists the receipts of the flat, or of every flat when
+// flatNo is 0, newest first.
+func (r repo) GetPaymentReceipts(flatNo int) ([]models.PaymentReceipt, error) {
+	query := r.db.Order("receipt_no DESC")
+	if flatNo != 0 {
+		query = query.Where("flat_no = ?", flatNo)
+	}
+
+	var receipts []models.PaymentReceipt
+	result := query.Find(&receipts)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return receipts, nil
+}
+
+// GetPaymentPeriods returns the periods of the charges a payment still
+// settles, oldest first. Charges a reversal opened again are not counted.
+func (r repo) GetPaymentPeriods(entryID int) ([]string, error) {
+	var periods []string
+	result := r.db.Table("ledger_allocations a").
+		Joins("JOIN ledger_entries c ON c.id = a.charge_id").
+		Where("a.credit_id = ? AND c.type <> ?", entryID, models.LedgerReversal).
+		Group("c.period").
+		Having("SUM(a.amount) > 0").
+		Order("c.period").
+		Pluck("c.period", &periods)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return periods, nil
+}
+
+func (r repo) GetReceiptMailByReceipt(receiptNo int) (models.ReceiptMail, error) {
+	var mail models.ReceiptMail
+	result := r.db.Where("receipt_no = ?", receiptNo).Take(&mail)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return models.ReceiptMail{}, dto.ThereIsNoReceiptMail{Message: "there is no receipt mail"}
+		}
+		return models.ReceiptMail{}, result.Error
+	}
+	return mail, nil
+}
+
+// GetDueReceiptMails returns the pending receipt emails whose next attempt is
+// not after the given time, and those whose sender gave up on its claim,
+// oldest first.
+func (r repo) GetDueReceiptMails(at time.Time) ([]models.ReceiptMail, error) {
+	var mails []models.ReceiptMail
+	result := r.db.Where("status IN ? AND next_attempt <= ?", []string{models.MailPending, models.MailSending}, at).Order("id").Find(&mails)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return mails, nil
+}
+
+// GetReceiptMails lists receipt emails by status, or all of them when status
+// is empty, newest first.
+func (r repo) GetReceiptMails(status string) ([]models.ReceiptMail, error) {
+	query := r.db.Order("id DESC")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var mails []models.ReceiptMail
+	result := query.Find(&mails)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return mails, nil
+}
+
+// ClaimReceiptMail takes a due receipt email for sending, holding it until
+// the given time. Only one sender gets it; the others get
+// dto.ReceiptMailClaimed.
+func (r repo) ClaimReceiptMail(id int, at time.Time, until time.Time) (models.ReceiptMail, error) {
+	return r.claimReceiptMail(id, until, map[string]interface{}{},
+		"status IN ? AND next_attempt <= ?", []string{models.MailPending, models.MailSending}, at)
+}
+
+// ClaimReceiptMailForRetry takes a receipt email that is not sent yet for
+// sending right away, whether it failed for good or is waiting for its next
+// attempt, and starts its attempts over. A mail another sender is working on
+// is left to it.
+func (r repo) ClaimReceiptMailForRetry(id int, at time.Time, until time.Time) (models.ReceiptMail, error) {
+	return r.claimReceiptMail(id, until, map[string]interface{}{"attempts": 0},
+		"status IN ? OR (status = ? AND next_attempt <= ?)", []string{models.MailPending, models.MailFailed}, models.MailSending, at)
+}
+
+func (r repo) claimReceiptMail(id int, until time.Time, updates map[string]interface{}, query string, args ...interface{}) (models.ReceiptMail, error) {
+	updates["status"] = models.MailSending
+	updates["next_attempt"] = until
+
+	var mail models.ReceiptMail
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.ReceiptMail{}).Where("id = ?", id).Where(query, args...).Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		claimed := result.RowsAffected > 0
+
+		if err := tx.Take(&mail, id).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return dto.ThereIsNoReceiptMail{Message: "there is no receipt mail"}
+			}
+			return err
+		}
+		if !claimed && mail.Status == models.MailSent {
+			return dto.ReceiptMailClaimed{Message: "receipt mail is already sent"}
+		}
+		if !claimed {
+			return dto.ReceiptMailClaimed{Message: "receipt mail is being sent or not due yet"}
+		}
+		return nil
+	})
+	if err != nil {
+		return models.ReceiptMail{}, err
+	}
+	return mail, nil
+}
+
+func (r repo) UpdateReceiptMail(mail models.ReceiptMail) error {
+	return r.db.Model(&mail).
+		Select("status", "attempts", "error", "next_attempt", "sent_at").
+		Updates(&mail).Error
+}
+
+// nextReceiptNo hands out the next receipt number. The table lock is held
+// until the transaction ends, so numbers run without gaps or duplicates.
+func nextReceiptNo(tx *gorm.DB) (int, error) {
+	if err := tx.Exec("LOCK TABLE payment_receipts IN SHARE ROW EXCLUSIVE MODE").Error; err != nil {
+		return 0, err
+	}
+
+	var last int
+	result := tx.Model(&models.PaymentReceipt{}).Select("COALESCE(MAX(receipt_no), 0)").Scan(&last)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return last + 1, nil
+}
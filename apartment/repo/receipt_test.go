@@ -0,0 +1,228 @@
+package repo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManualPayment(t *testing.T) {
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{}, models.PaymentReceipt{})
+	repo := NewRepo(db)
+
+	result := db.Create(&models.Apartment{FlatNo: 1, Mail: "ornek@email.com"})
+	assert.NoError(t, result.Error)
+
+	assert.NoError(t, repo.AddDues(1, "2026-09", money.New(4000)))
+	assert.NoError(t, repo.AddDues(1, "2026-10", money.New(4000)))
+
+	paidAt := time.Date(2026, 10, 10, 0, 0, 0, 0, time.Local)
+	first := models.PaymentReceipt{FlatNo: 1, Amount: money.New(5000), Method: models.MethodCash, PaidAt: paidAt, CollectedBy: "manager", CreatedBy: "admin"}
+	assert.NoError(t, repo.AddManualPayment(&first))
+	second := models.PaymentReceipt{FlatNo: 1, Amount: money.New(3000), Method: models.MethodTransfer, PaidAt: paidAt, CollectedBy: "manager", CreatedBy: "admin"}
+	assert.NoError(t, repo.AddManualPayment(&second))
+	assert.Equal(t, 1, first.ReceiptNo)
+	assert.Equal(t, 2, second.ReceiptNo)
+
+	var payment models.LedgerEntry
+	result = db.First(&payment, first.EntryID)
+	assert.NoError(t, result.Error)
+	assert.Equal(t, models.LedgerPayment, payment.Type)
+	assert.Equal(t, models.SourceManual, payment.Source)
+	assert.Equal(t, "R-000001", payment.Reference)
+	assert.Equal(t, money.New(-5000), payment.Amount)
+
+	duesCount, err := repo.GetDuesCount(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, duesCount)
+
+	cancelled, err := repo.CancelManualPayment(first.ReceiptNo, "admin", "wrong flat")
+	assert.NoError(t, err)
+	assert.NotNil(t, cancelled.CancelledAt)
+	assert.NotNil(t, cancelled.CancelEntryID)
+
+	groups, err := repo.GetOpenChargeGroups(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ChargeGroup{
+		{Type: models.LedgerAccrual, Period: "2026-09", Amount: money.New(4000)},
+		{Type: models.LedgerAccrual, Period: "2026-10", Amount: money.New(1000)},
+	}, groups)
+
+	summary, err := repo.GetDuesSummary(1)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(5000), summary.Balance)
+
+	_, err = repo.CancelManualPayment(first.ReceiptNo, "admin", "again")
+	assert.IsType(t, dto.ReceiptAlreadyCancelled{}, err)
+
+	_, err = repo.CancelManualPayment(99, "admin", "missing")
+	assert.IsType(t, dto.ThereIsNoReceipt{}, err)
+
+	receipts, err := repo.GetPaymentReceipts(1)
+	assert.NoError(t, err)
+	assert.Len(t, receipts, 2)
+	assert.Equal(t, 2, receipts[0].ReceiptNo)
+}
+
+func TestManualPaymentForError(t *testing.T) {
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{}, models.PaymentReceipt{})
+	repo := NewRepo(db)
+
+	err := repo.AddManualPayment(&models.PaymentReceipt{FlatNo: 9, Amount: money.New(1000), Method: models.MethodCash, PaidAt: time.Now()})
+	assert.IsType(t, dto.ThereIsNoFlat{}, err)
+
+	_, err = repo.GetPaymentReceipt(1)
+	assert.IsType(t, dto.ThereIsNoReceipt{}, err)
+}
+
+func TestSettleOnlinePayment(t *testing.T) {
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{}, models.PaymentReceipt{}, models.ReceiptMail{}, models.PaymentTransaction{})
+	repo := NewRepo(db)
+
+	result := db.Create(&models.Apartment{FlatNo: 1, Mail: "ornek@email.com"})
+	assert.NoError(t, result.Error)
+	result = db.Create(&models.PaymentTransaction{MerchantOID: "oid", Provider: "paytr", FlatNo: 1, Email: "ornek@email.com", Amount: money.New(8000), Status: models.PaymentPending})
+	assert.NoError(t, result.Error)
+
+	assert.NoError(t, repo.AddDues(1, "2026-09", money.New(4000)))
+	assert.NoError(t, repo.AddDues(1, "2026-10", money.New(4000)))
+
+	receipt, err := repo.SettleOnlinePayment("oid", money.New(8000), time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, receipt.ReceiptNo)
+	assert.Equal(t, models.MethodOnline, receipt.Method)
+
+	periods, err := repo.GetPaymentPeriods(receipt.EntryID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2026-09", "2026-10"}, periods)
+
+	mail, err := repo.GetReceiptMailByReceipt(receipt.ReceiptNo)
+	assert.NoError(t, err)
+	assert.Equal(t, models.MailPending, mail.Status)
+	assert.Equal(t, "ornek@email.com", mail.Mail)
+	assert.Equal(t, money.New(0), mail.Balance)
+
+	due, err := repo.GetDueReceiptMails(time.Now())
+	assert.NoError(t, err)
+	assert.Len(t, due, 1)
+
+	transaction, err := repo.GetPaymentTransaction("oid")
+	assert.NoError(t, err)
+	assert.Equal(t, models.PaymentSucceeded, transaction.Status)
+	assert.Equal(t, receipt.ReceiptNo, transaction.ReceiptNo)
+
+	_, err = repo.CancelManualPayment(receipt.ReceiptNo, "admin", "mistake")
+	assert.IsType(t, dto.ManualPaymentError{}, err)
+}
+
+func TestClaimReceiptMail(t *testing.T) {
+	db := setupDb(models.ReceiptMail{})
+	repo := NewRepo(db)
+
+	now := time.Now()
+	mail := models.ReceiptMail{ReceiptNo: 1, Mail: "ornek@email.com", Status: models.MailPending, NextAttempt: now}
+	assert.NoError(t, db.Create(&mail).Error)
+
+	_, err := repo.ClaimReceiptMail(mail.ID, now.Add(-time.Minute), now.Add(time.Hour))
+	assert.IsType(t, dto.ReceiptMailClaimed{}, err)
+
+	claimed, err := repo.ClaimReceiptMail(mail.ID, now, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, models.MailSending, claimed.Status)
+
+	_, err = repo.ClaimReceiptMail(mail.ID, now, now.Add(time.Hour))
+	assert.IsType(t, dto.ReceiptMailClaimed{}, err)
+	_, err = repo.ClaimReceiptMailForRetry(mail.ID, now, now.Add(time.Hour))
+	assert.IsType(t, dto.ReceiptMailClaimed{}, err)
+
+	due, err := repo.GetDueReceiptMails(now.Add(2 * time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, due, 1)
+	claimed, err = repo.ClaimReceiptMail(mail.ID, now.Add(2*time.Hour), now.Add(3*time.Hour))
+	assert.NoError(t, err)
+
+	claimed.Status = models.MailFailed
+	claimed.Attempts = 5
+	assert.NoError(t, repo.UpdateReceiptMail(claimed))
+	claimed, err = repo.ClaimReceiptMailForRetry(mail.ID, now, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, models.MailSending, claimed.Status)
+	assert.Equal(t, 0, claimed.Attempts)
+
+	claimed.Status = models.MailSent
+	assert.NoError(t, repo.UpdateReceiptMail(claimed))
+	_, err = repo.ClaimReceiptMailForRetry(mail.ID, now.Add(2*time.Hour), now.Add(3*time.Hour))
+	assert.EqualError(t, err, "receipt mail is already sent")
+
+	_, err = repo.ClaimReceiptMail(99, now, now.Add(time.Hour))
+	assert.IsType(t, dto.ThereIsNoReceiptMail{}, err)
+}
+
+func TestSettleOnlinePaymentOnce(t *testing.T) {
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{}, models.PaymentReceipt{}, models.ReceiptMail{}, models.PaymentTransaction{})
+	repo := NewRepo(db)
+
+	db.Create(&models.Apartment{FlatNo: 1, Mail: "ornek@email.com"})
+	db.Create(&models.PaymentTransaction{MerchantOID: "oid", Provider: "paytr", FlatNo: 1, Email: "ornek@email.com", Amount: money.New(4000), Status: models.PaymentPending})
+	assert.NoError(t, repo.AddDues(1, "2026-09", money.New(4000)))
+	assert.NoError(t, repo.AddDues(1, "2026-10", money.New(4000)))
+
+	_, err := repo.SettleOnlinePayment("oid", money.New(4000), time.Now())
+	assert.NoError(t, err)
+
+	_, err = repo.SettleOnlinePayment("oid", money.New(4000), time.Now())
+	assert.IsType(t, dto.PaymentAlreadySettled{}, err)
+
+	receipts, err := repo.GetPaymentReceipts(1)
+	assert.NoError(t, err)
+	assert.Len(t, receipts, 1)
+
+	summary, err := repo.GetDuesSummary(1)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(4000), summary.Balance)
+}
+
+func TestSettleOnlinePaymentAmountMismatch(t *testing.T) {
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{}, models.PaymentReceipt{}, models.ReceiptMail{}, models.PaymentTransaction{})
+	repo := NewRepo(db)
+
+	db.Create(&models.Apartment{FlatNo: 1, Mail: "ornek@email.com"})
+	db.Create(&models.PaymentTransaction{MerchantOID: "oid", Provider: "paytr", FlatNo: 1, Email: "ornek@email.com", Amount: money.New(8000), Status: models.PaymentPending})
+	assert.NoError(t, repo.AddDues(1, "2026-09", money.New(4000)))
+	assert.NoError(t, repo.AddDues(1, "2026-10", money.New(4000)))
+
+	_, err := repo.SettleOnlinePayment("oid", money.New(4000), time.Now())
+	assert.IsType(t, dto.PaymentAmountMismatch{}, err)
+
+	transaction, err := repo.GetPaymentTransaction("oid")
+	assert.NoError(t, err)
+	assert.Equal(t, models.PaymentReview, transaction.Status)
+	assert.Equal(t, money.New(4000), transaction.PaidAmount)
+
+	summary, err := repo.GetDuesSummary(1)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(8000), summary.Balance)
+
+	_, err = repo.SettleOnlinePayment("oid", money.New(4000), time.Now())
+	assert.IsType(t, dto.PaymentAlreadySettled{}, err)
+
+	receipt, err := repo.AcceptPaymentReview("oid", "admin", time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(4000), receipt.Amount)
+
+	periods, err := repo.GetPaymentPeriods(receipt.EntryID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2026-09"}, periods)
+
+	transaction, err = repo.GetPaymentTransaction("oid")
+	assert.NoError(t, err)
+	assert.Equal(t, models.PaymentSucceeded, transaction.Status)
+	assert.Equal(t, "admin", transaction.ReviewedBy)
+
+	_, err = repo.AcceptPaymentReview("oid", "admin", time.Now())
+	assert.IsType(t, dto.PaymentStateConflict{}, err)
+}
@@ -0,0 +1,49 @@
+package repo
+
+import (
+	"time"
+
+	"github.com/pragmataW/apartment_management/models"
+)
+
+func (r repo) SaveDuesReminder(reminder *models.DuesReminder) error {
+	return r.db.Create(reminder).Error
+}
+
+// GetLastReminderTimes returns, per flat, when it was last sent a reminder
+// that went out.
+func (r repo) GetLastReminderTimes() (map[int]time.Time, error) {
+	var rows []struct {
+		FlatNo int       `gorm:"column:flat_no"`
+		SentAt time.Time `gorm:"column:sent_at"`
+	}
+	result := r.db.Model(&models.DuesReminder{}).
+		Select("flat_no, MAX(sent_at) AS sent_at").
+		Where("status = ?", models.ReminderSent).
+		Group("flat_no").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	last := make(map[int]time.Time, len(rows))
+	for _, row := range rows {
+		last[row.FlatNo] = row.SentAt
+	}
+	return last, nil
+}
+
+// GetDuesReminders lists the reminders of the flat, or of every flat when
+// flatNo is 0, newest first.
+func (r repo) GetDuesReminders(flatNo int) ([]models.DuesReminder, error) {
+	var reminders []models.DuesReminder
+	query := r.db.Order("sent_at DESC, id DESC")
+	if flatNo != 0 {
+		query = query.Where("flat_no = ?", flatNo)
+	}
+	result := query.Find(&reminders)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return reminders, nil
+}
@@ -0,0 +1,35 @@
+package repo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLastReminderTimes(t *testing.T) {
+	db := setupDb(models.DuesReminder{})
+	repo := NewRepo(db)
+
+	day := time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)
+	reminders := []models.DuesReminder{
+		{FlatNo: 1, Mail: "a@mail.com", Amount: money.New(4000), Status: models.ReminderSent, SentAt: day},
+		{FlatNo: 1, Mail: "a@mail.com", Amount: money.New(4000), Status: models.ReminderFailed, SentAt: day.AddDate(0, 0, 2)},
+		{FlatNo: 2, Mail: "b@mail.com", Amount: money.New(8000), Status: models.ReminderFailed, SentAt: day},
+	}
+	for i := range reminders {
+		assert.NoError(t, repo.SaveDuesReminder(&reminders[i]))
+	}
+
+	last, err := repo.GetLastReminderTimes()
+	assert.NoError(t, err)
+	assert.Len(t, last, 1)
+	assert.True(t, last[1].Equal(day))
+
+	log, err := repo.GetDuesReminders(1)
+	assert.NoError(t, err)
+	assert.Len(t, log, 2)
+	assert.Equal(t, models.ReminderFailed, log[0].Status)
+}
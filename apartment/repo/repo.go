@@ -1,11 +1,14 @@
 package repo
 
 import (
-	"errors"
+	"sort"
+	"time"
 
 	"github.com/pragmataW/apartment_management/dto"
 	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func (r repo) CreateFlat(flatNo int) error {
@@ -27,7 +30,10 @@ func (r repo) CreateFlat(flatNo int) error {
 }
 
 func (r repo) UpdateFlatOwner(apartment models.Apartment) error {
-	result := r.db.Save(&apartment)
+	result := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "flat_no"}},
+		DoUpdates: clause.AssignmentColumns([]string{"owner_name", "owner_surname", "mail", "password"}),
+	}).Create(&apartment)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -51,15 +57,45 @@ func (r repo) GetAllInfoAboutFlat(flatNo int) (models.Apartment, error) {
 	flat := models.Apartment{}
 	result := r.db.Where("flat_no = ?", flatNo).Take(&flat)
 	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return models.Apartment{}, dto.ThereIsNoFlat{Message: "there is no flat"}
+		}
 		return models.Apartment{}, result.Error
 	}
 
 	return flat, nil
 }
 
+func (r repo) GetFlatByEmail(email string) (models.Apartment, error) {
+	flat := models.Apartment{}
+	result := r.db.Where("mail = ?", email).Take(&flat)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return models.Apartment{}, dto.ThereIsNoFlat{Message: "there is no flat"}
+		}
+		return models.Apartment{}, result.Error
+	}
+
+	return flat, nil
+}
+
+func (r repo) UpdateDuesProfile(apartment models.Apartment) error {
+	result := r.db.Model(&models.Apartment{}).
+		Where("flat_no = ?", apartment.FlatNo).
+		Select("flat_type", "area", "share", "dues_coefficient", "dues_override").
+		Updates(&apartment)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return dto.ThereIsNoFlat{Message: "there is no flat"}
+	}
+	return nil
+}
+
 func (r repo) GetAllInfoAboutAllFlats() ([]models.Apartment, error) {
 	var flatList []models.Apartment
-	result := r.db.Select("flat_no", "owner_name", "owner_surname", "mail", "dues_count").Find(&flatList)
+	result := r.db.Select("flat_no", "owner_name", "owner_surname", "mail", "flat_type", "area", "share", "dues_coefficient", "dues_override").Find(&flatList)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -67,65 +103,231 @@ func (r repo) GetAllInfoAboutAllFlats() ([]models.Apartment, error) {
 	return flatList, nil
 }
 
-func (r repo) AddDues(flatNo int) error {
-	result := r.db.Model(&models.Apartment{}).Where("flat_no = ?", flatNo).UpdateColumn("dues_count", gorm.Expr("dues_count + ?", 1))
+func (r repo) AddDues(flatNo int, period string, amount money.Money) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := lockFlat(tx, "flat_no = ?", flatNo); err != nil {
+			return err
+		}
+
+		entry := models.LedgerEntry{
+			FlatNo: flatNo,
+			Period: period,
+			Type:   models.LedgerAccrual,
+			Amount: amount,
+			Source: models.SourceAdmin,
+		}
+		return insertLedgerEntry(tx, &entry)
+	})
+}
+
+func (r repo) DeleteDues(flatNo int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := lockFlat(tx, "flat_no = ?", flatNo); err != nil {
+			return err
+		}
+
+		return settleOldestCharge(tx, flatNo, models.LedgerEntry{
+			Type:   models.LedgerWaiver,
+			Source: models.SourceAdmin,
+		})
+	})
+}
+
+// AddDuesForAll charges every flat in amounts for the run's period. The
+// accrual run is recorded in the same transaction, so a period that was
+// already charged is rejected with dto.PeriodAlreadyAccrued instead of being
+// charged twice.
+func (r repo) AddDuesForAll(run models.AccrualRun, amounts map[int]money.Money) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		run.FlatCount = len(amounts)
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&run)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return dto.PeriodAlreadyAccrued{Message: "period already accrued: " + run.Period}
+		}
+
+		flatNos := make([]int, 0, len(amounts))
+		for flatNo := range amounts {
+			flatNos = append(flatNos, flatNo)
+		}
+		sort.Ints(flatNos)
+
+		for _, flatNo := range flatNos {
+			if !amounts[flatNo].IsPositive() {
+				continue
+			}
+
+			entry := models.LedgerEntry{
+				FlatNo:  flatNo,
+				Period:  run.Period,
+				Type:    models.LedgerAccrual,
+				Amount:  amounts[flatNo],
+				Source:  models.SourceScheduler,
+				DueDate: run.DueDate,
+			}
+			if err := insertLedgerEntry(tx, &entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r repo) GetLastAccrualRun() (models.AccrualRun, error) {
+	var run models.AccrualRun
+	result := r.db.Order("period DESC").Limit(1).Find(&run)
 	if result.Error != nil {
-		return result.Error
+		return models.AccrualRun{}, result.Error
 	}
-	return nil
+	if result.RowsAffected == 0 {
+		return models.AccrualRun{}, dto.ThereIsNoAccrualRun{Message: "there is no accrual run"}
+	}
+	return run, nil
 }
 
-func (r repo) DeleteDues(flatNo int) error {
-	existingDues, err := r.GetDuesCount(flatNo)
+func (r repo) GetAccrualRuns() ([]models.AccrualRun, error) {
+	var runs []models.AccrualRun
+	result := r.db.Order("period DESC").Find(&runs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return runs, nil
+}
+
+func (r repo) GetDuesCount(flatNo int) (int, error) {
+	charges, err := openCharges(r.db, flatNo)
 	if err != nil {
-		return err
+		return -1, err
 	}
 
-	if existingDues <= 0 {
-		return dto.ThereIsNoDues{}
+	count := 0
+	for _, charge := range charges {
+		if charge.Type == models.LedgerAccrual {
+			count++
+		}
 	}
+	return count, nil
+}
 
-	result := r.db.Model(&models.Apartment{}).Where("flat_no = ?", flatNo).UpdateColumn("dues_count", gorm.Expr("dues_count - ?", 1))
-	if result.Error != nil {
-		return result.Error
+// GetOpenChargeGroups returns the open charges of the flat with their late
+// fees, in the order a payment settles them.
+func (r repo) GetOpenChargeGroups(flatNo int) ([]models.ChargeGroup, error) {
+	charges, err := openCharges(r.db, flatNo)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+
+	var groups []models.ChargeGroup
+	for _, group := range settlementGroups(charges) {
+		charge := group[0]
+		for _, member := range group {
+			if member.Type != models.LedgerPenalty {
+				charge = member
+				break
+			}
+		}
+		groups = append(groups, models.ChargeGroup{
+			Type:   charge.Type,
+			Period: charge.Period,
+			Amount: sumRemaining(group),
+		})
+	}
+	return groups, nil
 }
 
-func (r repo) AddDuesForAll() error {
-	stmt := "UPDATE apartments SET dues_count = dues_count + 1"
-	result := r.db.Exec(stmt)
-	if result.Error != nil {
-		return result.Error
+// bookPayment writes the payment entry of a flat that is already locked and
+// allocates it to the open months oldest first. The payment is booked for the
+// period of the oldest month it settles, or the current one when nothing is
+// open.
+func bookPayment(tx *gorm.DB, payment *models.LedgerEntry) error {
+	charges, err := openCharges(tx, payment.FlatNo)
+	if err != nil {
+		return err
 	}
-	return nil
+
+	groups := settlementGroups(charges)
+	var ordered []models.OpenCharge
+	for _, group := range groups {
+		ordered = append(ordered, group...)
+	}
+
+	payment.Type = models.LedgerPayment
+	payment.Period = time.Now().Format("2006-01")
+	if len(groups) > 0 {
+		payment.Period = groups[0][0].Period
+	}
+	if err := createLedgerEntry(tx, payment); err != nil {
+		return err
+	}
+	return allocateCredit(tx, *payment, ordered)
 }
 
-func (r repo) GetDuesCount(flatNo int) (int, error) {
-	flat := models.Apartment{}
-	result := r.db.Where("flat_no = ?", flatNo).Select("dues_count").First(&flat)
-	if result.Error != nil {
-		return -1, result.Error
+func settleOldestCharge(tx *gorm.DB, flatNo int, credit models.LedgerEntry) error {
+	charges, err := openCharges(tx, flatNo)
+	if err != nil {
+		return err
 	}
-	return flat.DuesCount, nil
+
+	groups := settlementGroups(charges)
+	if len(groups) == 0 {
+		return dto.ThereIsNoDues{Message: "there is no dues"}
+	}
+	settled := groups[0]
+
+	credit.FlatNo = flatNo
+	credit.Period = settled[0].Period
+	credit.Amount = sumRemaining(settled).Neg()
+	if err := createLedgerEntry(tx, &credit); err != nil {
+		return err
+	}
+	return allocateCredit(tx, credit, settled)
 }
 
-func (r repo) DeleteDuesByEmail(email string) error {
-	var flat models.Apartment
-	result := r.db.Where("mail = ?", email).First(&flat)
-	if result.Error != nil {
-		return result.Error
+// settlementGroups splits open charges into the units they are paid or waived
+// in: every charge together with the open penalties raised for it, so that
+// settling a month also clears its late fees. Penalties whose charge is
+// already cleared go with the oldest group.
+func settlementGroups(charges []models.OpenCharge) [][]models.OpenCharge {
+	var groups [][]models.OpenCharge
+	index := map[int]int{}
+	var orphans []models.OpenCharge
+	for _, charge := range charges {
+		if charge.Type != models.LedgerPenalty {
+			index[charge.ID] = len(groups)
+			groups = append(groups, []models.OpenCharge{charge})
+		}
 	}
 
-	if flat.DuesCount <= 0 {
-		return dto.ThereIsNoDues{}
+	for _, charge := range charges {
+		if charge.Type != models.LedgerPenalty {
+			continue
+		}
+		if charge.ChargeID != nil {
+			if i, ok := index[*charge.ChargeID]; ok {
+				groups[i] = append(groups[i], charge)
+				continue
+			}
+		}
+		orphans = append(orphans, charge)
 	}
 
-	result = r.db.Model(&models.Apartment{}).Where("mail = ?", email).UpdateColumn("dues_count", gorm.Expr("dues_count - ?", 1))
-	if result.Error != nil {
-		return result.Error
+	if len(orphans) > 0 {
+		if len(groups) == 0 {
+			return [][]models.OpenCharge{orphans}
+		}
+		groups[0] = append(orphans, groups[0]...)
 	}
-	return nil
+	return groups
+}
+
+func sumRemaining(charges []models.OpenCharge) money.Money {
+	total := money.New(0)
+	for _, charge := range charges {
+		total = total.Add(charge.Remaining)
+	}
+	return total
 }
 
 func (r repo) GetPasswordAndFlatNoByEmail(email string) (string, int, error) {
@@ -157,31 +359,3 @@ func (r repo) AddAnnouncement(announcement models.Announcement) error {
 	}
 	return nil
 }
-
-func (r repo) AddMerchant(uuid string, email string) error {
-	newMerchant := models.Merchant{
-		MerchantID: uuid,
-		Email:      email,
-	}
-
-	result := r.db.Create(&newMerchant)
-	if result.Error != nil {
-		return result.Error
-	}
-
-	return nil
-}
-
-func (r repo) GetEmailFromMerchant(merchantOID string) (string, error) {
-	var merchant models.Merchant
-	result := r.db.First(&merchant, "merchant_id = ?", merchantOID)
-	if result.Error != nil {
-		return "", result.Error
-	}
-
-	if result.RowsAffected == 0 {
-		return "", errors.New("there is no merchant")
-	}
-
-	return merchant.Email, nil
-}
@@ -3,6 +3,7 @@ package repo
 import (
 	"log"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pragmataW/apartment_management/dto"
@@ -10,13 +11,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"github.com/pragmataW/apartment_management/pkg/money"
 )
 
 var (
 	dsn = "host=localhost user=postgres password=123wsedrf dbname=Apartments port=5432 sslmode=disable"
 )
 
-func setupDb(model interface{}) *gorm.DB {
+func setupDb(tables ...interface{}) *gorm.DB {
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
@@ -25,7 +27,7 @@ func setupDb(model interface{}) *gorm.DB {
 	db.Exec("DROP SCHEMA public CASCADE; CREATE SCHEMA public;")
 
 	migrator := db.Migrator()
-	migrator.CreateTable(model)
+	migrator.CreateTable(tables...)
 
 	return db
 }
@@ -66,12 +68,13 @@ func TestUpdateFlatOwner(t *testing.T) {
 
 	repo := NewRepo(db)
 	expected := models.Apartment{
-		FlatNo:       1,
-		OwnerName:    "Yusuf",
-		OwnerSurname: "Çiftçi",
-		Mail:         "yciftci@gmail.com",
-		Password:     "123",
-		DuesCount:    0,
+		FlatNo:          1,
+		OwnerName:       "Yusuf",
+		OwnerSurname:    "Çiftçi",
+		Mail:            "yciftci@gmail.com",
+		Password:        "123",
+		FlatType:        models.FlatResidential,
+		DuesCoefficient: 1,
 	}
 
 	err := repo.CreateFlat(1)
@@ -125,15 +128,15 @@ func TestGetAllInfoAboutFlat(t *testing.T) {
 	ownerSurname := "çiftçi"
 	ownerMail := "yciftci@gmail.com"
 	ownerPassword := "123"
-	duesCount := 0
 
 	apartment := models.Apartment{
-		FlatNo:       flatNo,
-		OwnerName:    ownerName,
-		OwnerSurname: ownerSurname,
-		Mail:         ownerMail,
-		Password:     ownerPassword,
-		DuesCount:    duesCount,
+		FlatNo:          flatNo,
+		OwnerName:       ownerName,
+		OwnerSurname:    ownerSurname,
+		Mail:            ownerMail,
+		Password:        ownerPassword,
+		FlatType:        models.FlatResidential,
+		DuesCoefficient: 1,
 	}
 
 	result := db.Create(&apartment)
@@ -144,13 +147,74 @@ func TestGetAllInfoAboutFlat(t *testing.T) {
 	assert.Equal(t, expected, apartment)
 }
 
+func TestGetAllInfoAboutFlatForError(t *testing.T) {
+	db := setupDb(models.Apartment{})
+	repo := NewRepo(db)
+
+	_, err := repo.GetAllInfoAboutFlat(1)
+	assert.Error(t, err)
+	assert.IsType(t, dto.ThereIsNoFlat{}, err)
+}
+
+func TestGetFlatByEmail(t *testing.T) {
+	db := setupDb(models.Apartment{})
+	repo := NewRepo(db)
+
+	result := db.Create(&models.Apartment{FlatNo: 1, Mail: "yciftci@gmail.com", DuesCoefficient: 1.5})
+	assert.NoError(t, result.Error)
+
+	flat, err := repo.GetFlatByEmail("yciftci@gmail.com")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, flat.FlatNo)
+	assert.Equal(t, 1.5, flat.DuesCoefficient)
+
+	_, err = repo.GetFlatByEmail("nobody@gmail.com")
+	assert.IsType(t, dto.ThereIsNoFlat{}, err)
+}
+
+func TestUpdateDuesProfile(t *testing.T) {
+	db := setupDb(models.Apartment{})
+	repo := NewRepo(db)
+
+	result := db.Create(&models.Apartment{FlatNo: 1, OwnerName: "Yusuf", Mail: "yciftci@gmail.com"})
+	assert.NoError(t, result.Error)
+
+	override := money.New(0)
+	err := repo.UpdateDuesProfile(models.Apartment{
+		FlatNo:          1,
+		FlatType:        models.FlatCaretaker,
+		Area:            55,
+		Share:           0.02,
+		DuesCoefficient: 1,
+		DuesOverride:    &override,
+	})
+	assert.NoError(t, err)
+
+	flat, err := repo.GetAllInfoAboutFlat(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Yusuf", flat.OwnerName)
+	assert.Equal(t, models.FlatCaretaker, flat.FlatType)
+	assert.Equal(t, 55.0, flat.Area)
+	assert.NotNil(t, flat.DuesOverride)
+	assert.Equal(t, money.New(0), *flat.DuesOverride)
+}
+
+func TestUpdateDuesProfileForError(t *testing.T) {
+	db := setupDb(models.Apartment{})
+	repo := NewRepo(db)
+
+	err := repo.UpdateDuesProfile(models.Apartment{FlatNo: 1, FlatType: models.FlatShop, DuesCoefficient: 2})
+	assert.Error(t, err)
+	assert.IsType(t, dto.ThereIsNoFlat{}, err)
+}
+
 func TestGetAllInfoAboutAllFlats(t *testing.T) {
 	db := setupDb(models.Apartment{})
 	repo := NewRepo(db)
 
 	apartments := []models.Apartment{
-		{FlatNo: 1, OwnerName: "yusuf", OwnerSurname: "çiftçi", Mail: "yciftci@gmail.com", Password: "123", DuesCount: 0},
-		{FlatNo: 2, OwnerName: "ali", OwnerSurname: "veli", Mail: "aliveli@gmail.com", Password: "456", DuesCount: 1},
+		{FlatNo: 1, OwnerName: "yusuf", OwnerSurname: "çiftçi", Mail: "yciftci@gmail.com", Password: "123"},
+		{FlatNo: 2, OwnerName: "ali", OwnerSurname: "veli", Mail: "aliveli@gmail.com", Password: "456"},
 	}
 
 	for _, apartment := range apartments {
@@ -167,12 +231,11 @@ func TestGetAllInfoAboutAllFlats(t *testing.T) {
 		assert.Equal(t, expected.OwnerName, resultApartments[i].OwnerName)
 		assert.Equal(t, expected.OwnerSurname, resultApartments[i].OwnerSurname)
 		assert.Equal(t, expected.Mail, resultApartments[i].Mail)
-		assert.Equal(t, expected.DuesCount, resultApartments[i].DuesCount)
 	}
 }
 
 func TestGetDuesCount(t *testing.T) {
-	db := setupDb(models.Apartment{})
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{})
 	repo := NewRepo(db)
 
 	flatNo := 1
@@ -182,58 +245,71 @@ func TestGetDuesCount(t *testing.T) {
 		OwnerSurname: "Çiftçi",
 		Mail:         "yciftci@gmail.com",
 		Password:     "123",
-		DuesCount:    0,
 	}
 
 	result := db.Create(&apartment)
 	assert.NoError(t, result.Error)
 
+	err := repo.AddDues(flatNo, "2026-09", money.New(4000))
+	assert.NoError(t, err)
+	err = repo.AddDues(flatNo, "2026-10", money.New(4000))
+	assert.NoError(t, err)
+
 	duesCount, err := repo.GetDuesCount(flatNo)
 	assert.NoError(t, err)
-	assert.Equal(t, apartment.DuesCount, duesCount)
+	assert.Equal(t, 2, duesCount)
 }
 
 func TestAddDues(t *testing.T) {
-	db := setupDb(models.Apartment{})
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{})
 	repo := NewRepo(db)
 
 	flatNo := 1
-	duesCount := 0
 	apartment := models.Apartment{
 		FlatNo:       flatNo,
 		OwnerName:    "Yusuf",
 		OwnerSurname: "Çiftçi",
 		Mail:         "yciftci@gmail.com",
 		Password:     "123",
-		DuesCount:    duesCount,
 	}
 
 	result := db.Create(&apartment)
 	assert.NoError(t, result.Error)
 
-	err := repo.AddDues(flatNo)
+	err := repo.AddDues(flatNo, "2026-10", money.New(4000))
 	assert.NoError(t, err)
 
-	var actual models.Apartment
-	result = db.First(&actual, "flat_no = ?", flatNo)
+	var entries []models.LedgerEntry
+	result = db.Find(&entries, "flat_no = ?", flatNo)
 	assert.NoError(t, result.Error)
-	assert.Equal(t, apartment.DuesCount+1, actual.DuesCount)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, models.LedgerAccrual, entries[0].Type)
+	assert.Equal(t, models.SourceAdmin, entries[0].Source)
+	assert.Equal(t, "2026-10", entries[0].Period)
+	assert.Equal(t, money.New(4000), entries[0].Amount)
+}
+
+func TestAddDuesForError(t *testing.T) {
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{})
+	repo := NewRepo(db)
+
+	err := repo.AddDues(32, "2026-10", money.New(4000))
+	assert.Error(t, err)
+	assert.IsType(t, dto.ThereIsNoFlat{}, err)
 }
 
 func TestAddDuesForAll(t *testing.T) {
-	db := setupDb(models.Apartment{})
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{}, models.AccrualRun{})
 	repo := NewRepo(db)
 
 	flatNo1 := 1
 	flatNo2 := 2
-	duesCount := 0
 	apartment1 := models.Apartment{
 		FlatNo:       flatNo1,
 		OwnerName:    "Yusuf",
 		OwnerSurname: "Çiftçi",
 		Mail:         "yciftci@gmail.com",
 		Password:     "123",
-		DuesCount:    duesCount,
 	}
 	apartment2 := models.Apartment{
 		FlatNo:       flatNo2,
@@ -241,7 +317,6 @@ func TestAddDuesForAll(t *testing.T) {
 		OwnerSurname: "Demir",
 		Mail:         "ademir@gmail.com",
 		Password:     "456",
-		DuesCount:    duesCount,
 	}
 
 	result := db.Create(&apartment1)
@@ -249,59 +324,138 @@ func TestAddDuesForAll(t *testing.T) {
 	result = db.Create(&apartment2)
 	assert.NoError(t, result.Error)
 
-	err := repo.AddDuesForAll()
+	run := models.AccrualRun{
+		Period:  "2026-10",
+		Amount:  money.New(4000),
+		DueDate: time.Date(2026, 10, 15, 0, 0, 0, 0, time.Local),
+	}
+	err := repo.AddDuesForAll(run, map[int]money.Money{flatNo1: money.New(4000), flatNo2: money.New(6000)})
+	assert.NoError(t, err)
+
+	summaries, err := repo.GetDuesSummaries()
+	assert.NoError(t, err)
+	assert.Len(t, summaries, 2)
+	for _, summary := range summaries {
+		assert.Equal(t, 1, summary.OpenDues)
+	}
+	assert.Equal(t, money.New(4000), summaries[0].Balance)
+	assert.Equal(t, money.New(6000), summaries[1].Balance)
+
+	lastRun, err := repo.GetLastAccrualRun()
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-10", lastRun.Period)
+	assert.Equal(t, 2, lastRun.FlatCount)
+}
+
+func TestAddDuesForAllForError(t *testing.T) {
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{}, models.AccrualRun{})
+	repo := NewRepo(db)
+
+	result := db.Create(&models.Apartment{FlatNo: 1, Mail: "yciftci@gmail.com"})
+	assert.NoError(t, result.Error)
+
+	run := models.AccrualRun{
+		Period:  "2026-10",
+		Amount:  money.New(4000),
+		DueDate: time.Date(2026, 10, 15, 0, 0, 0, 0, time.Local),
+	}
+	err := repo.AddDuesForAll(run, map[int]money.Money{1: money.New(4000)})
+	assert.NoError(t, err)
+
+	err = repo.AddDuesForAll(run, map[int]money.Money{1: money.New(4000)})
+	assert.Error(t, err)
+	assert.IsType(t, dto.PeriodAlreadyAccrued{}, err)
+
+	duesCount, err := repo.GetDuesCount(1)
 	assert.NoError(t, err)
+	assert.Equal(t, 1, duesCount)
+}
 
-	var actual1, actual2 models.Apartment
-	result = db.First(&actual1, "flat_no = ?", flatNo1)
+func TestGetLastAccrualRunForError(t *testing.T) {
+	db := setupDb(models.AccrualRun{})
+	repo := NewRepo(db)
+
+	_, err := repo.GetLastAccrualRun()
+	assert.Error(t, err)
+	assert.IsType(t, dto.ThereIsNoAccrualRun{}, err)
+}
+
+func TestSeedAccrualRuns(t *testing.T) {
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{}, models.AccrualRun{})
+	repo := NewRepo(db)
+
+	result := db.Create(&[]models.Apartment{{FlatNo: 1, Mail: "a@mail.com"}, {FlatNo: 2, Mail: "b@mail.com"}})
 	assert.NoError(t, result.Error)
-	result = db.First(&actual2, "flat_no = ?", flatNo2)
+	result = db.Create(&[]models.LedgerEntry{
+		{FlatNo: 1, Period: "2026-08", Type: models.LedgerAccrual, Amount: money.New(4000), Source: models.SourceMigration, DueDate: time.Date(2026, 8, 1, 0, 0, 0, 0, time.Local)},
+		{FlatNo: 2, Period: "2026-08", Type: models.LedgerAccrual, Amount: money.New(4000), Source: models.SourceMigration, DueDate: time.Date(2026, 8, 1, 0, 0, 0, 0, time.Local)},
+		{FlatNo: 1, Period: "2026-09", Type: models.LedgerAccrual, Amount: money.New(4000), Source: models.SourceMigration, DueDate: time.Date(2026, 9, 1, 0, 0, 0, 0, time.Local)},
+		{FlatNo: 2, Period: "2026-10", Type: models.LedgerAccrual, Amount: money.New(4000), Source: models.SourceAdmin, DueDate: time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)},
+	})
 	assert.NoError(t, result.Error)
 
-	assert.Equal(t, apartment1.DuesCount+1, actual1.DuesCount)
-	assert.Equal(t, apartment2.DuesCount+1, actual2.DuesCount)
+	err := seedAccrualRuns(db)
+	assert.NoError(t, err)
+
+	lastRun, err := repo.GetLastAccrualRun()
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-09", lastRun.Period)
+	assert.Equal(t, 1, lastRun.FlatCount)
+
+	runs, err := repo.GetAccrualRuns()
+	assert.NoError(t, err)
+	assert.Len(t, runs, 2)
 }
 
 func TestDeleteDues(t *testing.T) {
-	db := setupDb(models.Apartment{})
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{})
 	repo := NewRepo(db)
 
 	flatNo := 1
-	duesCount := 1
 	apartment := models.Apartment{
 		FlatNo:       flatNo,
 		OwnerName:    "Yusuf",
 		OwnerSurname: "Çiftçi",
 		Mail:         "yciftci@gmail.com",
 		Password:     "123",
-		DuesCount:    duesCount,
 	}
 
 	result := db.Create(&apartment)
 	assert.NoError(t, result.Error)
 
-	err := repo.DeleteDues(flatNo)
+	err := repo.AddDues(flatNo, "2026-10", money.New(4000))
+	assert.NoError(t, err)
+
+	err = repo.DeleteDues(flatNo)
+	assert.NoError(t, err)
+
+	summary, err := repo.GetDuesSummary(flatNo)
 	assert.NoError(t, err)
+	assert.Equal(t, 0, summary.OpenDues)
+	assert.Equal(t, money.New(0), summary.Balance)
+
+	var waiver models.LedgerEntry
+	result = db.First(&waiver, "flat_no = ? AND type = ?", flatNo, models.LedgerWaiver)
+	assert.NoError(t, result.Error)
+	assert.Equal(t, money.New(-4000), waiver.Amount)
 
-	var actual models.Apartment
-	result = db.First(&actual, "flat_no = ?", flatNo)
+	var allocation models.LedgerAllocation
+	result = db.First(&allocation, "credit_id = ?", waiver.ID)
 	assert.NoError(t, result.Error)
-	assert.Equal(t, apartment.DuesCount-1, actual.DuesCount)
+	assert.Equal(t, money.New(4000), allocation.Amount)
 }
 
 func TestDeleteDuesForError(t *testing.T) {
-	db := setupDb(models.Apartment{})
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{})
 	repo := NewRepo(db)
 
 	flatNo := 1
-	duesCount := 0
 	apartment := models.Apartment{
 		FlatNo:       flatNo,
 		OwnerName:    "Yusuf",
 		OwnerSurname: "Çiftçi",
 		Mail:         "yciftci@gmail.com",
 		Password:     "123",
-		DuesCount:    duesCount,
 	}
 
 	result := db.Create(&apartment)
@@ -312,6 +466,38 @@ func TestDeleteDuesForError(t *testing.T) {
 	assert.IsType(t, dto.ThereIsNoDues{}, err)
 }
 
+func TestAddLedgerEntry(t *testing.T) {
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{})
+	repo := NewRepo(db)
+
+	flatNo := 1
+	result := db.Create(&models.Apartment{FlatNo: flatNo, Mail: "yciftci@gmail.com"})
+	assert.NoError(t, result.Error)
+
+	err := repo.AddDues(flatNo, "2026-09", money.New(4000))
+	assert.NoError(t, err)
+	err = repo.AddDues(flatNo, "2026-10", money.New(4000))
+	assert.NoError(t, err)
+
+	err = repo.AddLedgerEntry(models.LedgerEntry{
+		FlatNo: flatNo,
+		Period: "2026-10",
+		Type:   models.LedgerAdjustment,
+		Amount: money.New(-5000),
+		Source: models.SourceAdmin,
+	})
+	assert.NoError(t, err)
+
+	entries, err := repo.GetLedgerEntries(flatNo)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 3)
+
+	summary, err := repo.GetDuesSummary(flatNo)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(3000), summary.Balance)
+	assert.Equal(t, 1, summary.OpenDues)
+}
+
 func TestGetAllAnnouncements(t *testing.T) {
 	db := setupDb(models.Announcement{})
 	repo := NewRepo(db)
@@ -385,67 +571,149 @@ func TestAddAnnouncement(t *testing.T) {
 	assert.Equal(t, announcemement.Content, actual.Content)
 }
 
-func TestAddMerchant(t *testing.T) {
+func TestCreatePaymentTransaction(t *testing.T) {
 	// Veritabanı bağlantısını kur
-	db := setupDb(models.Merchant{})
+	db := setupDb(models.PaymentTransaction{})
 	repo := NewRepo(db)
 
-	// Eklenecek satıcının e-posta adresi
+	// Eklenecek ödemenin e-posta adresi
 	email := "ornek@email.com"
 
-	// Satıcıyı eklemeyi dene
-	err := repo.AddMerchant(uuid.New().String(), email)
+	// Ödemeyi eklemeyi dene
+	err := repo.CreatePaymentTransaction(&models.PaymentTransaction{
+		MerchantOID: uuid.New().String(),
+		Provider:    "paytr",
+		FlatNo:      1,
+		Email:       email,
+		Amount:      money.New(12000),
+		Status:      models.PaymentCreated,
+	})
 
 	// Hata olup olmadığını kontrol et
 	assert.NoError(t, err)
 
-	// Satıcının veritabanına başarıyla eklendiğini doğrula
+	// Ödemenin veritabanına başarıyla eklendiğini doğrula
 	var count int64
-	result := db.Model(&models.Merchant{}).Where("email = ?", email).Count(&count)
+	result := db.Model(&models.PaymentTransaction{}).Where("email = ?", email).Count(&count)
+	assert.NoError(t, result.Error)
+	assert.Equal(t, int64(1), count, "Expected payment transaction to be added to the database")
+}
+
+func TestGetPaymentTransaction(t *testing.T) {
+	// Veritabanı bağlantısını kur
+	db := setupDb(models.PaymentTransaction{})
+	repo := NewRepo(db)
+
+	// Eklenecek ödemenin bilgileri
+	email := "ornek@email.com"
+	newTransaction := models.PaymentTransaction{
+		MerchantOID: uuid.NewString(),
+		Provider:    "paytr",
+		FlatNo:      1,
+		Email:       email,
+		Amount:      money.New(12000),
+		Status:      models.PaymentPending,
+	}
+	result := db.Create(&newTransaction)
 	assert.NoError(t, result.Error)
-	assert.Equal(t, int64(1), count, "Expected merchant to be added to the database")
+
+	transaction, err := repo.GetPaymentTransaction(newTransaction.MerchantOID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, email, transaction.Email)
+	assert.Equal(t, money.New(12000), transaction.Amount)
+
+	_, err = repo.GetPaymentTransaction("missing")
+	assert.IsType(t, dto.ThereIsNoPaymentTransaction{}, err)
 }
 
-func TestGetEmailFromMerchant(t *testing.T) {
-    // Veritabanı bağlantısını kur
-    db := setupDb(models.Merchant{})
-    repo := NewRepo(db)
+func TestSettleOnlinePaymentBooksPayment(t *testing.T) {
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{}, models.PaymentReceipt{}, models.ReceiptMail{}, models.PaymentTransaction{})
+	repo := NewRepo(db)
+
+	email := "ornek@email.com"
+	apartment := models.Apartment{
+		FlatNo: 1,
+		Mail:   email,
+	}
+	result := db.Create(&apartment)
+	assert.NoError(t, result.Error)
+	result = db.Create(&models.PaymentTransaction{MerchantOID: "merchant-oid", Provider: "paytr", FlatNo: 1, Email: email, Amount: money.New(8000), Status: models.PaymentPending})
+	assert.NoError(t, result.Error)
 
-    // Eklenecek satıcının bilgileri
-    email := "ornek@email.com"
-    newMerchant := models.Merchant{
-        MerchantID: uuid.NewString(),
-		Email: email,
-    }
-    result := db.Create(&newMerchant)
-    assert.NoError(t, result.Error)
+	err := repo.AddDues(apartment.FlatNo, "2026-09", money.New(4000))
+	assert.NoError(t, err)
+	err = repo.AddDues(apartment.FlatNo, "2026-10", money.New(4000))
+	assert.NoError(t, err)
 
-    merchantOID := newMerchant.MerchantID
+	_, err = repo.SettleOnlinePayment("merchant-oid", money.New(8000), time.Now())
+	assert.NoError(t, err)
 
-    retrievedEmail, err := repo.GetEmailFromMerchant(merchantOID)
+	var payment models.LedgerEntry
+	result = db.First(&payment, "flat_no = ? AND type = ?", apartment.FlatNo, models.LedgerPayment)
+	assert.NoError(t, result.Error)
+	assert.Equal(t, models.SourcePaytr, payment.Source)
+	assert.Equal(t, "merchant-oid", payment.Reference)
+	assert.Equal(t, money.New(-8000), payment.Amount)
 
-    assert.NoError(t, err)
-    assert.Equal(t, email, retrievedEmail)
+	duesCount, err := repo.GetDuesCount(apartment.FlatNo)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, duesCount)
 }
 
-func TestDeleteDuesByEmail(t *testing.T) {
-    db := setupDb(models.Apartment{})
-    repo := NewRepo(db)
-
-    email := "ornek@email.com"
-    apartment := models.Apartment{
-        Mail: email,
-        DuesCount: 1,
-    }
-    result := db.Create(&apartment)
-    assert.NoError(t, result.Error)
-
-    err := repo.DeleteDuesByEmail(email)
-    
-    assert.NoError(t, err)
-
-    var updatedApartment models.Apartment
-    db.Where("mail = ?", email).First(&updatedApartment)
-    assert.Equal(t, 0, updatedApartment.DuesCount)
+func TestPartialPayment(t *testing.T) {
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{}, models.PaymentReceipt{})
+	repo := NewRepo(db)
+
+	email := "ornek@email.com"
+	result := db.Create(&models.Apartment{FlatNo: 1, Mail: email})
+	assert.NoError(t, result.Error)
+
+	err := repo.AddDues(1, "2026-09", money.New(4000))
+	assert.NoError(t, err)
+	err = repo.AddDues(1, "2026-10", money.New(4000))
+	assert.NoError(t, err)
+
+	payment := models.PaymentReceipt{FlatNo: 1, Amount: money.New(5000), Method: models.MethodCash, PaidAt: time.Now(), CollectedBy: "manager", CreatedBy: "admin"}
+	err = repo.AddManualPayment(&payment)
+	assert.NoError(t, err)
+
+	groups, err := repo.GetOpenChargeGroups(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ChargeGroup{{Type: models.LedgerAccrual, Period: "2026-10", Amount: money.New(3000)}}, groups)
 }
 
+
+func TestPrepaymentCredit(t *testing.T) {
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{}, models.AccrualRun{}, models.PaymentReceipt{})
+	repo := NewRepo(db)
+
+	email := "ornek@email.com"
+	result := db.Create(&models.Apartment{FlatNo: 1, Mail: email})
+	assert.NoError(t, result.Error)
+
+	payment := models.PaymentReceipt{FlatNo: 1, Amount: money.New(10000), Method: models.MethodCash, PaidAt: time.Now(), CollectedBy: "manager", CreatedBy: "admin"}
+	err := repo.AddManualPayment(&payment)
+	assert.NoError(t, err)
+
+	summary, err := repo.GetDuesSummary(1)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(10000), summary.Credit)
+	assert.Equal(t, money.New(-10000), summary.Balance)
+
+	run := models.AccrualRun{Period: "2026-10", Amount: money.New(4000), DueDate: time.Date(2026, 10, 15, 0, 0, 0, 0, time.Local)}
+	err = repo.AddDuesForAll(run, map[int]money.Money{1: money.New(4000)})
+	assert.NoError(t, err)
+	err = repo.AddDues(1, "2026-11", money.New(8000))
+	assert.NoError(t, err)
+
+	summary, err = repo.GetDuesSummary(1)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(0), summary.Credit)
+	assert.Equal(t, money.New(2000), summary.Balance)
+	assert.Equal(t, 1, summary.OpenDues)
+
+	groups, err := repo.GetOpenChargeGroups(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ChargeGroup{{Type: models.LedgerAccrual, Period: "2026-11", Amount: money.New(2000)}}, groups)
+}
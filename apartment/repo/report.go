@@ -0,0 +1,83 @@
+package repo
+
+import (
+	"time"
+
+	"github.com/pragmataW/apartment_management/models"
+)
+
+const chargeTotalsQuery = `
+SELECT e.type,
+	SUM(e.amount) AS charged,
+	SUM(COALESCE((SELECT SUM(a.amount) FROM ledger_allocations a JOIN ledger_entries c ON c.id = a.credit_id
+		WHERE a.charge_id = e.id AND c.type = ?), 0)) AS paid,
+	SUM(e.amount - COALESCE((SELECT SUM(a.amount) FROM ledger_allocations a WHERE a.charge_id = e.id), 0)) AS outstanding
+FROM ledger_entries e
+WHERE e.amount > 0 AND e.type <> ? AND e.period >= ? AND e.period <= ?
+GROUP BY e.type
+ORDER BY e.type`
+
+const totalOutstandingQuery = `
+SELECT COALESCE(SUM(e.amount - COALESCE((SELECT SUM(a.amount) FROM ledger_allocations a WHERE a.charge_id = e.id), 0)), 0)
+FROM ledger_entries e
+WHERE e.amount > 0`
+
+// GetReportTotals gathers the figures of a financial report. Charges are
+// taken by billing period, from fromPeriod to toPeriod inclusive, while
+// credits and expenses are taken by date, from from up to but not including
+// to. Reversals count as negative credits rather than as charges.
+// TotalOutstanding is what all flats owe right now.
+func (r repo) GetReportTotals(fromPeriod string, toPeriod string, from time.Time, to time.Time) (models.ReportTotals, error) {
+	var totals models.ReportTotals
+
+	result := r.db.Raw(chargeTotalsQuery, models.LedgerPayment, models.LedgerReversal, fromPeriod, toPeriod).Scan(&totals.Charges)
+	if result.Error != nil {
+		return models.ReportTotals{}, result.Error
+	}
+
+	result = r.db.Model(&models.LedgerEntry{}).
+		Select("type, source, -SUM(amount) AS amount").
+		Where("(amount < 0 OR type = ?) AND created_at >= ? AND created_at < ?", models.LedgerReversal, from, to).
+		Group("type, source").
+		Order("type, source").
+		Scan(&totals.Credits)
+	if result.Error != nil {
+		return models.ReportTotals{}, result.Error
+	}
+
+	result = r.db.Table("expenses AS x").
+		Select("c.name AS category_name, COUNT(*) AS count, SUM(x.amount) AS amount").
+		Joins("JOIN expense_categories c ON c.id = x.category_id").
+		Where("x.date >= ? AND x.date < ?", from, to).
+		Group("c.name").
+		Order("amount DESC, c.name").
+		Scan(&totals.Expenses)
+	if result.Error != nil {
+		return models.ReportTotals{}, result.Error
+	}
+
+	result = r.db.Raw(totalOutstandingQuery).Scan(&totals.TotalOutstanding)
+	if result.Error != nil {
+		return models.ReportTotals{}, result.Error
+	}
+
+	return totals, nil
+}
+
+// GetDueCharges returns the open charges of every flat, of any type, that fell
+// due before the given time, oldest first within each flat.
+func (r repo) GetDueCharges(before time.Time) ([]models.OpenCharge, error) {
+	var charges []models.OpenCharge
+	result := r.db.Table("ledger_entries AS e").
+		Select("e.*, e.amount - COALESCE(SUM(a.amount), 0) AS remaining").
+		Joins("LEFT JOIN ledger_allocations a ON a.charge_id = e.id").
+		Where("e.amount > 0 AND e.due_date < ?", before).
+		Group("e.id").
+		Having("e.amount - COALESCE(SUM(a.amount), 0) > 0").
+		Order("e.flat_no, e.due_date, e.id").
+		Scan(&charges)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return charges, nil
+}
@@ -0,0 +1,82 @@
+package repo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetReportTotals(t *testing.T) {
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{}, models.AccrualRun{}, models.ExpenseCategory{}, models.Expense{}, models.PaymentReceipt{})
+	repo := NewRepo(db)
+
+	result := db.Create(&[]models.Apartment{{FlatNo: 1, Mail: "a@mail.com"}, {FlatNo: 2, Mail: "b@mail.com"}})
+	assert.NoError(t, result.Error)
+
+	run := models.AccrualRun{Period: time.Now().Format("2006-01"), Amount: money.New(100000), DueDate: time.Now()}
+	err := repo.AddDuesForAll(run, map[int]money.Money{1: money.New(100000), 2: money.New(100000)})
+	assert.NoError(t, err)
+	payment := models.PaymentReceipt{FlatNo: 1, Amount: money.New(100000), Method: models.MethodCash, PaidAt: time.Now(), CollectedBy: "manager", CreatedBy: "admin"}
+	err = repo.AddManualPayment(&payment)
+	assert.NoError(t, err)
+	err = repo.DeleteDues(2)
+	assert.NoError(t, err)
+
+	category := models.ExpenseCategory{Name: "cleaning"}
+	assert.NoError(t, repo.CreateExpenseCategory(&category))
+	expense := models.Expense{CategoryID: category.ID, Date: time.Now(), Amount: money.New(40000), CreatedBy: "admin"}
+	assert.NoError(t, repo.CreateExpense(&expense))
+
+	from := time.Now().AddDate(0, 0, -1)
+	to := time.Now().AddDate(0, 0, 1)
+	totals, err := repo.GetReportTotals(run.Period, run.Period, from, to)
+	assert.NoError(t, err)
+
+	assert.Len(t, totals.Charges, 1)
+	assert.Equal(t, models.LedgerAccrual, totals.Charges[0].Type)
+	assert.Equal(t, money.New(200000), totals.Charges[0].Charged)
+	assert.Equal(t, money.New(100000), totals.Charges[0].Paid)
+	assert.Equal(t, money.New(0), totals.Charges[0].Outstanding)
+
+	assert.Len(t, totals.Credits, 2)
+	assert.Equal(t, models.LedgerPayment, totals.Credits[0].Type)
+	assert.Equal(t, money.New(100000), totals.Credits[0].Amount)
+	assert.Equal(t, models.LedgerWaiver, totals.Credits[1].Type)
+
+	assert.Len(t, totals.Expenses, 1)
+	assert.Equal(t, "cleaning", totals.Expenses[0].CategoryName)
+	assert.Equal(t, money.New(40000), totals.Expenses[0].Amount)
+	assert.Equal(t, money.New(0), totals.TotalOutstanding)
+}
+
+func TestGetDueCharges(t *testing.T) {
+	db := setupDb(models.Apartment{}, models.LedgerEntry{}, models.LedgerAllocation{}, models.PaymentReceipt{})
+	repo := NewRepo(db)
+
+	result := db.Create(&models.Apartment{FlatNo: 1, Mail: "a@mail.com"})
+	assert.NoError(t, result.Error)
+
+	now := time.Now()
+	entries := []models.LedgerEntry{
+		{FlatNo: 1, Period: "2026-07", Type: models.LedgerAccrual, Amount: money.New(4000), Source: models.SourceScheduler, DueDate: now.AddDate(0, -3, 0)},
+		{FlatNo: 1, Period: "2026-09", Type: models.LedgerPenalty, Amount: money.New(500), Source: models.SourceScheduler, DueDate: now.AddDate(0, -1, 0)},
+		{FlatNo: 1, Period: "2026-12", Type: models.LedgerAssessment, Amount: money.New(30000), Source: models.SourceAdmin, DueDate: now.AddDate(0, 2, 0)},
+	}
+	for _, entry := range entries {
+		assert.NoError(t, repo.AddLedgerEntry(entry))
+	}
+	payment := models.PaymentReceipt{FlatNo: 1, Amount: money.New(1000), Method: models.MethodCash, PaidAt: now, CollectedBy: "manager", CreatedBy: "admin"}
+	err := repo.AddManualPayment(&payment)
+	assert.NoError(t, err)
+
+	charges, err := repo.GetDueCharges(now)
+	assert.NoError(t, err)
+	assert.Len(t, charges, 2)
+	assert.Equal(t, models.LedgerAccrual, charges[0].Type)
+	assert.Equal(t, money.New(3000), charges[0].Remaining)
+	assert.Equal(t, models.LedgerPenalty, charges[1].Type)
+	assert.Equal(t, money.New(500), charges[1].Remaining)
+}
@@ -0,0 +1,65 @@
+package repo
+
+import (
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"gorm.io/gorm"
+)
+
+func (r repo) GetSetting(key string, at time.Time) (models.Setting, error) {
+	var setting models.Setting
+	result := r.db.Where("key = ? AND effective_from <= ?", key, at).Order("effective_from DESC, id DESC").Take(&setting)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return models.Setting{}, dto.ThereIsNoSetting{Message: "there is no setting: " + key}
+		}
+		return models.Setting{}, result.Error
+	}
+	return setting, nil
+}
+
+func (r repo) GetPendingSetting(key string, at time.Time) (models.Setting, error) {
+	var setting models.Setting
+	result := r.db.Where("key = ? AND effective_from > ?", key, at).Order("effective_from DESC, id DESC").Take(&setting)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return models.Setting{}, dto.ThereIsNoSetting{Message: "there is no pending setting: " + key}
+		}
+		return models.Setting{}, result.Error
+	}
+	return setting, nil
+}
+
+// SaveSetting stores a new version of a setting. The previous value is taken
+// from the most recently saved version, pending or not, so the history reads
+// as a chain of changes.
+func (r repo) SaveSetting(setting models.Setting) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", setting.Key).Error; err != nil {
+			return err
+		}
+
+		var last models.Setting
+		result := tx.Where("key = ?", setting.Key).Order("id DESC").Limit(1).Find(&last)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected > 0 {
+			setting.PreviousValue = last.Value
+		}
+
+		return tx.Create(&setting).Error
+	})
+}
+
+func (r repo) GetSettingHistory(key string) ([]models.Setting, error) {
+	var history []models.Setting
+	result := r.db.Where("key = ?", key).Order("id DESC").Find(&history)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return history, nil
+}
@@ -0,0 +1,60 @@
+package repo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveSetting(t *testing.T) {
+	db := setupDb(models.Setting{})
+	repo := NewRepo(db)
+
+	now := time.Now()
+	err := repo.SaveSetting(models.Setting{Key: models.SettingDuesPrice, Value: "40.00", ChangedBy: "admin", EffectiveFrom: now.Add(-time.Hour)})
+	assert.NoError(t, err)
+	err = repo.SaveSetting(models.Setting{Key: models.SettingDuesPrice, Value: "50.00", ChangedBy: "admin", EffectiveFrom: now.Add(24 * time.Hour)})
+	assert.NoError(t, err)
+
+	current, err := repo.GetSetting(models.SettingDuesPrice, now)
+	assert.NoError(t, err)
+	assert.Equal(t, "40.00", current.Value)
+
+	pending, err := repo.GetPendingSetting(models.SettingDuesPrice, now)
+	assert.NoError(t, err)
+	assert.Equal(t, "50.00", pending.Value)
+	assert.Equal(t, "40.00", pending.PreviousValue)
+
+	later, err := repo.GetSetting(models.SettingDuesPrice, now.Add(48*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, "50.00", later.Value)
+}
+
+func TestGetSettingForError(t *testing.T) {
+	db := setupDb(models.Setting{})
+	repo := NewRepo(db)
+
+	_, err := repo.GetSetting(models.SettingPayDay, time.Now())
+	assert.Error(t, err)
+	assert.IsType(t, dto.ThereIsNoSetting{}, err)
+}
+
+func TestGetSettingHistory(t *testing.T) {
+	db := setupDb(models.Setting{})
+	repo := NewRepo(db)
+
+	for _, payDay := range []string{"15", "20", "10"} {
+		err := repo.SaveSetting(models.Setting{Key: models.SettingPayDay, Value: payDay, ChangedBy: "admin", EffectiveFrom: time.Now()})
+		assert.NoError(t, err)
+	}
+
+	history, err := repo.GetSettingHistory(models.SettingPayDay)
+	assert.NoError(t, err)
+	assert.Len(t, history, 3)
+	assert.Equal(t, "10", history[0].Value)
+	assert.Equal(t, "20", history[0].PreviousValue)
+	assert.Equal(t, "", history[2].PreviousValue)
+}
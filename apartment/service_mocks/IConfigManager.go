@@ -81,6 +81,24 @@ func (_m *IConfigManager) GetMailServer() string {
 	return r0
 }
 
+// GetPaymentPageUrl provides a mock function with given fields:
+func (_m *IConfigManager) GetPaymentPageUrl() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentPageUrl")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // NewIConfigManager creates a new instance of IConfigManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewIConfigManager(t interface {
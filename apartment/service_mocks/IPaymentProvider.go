@@ -0,0 +1,120 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	money "github.com/pragmataW/apartment_management/pkg/money"
+	paymentprovider "github.com/pragmataW/apartment_management/pkg/payment_provider"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IPaymentProvider is an autogenerated mock type for the IPaymentProvider type
+type IPaymentProvider struct {
+	mock.Mock
+}
+
+// GetToken provides a mock function with given fields: payment
+func (_m *IPaymentProvider) GetToken(payment paymentprovider.Payment) (string, error) {
+	ret := _m.Called(payment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetToken")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(paymentprovider.Payment) (string, error)); ok {
+		return rf(payment)
+	}
+	if rf, ok := ret.Get(0).(func(paymentprovider.Payment) string); ok {
+		r0 = rf(payment)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(paymentprovider.Payment) error); ok {
+		r1 = rf(payment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Name provides a mock function with given fields:
+func (_m *IPaymentProvider) Name() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Name")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Refund provides a mock function with given fields: orderID, amount
+func (_m *IPaymentProvider) Refund(orderID string, amount money.Money) error {
+	ret := _m.Called(orderID, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refund")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, money.Money) error); ok {
+		r0 = rf(orderID, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyCallback provides a mock function with given fields: form
+func (_m *IPaymentProvider) VerifyCallback(form map[string]string) (paymentprovider.Notice, error) {
+	ret := _m.Called(form)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyCallback")
+	}
+
+	var r0 paymentprovider.Notice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]string) (paymentprovider.Notice, error)); ok {
+		return rf(form)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]string) paymentprovider.Notice); ok {
+		r0 = rf(form)
+	} else {
+		r0 = ret.Get(0).(paymentprovider.Notice)
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]string) error); ok {
+		r1 = rf(form)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIPaymentProvider creates a new instance of IPaymentProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIPaymentProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IPaymentProvider {
+	mock := &IPaymentProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -4,7 +4,10 @@ package mocks
 
 import (
 	models "github.com/pragmataW/apartment_management/models"
+	money "github.com/pragmataW/apartment_management/pkg/money"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // IRepo is an autogenerated mock type for the IRepo type
@@ -12,6 +15,34 @@ type IRepo struct {
 	mock.Mock
 }
 
+// AcceptPaymentReview provides a mock function with given fields: merchantOID, reviewedBy, at
+func (_m *IRepo) AcceptPaymentReview(merchantOID string, reviewedBy string, at time.Time) (models.PaymentReceipt, error) {
+	ret := _m.Called(merchantOID, reviewedBy, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptPaymentReview")
+	}
+
+	var r0 models.PaymentReceipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, time.Time) (models.PaymentReceipt, error)); ok {
+		return rf(merchantOID, reviewedBy, at)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, time.Time) models.PaymentReceipt); ok {
+		r0 = rf(merchantOID, reviewedBy, at)
+	} else {
+		r0 = ret.Get(0).(models.PaymentReceipt)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, time.Time) error); ok {
+		r1 = rf(merchantOID, reviewedBy, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AddAnnouncement provides a mock function with given fields: announcement
 func (_m *IRepo) AddAnnouncement(announcement models.Announcement) error {
 	ret := _m.Called(announcement)
@@ -30,17 +61,17 @@ func (_m *IRepo) AddAnnouncement(announcement models.Announcement) error {
 	return r0
 }
 
-// AddDues provides a mock function with given fields: flatNo
-func (_m *IRepo) AddDues(flatNo int) error {
-	ret := _m.Called(flatNo)
+// AddDues provides a mock function with given fields: flatNo, period, amount
+func (_m *IRepo) AddDues(flatNo int, period string, amount money.Money) error {
+	ret := _m.Called(flatNo, period, amount)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddDues")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(flatNo)
+	if rf, ok := ret.Get(0).(func(int, string, money.Money) error); ok {
+		r0 = rf(flatNo, period, amount)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -48,17 +79,17 @@ func (_m *IRepo) AddDues(flatNo int) error {
 	return r0
 }
 
-// AddDuesForAll provides a mock function with given fields:
-func (_m *IRepo) AddDuesForAll() error {
-	ret := _m.Called()
+// AddDuesForAll provides a mock function with given fields: run, amounts
+func (_m *IRepo) AddDuesForAll(run models.AccrualRun, amounts map[int]money.Money) error {
+	ret := _m.Called(run, amounts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddDuesForAll")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func() error); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(models.AccrualRun, map[int]money.Money) error); ok {
+		r0 = rf(run, amounts)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -66,17 +97,17 @@ func (_m *IRepo) AddDuesForAll() error {
 	return r0
 }
 
-// AddMerchant provides a mock function with given fields: uuid, email
-func (_m *IRepo) AddMerchant(uuid string, email string) error {
-	ret := _m.Called(uuid, email)
+// AddLedgerEntry provides a mock function with given fields: entry
+func (_m *IRepo) AddLedgerEntry(entry models.LedgerEntry) error {
+	ret := _m.Called(entry)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AddMerchant")
+		panic("no return value specified for AddLedgerEntry")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(uuid, email)
+	if rf, ok := ret.Get(0).(func(models.LedgerEntry) error); ok {
+		r0 = rf(entry)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -84,17 +115,17 @@ func (_m *IRepo) AddMerchant(uuid string, email string) error {
 	return r0
 }
 
-// CreateFlat provides a mock function with given fields: flatNo
-func (_m *IRepo) CreateFlat(flatNo int) error {
-	ret := _m.Called(flatNo)
+// AddManualPayment provides a mock function with given fields: receipt
+func (_m *IRepo) AddManualPayment(receipt *models.PaymentReceipt) error {
+	ret := _m.Called(receipt)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateFlat")
+		panic("no return value specified for AddManualPayment")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(flatNo)
+	if rf, ok := ret.Get(0).(func(*models.PaymentReceipt) error); ok {
+		r0 = rf(receipt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -102,17 +133,17 @@ func (_m *IRepo) CreateFlat(flatNo int) error {
 	return r0
 }
 
-// DeleteDues provides a mock function with given fields: flatNo
-func (_m *IRepo) DeleteDues(flatNo int) error {
-	ret := _m.Called(flatNo)
+// AddMeterReadings provides a mock function with given fields: readings
+func (_m *IRepo) AddMeterReadings(readings []models.MeterReading) error {
+	ret := _m.Called(readings)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteDues")
+		panic("no return value specified for AddMeterReadings")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(flatNo)
+	if rf, ok := ret.Get(0).(func([]models.MeterReading) error); ok {
+		r0 = rf(readings)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -120,65 +151,83 @@ func (_m *IRepo) DeleteDues(flatNo int) error {
 	return r0
 }
 
-// DeleteDuesByEmail provides a mock function with given fields: email
-func (_m *IRepo) DeleteDuesByEmail(email string) error {
-	ret := _m.Called(email)
+// AddPenalties provides a mock function with given fields: penalties
+func (_m *IRepo) AddPenalties(penalties []models.LedgerEntry) (int, error) {
+	ret := _m.Called(penalties)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteDuesByEmail")
+		panic("no return value specified for AddPenalties")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(email)
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]models.LedgerEntry) (int, error)); ok {
+		return rf(penalties)
+	}
+	if rf, ok := ret.Get(0).(func([]models.LedgerEntry) int); ok {
+		r0 = rf(penalties)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func([]models.LedgerEntry) error); ok {
+		r1 = rf(penalties)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// DeleteFlat provides a mock function with given fields: flatNo
-func (_m *IRepo) DeleteFlat(flatNo int) error {
-	ret := _m.Called(flatNo)
+// CancelManualPayment provides a mock function with given fields: receiptNo, cancelledBy, reason
+func (_m *IRepo) CancelManualPayment(receiptNo int, cancelledBy string, reason string) (models.PaymentReceipt, error) {
+	ret := _m.Called(receiptNo, cancelledBy, reason)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteFlat")
+		panic("no return value specified for CancelManualPayment")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(flatNo)
+	var r0 models.PaymentReceipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string, string) (models.PaymentReceipt, error)); ok {
+		return rf(receiptNo, cancelledBy, reason)
+	}
+	if rf, ok := ret.Get(0).(func(int, string, string) models.PaymentReceipt); ok {
+		r0 = rf(receiptNo, cancelledBy, reason)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(models.PaymentReceipt)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(int, string, string) error); ok {
+		r1 = rf(receiptNo, cancelledBy, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// GetAllAnnouncements provides a mock function with given fields:
-func (_m *IRepo) GetAllAnnouncements() ([]models.Announcement, error) {
-	ret := _m.Called()
+// ChargeDueInstallments provides a mock function with given fields: at
+func (_m *IRepo) ChargeDueInstallments(at time.Time) (int, error) {
+	ret := _m.Called(at)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllAnnouncements")
+		panic("no return value specified for ChargeDueInstallments")
 	}
 
-	var r0 []models.Announcement
+	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]models.Announcement, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(time.Time) (int, error)); ok {
+		return rf(at)
 	}
-	if rf, ok := ret.Get(0).(func() []models.Announcement); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(time.Time) int); ok {
+		r0 = rf(at)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Announcement)
-		}
+		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(at)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -186,29 +235,27 @@ func (_m *IRepo) GetAllAnnouncements() ([]models.Announcement, error) {
 	return r0, r1
 }
 
-// GetAllInfoAboutAllFlats provides a mock function with given fields:
-func (_m *IRepo) GetAllInfoAboutAllFlats() ([]models.Apartment, error) {
-	ret := _m.Called()
+// ClaimReceiptMail provides a mock function with given fields: id, at, until
+func (_m *IRepo) ClaimReceiptMail(id int, at time.Time, until time.Time) (models.ReceiptMail, error) {
+	ret := _m.Called(id, at, until)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllInfoAboutAllFlats")
+		panic("no return value specified for ClaimReceiptMail")
 	}
 
-	var r0 []models.Apartment
+	var r0 models.ReceiptMail
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]models.Apartment, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(int, time.Time, time.Time) (models.ReceiptMail, error)); ok {
+		return rf(id, at, until)
 	}
-	if rf, ok := ret.Get(0).(func() []models.Apartment); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(int, time.Time, time.Time) models.ReceiptMail); ok {
+		r0 = rf(id, at, until)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Apartment)
-		}
+		r0 = ret.Get(0).(models.ReceiptMail)
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(int, time.Time, time.Time) error); ok {
+		r1 = rf(id, at, until)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -216,27 +263,27 @@ func (_m *IRepo) GetAllInfoAboutAllFlats() ([]models.Apartment, error) {
 	return r0, r1
 }
 
-// GetAllInfoAboutFlat provides a mock function with given fields: flatNo
-func (_m *IRepo) GetAllInfoAboutFlat(flatNo int) (models.Apartment, error) {
-	ret := _m.Called(flatNo)
+// ClaimReceiptMailForRetry provides a mock function with given fields: id, at, until
+func (_m *IRepo) ClaimReceiptMailForRetry(id int, at time.Time, until time.Time) (models.ReceiptMail, error) {
+	ret := _m.Called(id, at, until)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllInfoAboutFlat")
+		panic("no return value specified for ClaimReceiptMailForRetry")
 	}
 
-	var r0 models.Apartment
+	var r0 models.ReceiptMail
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) (models.Apartment, error)); ok {
-		return rf(flatNo)
+	if rf, ok := ret.Get(0).(func(int, time.Time, time.Time) (models.ReceiptMail, error)); ok {
+		return rf(id, at, until)
 	}
-	if rf, ok := ret.Get(0).(func(int) models.Apartment); ok {
-		r0 = rf(flatNo)
+	if rf, ok := ret.Get(0).(func(int, time.Time, time.Time) models.ReceiptMail); ok {
+		r0 = rf(id, at, until)
 	} else {
-		r0 = ret.Get(0).(models.Apartment)
+		r0 = ret.Get(0).(models.ReceiptMail)
 	}
 
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(flatNo)
+	if rf, ok := ret.Get(1).(func(int, time.Time, time.Time) error); ok {
+		r1 = rf(id, at, until)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -244,27 +291,27 @@ func (_m *IRepo) GetAllInfoAboutFlat(flatNo int) (models.Apartment, error) {
 	return r0, r1
 }
 
-// GetDuesCount provides a mock function with given fields: flatNo
-func (_m *IRepo) GetDuesCount(flatNo int) (int, error) {
-	ret := _m.Called(flatNo)
+// CompletePaymentRefund provides a mock function with given fields: id
+func (_m *IRepo) CompletePaymentRefund(id int) (models.PaymentTransaction, error) {
+	ret := _m.Called(id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetDuesCount")
+		panic("no return value specified for CompletePaymentRefund")
 	}
 
-	var r0 int
+	var r0 models.PaymentTransaction
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) (int, error)); ok {
-		return rf(flatNo)
+	if rf, ok := ret.Get(0).(func(int) (models.PaymentTransaction, error)); ok {
+		return rf(id)
 	}
-	if rf, ok := ret.Get(0).(func(int) int); ok {
-		r0 = rf(flatNo)
+	if rf, ok := ret.Get(0).(func(int) models.PaymentTransaction); ok {
+		r0 = rf(id)
 	} else {
-		r0 = ret.Get(0).(int)
+		r0 = ret.Get(0).(models.PaymentTransaction)
 	}
 
 	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(flatNo)
+		r1 = rf(id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -272,80 +319,2001 @@ func (_m *IRepo) GetDuesCount(flatNo int) (int, error) {
 	return r0, r1
 }
 
-// GetEmailFromMerchant provides a mock function with given fields: merchantOID
-func (_m *IRepo) GetEmailFromMerchant(merchantOID string) (string, error) {
-	ret := _m.Called(merchantOID)
+// CreateAssessment provides a mock function with given fields: assessment, installments
+func (_m *IRepo) CreateAssessment(assessment *models.Assessment, installments []models.AssessmentInstallment) error {
+	ret := _m.Called(assessment, installments)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetEmailFromMerchant")
+		panic("no return value specified for CreateAssessment")
 	}
 
-	var r0 string
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(merchantOID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Assessment, []models.AssessmentInstallment) error); ok {
+		r0 = rf(assessment, installments)
+	} else {
+		r0 = ret.Error(0)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(merchantOID)
+
+	return r0
+}
+
+// CreateExpense provides a mock function with given fields: expense
+func (_m *IRepo) CreateExpense(expense *models.Expense) error {
+	ret := _m.Called(expense)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateExpense")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Expense) error); ok {
+		r0 = rf(expense)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(merchantOID)
+	return r0
+}
+
+// CreateExpenseCategory provides a mock function with given fields: category
+func (_m *IRepo) CreateExpenseCategory(category *models.ExpenseCategory) error {
+	ret := _m.Called(category)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateExpenseCategory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.ExpenseCategory) error); ok {
+		r0 = rf(category)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// GetPasswordAndFlatNoByEmail provides a mock function with given fields: email
-func (_m *IRepo) GetPasswordAndFlatNoByEmail(email string) (string, int, error) {
-	ret := _m.Called(email)
+// CreateFlat provides a mock function with given fields: flatNo
+func (_m *IRepo) CreateFlat(flatNo int) error {
+	ret := _m.Called(flatNo)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPasswordAndFlatNoByEmail")
+		panic("no return value specified for CreateFlat")
 	}
 
-	var r0 string
-	var r1 int
-	var r2 error
-	if rf, ok := ret.Get(0).(func(string) (string, int, error)); ok {
-		return rf(email)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(flatNo)
+	} else {
+		r0 = ret.Error(0)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(email)
+
+	return r0
+}
+
+// CreateMeter provides a mock function with given fields: meter
+func (_m *IRepo) CreateMeter(meter *models.Meter) error {
+	ret := _m.Called(meter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMeter")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Meter) error); ok {
+		r0 = rf(meter)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) int); ok {
-		r1 = rf(email)
+	return r0
+}
+
+// CreatePaymentTransaction provides a mock function with given fields: transaction
+func (_m *IRepo) CreatePaymentTransaction(transaction *models.PaymentTransaction) error {
+	ret := _m.Called(transaction)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePaymentTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.PaymentTransaction) error); ok {
+		r0 = rf(transaction)
 	} else {
-		r1 = ret.Get(1).(int)
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(2).(func(string) error); ok {
-		r2 = rf(email)
+	return r0
+}
+
+// CreateReferenceRule provides a mock function with given fields: rule
+func (_m *IRepo) CreateReferenceRule(rule *models.ReferenceRule) error {
+	ret := _m.Called(rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReferenceRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.ReferenceRule) error); ok {
+		r0 = rf(rule)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1, r2
+	return r0
 }
 
-// UpdateFlatOwner provides a mock function with given fields: apartment
-func (_m *IRepo) UpdateFlatOwner(apartment models.Apartment) error {
-	ret := _m.Called(apartment)
+// CreateUtilityBill provides a mock function with given fields: bill, lines, charges
+func (_m *IRepo) CreateUtilityBill(bill *models.UtilityBill, lines []models.UtilityBillLine, charges []models.LedgerEntry) error {
+	ret := _m.Called(bill, lines, charges)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateFlatOwner")
+		panic("no return value specified for CreateUtilityBill")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(models.Apartment) error); ok {
-		r0 = rf(apartment)
+	if rf, ok := ret.Get(0).(func(*models.UtilityBill, []models.UtilityBillLine, []models.LedgerEntry) error); ok {
+		r0 = rf(bill, lines, charges)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateVendor provides a mock function with given fields: vendor
+func (_m *IRepo) CreateVendor(vendor *models.Vendor) error {
+	ret := _m.Called(vendor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateVendor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Vendor) error); ok {
+		r0 = rf(vendor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteDues provides a mock function with given fields: flatNo
+func (_m *IRepo) DeleteDues(flatNo int) error {
+	ret := _m.Called(flatNo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDues")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(flatNo)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteExpense provides a mock function with given fields: id
+func (_m *IRepo) DeleteExpense(id int) (models.Expense, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpense")
+	}
+
+	var r0 models.Expense
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (models.Expense, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) models.Expense); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(models.Expense)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteExpenseCategory provides a mock function with given fields: id
+func (_m *IRepo) DeleteExpenseCategory(id int) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpenseCategory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteFlat provides a mock function with given fields: flatNo
+func (_m *IRepo) DeleteFlat(flatNo int) error {
+	ret := _m.Called(flatNo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFlat")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(flatNo)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteMeter provides a mock function with given fields: id
+func (_m *IRepo) DeleteMeter(id int) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMeter")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteReferenceRule provides a mock function with given fields: id
+func (_m *IRepo) DeleteReferenceRule(id int) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteReferenceRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteVendor provides a mock function with given fields: id
+func (_m *IRepo) DeleteVendor(id int) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteVendor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExpirePaymentTransactions provides a mock function with given fields: before
+func (_m *IRepo) ExpirePaymentTransactions(before time.Time) (int, error) {
+	ret := _m.Called(before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpirePaymentTransactions")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (int, error)); ok {
+		return rf(before)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) int); ok {
+		r0 = rf(before)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FailPaymentRefund provides a mock function with given fields: id, reason
+func (_m *IRepo) FailPaymentRefund(id int, reason string) error {
+	ret := _m.Called(id, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailPaymentRefund")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, string) error); ok {
+		r0 = rf(id, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAccrualRuns provides a mock function with given fields:
+func (_m *IRepo) GetAccrualRuns() ([]models.AccrualRun, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccrualRuns")
+	}
+
+	var r0 []models.AccrualRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]models.AccrualRun, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []models.AccrualRun); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AccrualRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllAnnouncements provides a mock function with given fields:
+func (_m *IRepo) GetAllAnnouncements() ([]models.Announcement, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllAnnouncements")
+	}
+
+	var r0 []models.Announcement
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]models.Announcement, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []models.Announcement); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Announcement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllInfoAboutAllFlats provides a mock function with given fields:
+func (_m *IRepo) GetAllInfoAboutAllFlats() ([]models.Apartment, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllInfoAboutAllFlats")
+	}
+
+	var r0 []models.Apartment
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]models.Apartment, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []models.Apartment); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Apartment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllInfoAboutFlat provides a mock function with given fields: flatNo
+func (_m *IRepo) GetAllInfoAboutFlat(flatNo int) (models.Apartment, error) {
+	ret := _m.Called(flatNo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllInfoAboutFlat")
+	}
+
+	var r0 models.Apartment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (models.Apartment, error)); ok {
+		return rf(flatNo)
+	}
+	if rf, ok := ret.Get(0).(func(int) models.Apartment); ok {
+		r0 = rf(flatNo)
+	} else {
+		r0 = ret.Get(0).(models.Apartment)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(flatNo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAssessmentCharges provides a mock function with given fields: assessmentID
+func (_m *IRepo) GetAssessmentCharges(assessmentID int) ([]models.OpenCharge, error) {
+	ret := _m.Called(assessmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAssessmentCharges")
+	}
+
+	var r0 []models.OpenCharge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]models.OpenCharge, error)); ok {
+		return rf(assessmentID)
+	}
+	if rf, ok := ret.Get(0).(func(int) []models.OpenCharge); ok {
+		r0 = rf(assessmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.OpenCharge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(assessmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAssessments provides a mock function with given fields:
+func (_m *IRepo) GetAssessments() ([]models.AssessmentStatus, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAssessments")
+	}
+
+	var r0 []models.AssessmentStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]models.AssessmentStatus, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []models.AssessmentStatus); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AssessmentStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBankImports provides a mock function with given fields:
+func (_m *IRepo) GetBankImports() ([]models.BankImport, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBankImports")
+	}
+
+	var r0 []models.BankImport
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]models.BankImport, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []models.BankImport); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.BankImport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBankTransactions provides a mock function with given fields: status
+func (_m *IRepo) GetBankTransactions(status string) ([]models.BankTransaction, error) {
+	ret := _m.Called(status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBankTransactions")
+	}
+
+	var r0 []models.BankTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]models.BankTransaction, error)); ok {
+		return rf(status)
+	}
+	if rf, ok := ret.Get(0).(func(string) []models.BankTransaction); ok {
+		r0 = rf(status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.BankTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDueCharges provides a mock function with given fields: before
+func (_m *IRepo) GetDueCharges(before time.Time) ([]models.OpenCharge, error) {
+	ret := _m.Called(before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDueCharges")
+	}
+
+	var r0 []models.OpenCharge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) ([]models.OpenCharge, error)); ok {
+		return rf(before)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) []models.OpenCharge); ok {
+		r0 = rf(before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.OpenCharge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDueReceiptMails provides a mock function with given fields: at
+func (_m *IRepo) GetDueReceiptMails(at time.Time) ([]models.ReceiptMail, error) {
+	ret := _m.Called(at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDueReceiptMails")
+	}
+
+	var r0 []models.ReceiptMail
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) ([]models.ReceiptMail, error)); ok {
+		return rf(at)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) []models.ReceiptMail); ok {
+		r0 = rf(at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ReceiptMail)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDuesCount provides a mock function with given fields: flatNo
+func (_m *IRepo) GetDuesCount(flatNo int) (int, error) {
+	ret := _m.Called(flatNo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDuesCount")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (int, error)); ok {
+		return rf(flatNo)
+	}
+	if rf, ok := ret.Get(0).(func(int) int); ok {
+		r0 = rf(flatNo)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(flatNo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDuesReminders provides a mock function with given fields: flatNo
+func (_m *IRepo) GetDuesReminders(flatNo int) ([]models.DuesReminder, error) {
+	ret := _m.Called(flatNo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDuesReminders")
+	}
+
+	var r0 []models.DuesReminder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]models.DuesReminder, error)); ok {
+		return rf(flatNo)
+	}
+	if rf, ok := ret.Get(0).(func(int) []models.DuesReminder); ok {
+		r0 = rf(flatNo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DuesReminder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(flatNo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDuesSummaries provides a mock function with given fields:
+func (_m *IRepo) GetDuesSummaries() ([]models.DuesSummary, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDuesSummaries")
+	}
+
+	var r0 []models.DuesSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]models.DuesSummary, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []models.DuesSummary); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DuesSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDuesSummary provides a mock function with given fields: flatNo
+func (_m *IRepo) GetDuesSummary(flatNo int) (models.DuesSummary, error) {
+	ret := _m.Called(flatNo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDuesSummary")
+	}
+
+	var r0 models.DuesSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (models.DuesSummary, error)); ok {
+		return rf(flatNo)
+	}
+	if rf, ok := ret.Get(0).(func(int) models.DuesSummary); ok {
+		r0 = rf(flatNo)
+	} else {
+		r0 = ret.Get(0).(models.DuesSummary)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(flatNo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExpense provides a mock function with given fields: id
+func (_m *IRepo) GetExpense(id int) (models.ExpenseRecord, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExpense")
+	}
+
+	var r0 models.ExpenseRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (models.ExpenseRecord, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) models.ExpenseRecord); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(models.ExpenseRecord)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExpenseCategories provides a mock function with given fields:
+func (_m *IRepo) GetExpenseCategories() ([]models.ExpenseCategory, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExpenseCategories")
+	}
+
+	var r0 []models.ExpenseCategory
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]models.ExpenseCategory, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []models.ExpenseCategory); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ExpenseCategory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExpenses provides a mock function with given fields: filter
+func (_m *IRepo) GetExpenses(filter models.ExpenseFilter) ([]models.ExpenseRecord, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExpenses")
+	}
+
+	var r0 []models.ExpenseRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.ExpenseFilter) ([]models.ExpenseRecord, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(models.ExpenseFilter) []models.ExpenseRecord); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ExpenseRecord)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(models.ExpenseFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFlatByEmail provides a mock function with given fields: email
+func (_m *IRepo) GetFlatByEmail(email string) (models.Apartment, error) {
+	ret := _m.Called(email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFlatByEmail")
+	}
+
+	var r0 models.Apartment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (models.Apartment, error)); ok {
+		return rf(email)
+	}
+	if rf, ok := ret.Get(0).(func(string) models.Apartment); ok {
+		r0 = rf(email)
+	} else {
+		r0 = ret.Get(0).(models.Apartment)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLastAccrualRun provides a mock function with given fields:
+func (_m *IRepo) GetLastAccrualRun() (models.AccrualRun, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastAccrualRun")
+	}
+
+	var r0 models.AccrualRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (models.AccrualRun, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() models.AccrualRun); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(models.AccrualRun)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLastReminderTimes provides a mock function with given fields:
+func (_m *IRepo) GetLastReminderTimes() (map[int]time.Time, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastReminderTimes")
+	}
+
+	var r0 map[int]time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (map[int]time.Time, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() map[int]time.Time); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]time.Time)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestReadings provides a mock function with given fields: meterType, on
+func (_m *IRepo) GetLatestReadings(meterType string, on time.Time) ([]models.MeterReading, error) {
+	ret := _m.Called(meterType, on)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestReadings")
+	}
+
+	var r0 []models.MeterReading
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) ([]models.MeterReading, error)); ok {
+		return rf(meterType, on)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time) []models.MeterReading); ok {
+		r0 = rf(meterType, on)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.MeterReading)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
+		r1 = rf(meterType, on)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLedgerEntries provides a mock function with given fields: flatNo
+func (_m *IRepo) GetLedgerEntries(flatNo int) ([]models.LedgerEntry, error) {
+	ret := _m.Called(flatNo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLedgerEntries")
+	}
+
+	var r0 []models.LedgerEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]models.LedgerEntry, error)); ok {
+		return rf(flatNo)
+	}
+	if rf, ok := ret.Get(0).(func(int) []models.LedgerEntry); ok {
+		r0 = rf(flatNo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.LedgerEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(flatNo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMeter provides a mock function with given fields: id
+func (_m *IRepo) GetMeter(id int) (models.Meter, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMeter")
+	}
+
+	var r0 models.Meter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (models.Meter, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) models.Meter); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(models.Meter)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMeterReadings provides a mock function with given fields: meterID
+func (_m *IRepo) GetMeterReadings(meterID int) ([]models.MeterReading, error) {
+	ret := _m.Called(meterID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMeterReadings")
+	}
+
+	var r0 []models.MeterReading
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]models.MeterReading, error)); ok {
+		return rf(meterID)
+	}
+	if rf, ok := ret.Get(0).(func(int) []models.MeterReading); ok {
+		r0 = rf(meterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.MeterReading)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(meterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMeters provides a mock function with given fields: flatNo
+func (_m *IRepo) GetMeters(flatNo int) ([]models.Meter, error) {
+	ret := _m.Called(flatNo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMeters")
+	}
+
+	var r0 []models.Meter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]models.Meter, error)); ok {
+		return rf(flatNo)
+	}
+	if rf, ok := ret.Get(0).(func(int) []models.Meter); ok {
+		r0 = rf(flatNo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Meter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(flatNo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOpenChargeGroups provides a mock function with given fields: flatNo
+func (_m *IRepo) GetOpenChargeGroups(flatNo int) ([]models.ChargeGroup, error) {
+	ret := _m.Called(flatNo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenChargeGroups")
+	}
+
+	var r0 []models.ChargeGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]models.ChargeGroup, error)); ok {
+		return rf(flatNo)
+	}
+	if rf, ok := ret.Get(0).(func(int) []models.ChargeGroup); ok {
+		r0 = rf(flatNo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ChargeGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(flatNo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOpenCharges provides a mock function with given fields: flatNo
+func (_m *IRepo) GetOpenCharges(flatNo int) ([]models.OpenCharge, error) {
+	ret := _m.Called(flatNo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenCharges")
+	}
+
+	var r0 []models.OpenCharge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]models.OpenCharge, error)); ok {
+		return rf(flatNo)
+	}
+	if rf, ok := ret.Get(0).(func(int) []models.OpenCharge); ok {
+		r0 = rf(flatNo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.OpenCharge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(flatNo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOverdueCharges provides a mock function with given fields: before
+func (_m *IRepo) GetOverdueCharges(before time.Time) ([]models.OpenCharge, error) {
+	ret := _m.Called(before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOverdueCharges")
+	}
+
+	var r0 []models.OpenCharge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) ([]models.OpenCharge, error)); ok {
+		return rf(before)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) []models.OpenCharge); ok {
+		r0 = rf(before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.OpenCharge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPasswordAndFlatNoByEmail provides a mock function with given fields: email
+func (_m *IRepo) GetPasswordAndFlatNoByEmail(email string) (string, int, error) {
+	ret := _m.Called(email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPasswordAndFlatNoByEmail")
+	}
+
+	var r0 string
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string) (string, int, error)); ok {
+		return rf(email)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(email)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) int); ok {
+		r1 = rf(email)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(email)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetPaymentPeriods provides a mock function with given fields: entryID
+func (_m *IRepo) GetPaymentPeriods(entryID int) ([]string, error) {
+	ret := _m.Called(entryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentPeriods")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]string, error)); ok {
+		return rf(entryID)
+	}
+	if rf, ok := ret.Get(0).(func(int) []string); ok {
+		r0 = rf(entryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(entryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaymentReceipt provides a mock function with given fields: receiptNo
+func (_m *IRepo) GetPaymentReceipt(receiptNo int) (models.PaymentReceipt, error) {
+	ret := _m.Called(receiptNo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentReceipt")
+	}
+
+	var r0 models.PaymentReceipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (models.PaymentReceipt, error)); ok {
+		return rf(receiptNo)
+	}
+	if rf, ok := ret.Get(0).(func(int) models.PaymentReceipt); ok {
+		r0 = rf(receiptNo)
+	} else {
+		r0 = ret.Get(0).(models.PaymentReceipt)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(receiptNo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaymentReceipts provides a mock function with given fields: flatNo
+func (_m *IRepo) GetPaymentReceipts(flatNo int) ([]models.PaymentReceipt, error) {
+	ret := _m.Called(flatNo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentReceipts")
+	}
+
+	var r0 []models.PaymentReceipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]models.PaymentReceipt, error)); ok {
+		return rf(flatNo)
+	}
+	if rf, ok := ret.Get(0).(func(int) []models.PaymentReceipt); ok {
+		r0 = rf(flatNo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PaymentReceipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(flatNo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaymentRefunds provides a mock function with given fields: merchantOID
+func (_m *IRepo) GetPaymentRefunds(merchantOID string) ([]models.PaymentRefund, error) {
+	ret := _m.Called(merchantOID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentRefunds")
+	}
+
+	var r0 []models.PaymentRefund
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]models.PaymentRefund, error)); ok {
+		return rf(merchantOID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []models.PaymentRefund); ok {
+		r0 = rf(merchantOID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PaymentRefund)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(merchantOID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaymentTransaction provides a mock function with given fields: merchantOID
+func (_m *IRepo) GetPaymentTransaction(merchantOID string) (models.PaymentTransaction, error) {
+	ret := _m.Called(merchantOID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentTransaction")
+	}
+
+	var r0 models.PaymentTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (models.PaymentTransaction, error)); ok {
+		return rf(merchantOID)
+	}
+	if rf, ok := ret.Get(0).(func(string) models.PaymentTransaction); ok {
+		r0 = rf(merchantOID)
+	} else {
+		r0 = ret.Get(0).(models.PaymentTransaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(merchantOID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaymentTransactions provides a mock function with given fields: filter
+func (_m *IRepo) GetPaymentTransactions(filter models.PaymentTransactionFilter) ([]models.PaymentTransaction, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentTransactions")
+	}
+
+	var r0 []models.PaymentTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.PaymentTransactionFilter) ([]models.PaymentTransaction, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(models.PaymentTransactionFilter) []models.PaymentTransaction); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PaymentTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(models.PaymentTransactionFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPayments provides a mock function with given fields: flatNo
+func (_m *IRepo) GetPayments(flatNo int) ([]models.LedgerEntry, error) {
+	ret := _m.Called(flatNo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPayments")
+	}
+
+	var r0 []models.LedgerEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]models.LedgerEntry, error)); ok {
+		return rf(flatNo)
+	}
+	if rf, ok := ret.Get(0).(func(int) []models.LedgerEntry); ok {
+		r0 = rf(flatNo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.LedgerEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(flatNo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPendingSetting provides a mock function with given fields: key, at
+func (_m *IRepo) GetPendingSetting(key string, at time.Time) (models.Setting, error) {
+	ret := _m.Called(key, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingSetting")
+	}
+
+	var r0 models.Setting
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) (models.Setting, error)); ok {
+		return rf(key, at)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time) models.Setting); ok {
+		r0 = rf(key, at)
+	} else {
+		r0 = ret.Get(0).(models.Setting)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
+		r1 = rf(key, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReceiptMailByReceipt provides a mock function with given fields: receiptNo
+func (_m *IRepo) GetReceiptMailByReceipt(receiptNo int) (models.ReceiptMail, error) {
+	ret := _m.Called(receiptNo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReceiptMailByReceipt")
+	}
+
+	var r0 models.ReceiptMail
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (models.ReceiptMail, error)); ok {
+		return rf(receiptNo)
+	}
+	if rf, ok := ret.Get(0).(func(int) models.ReceiptMail); ok {
+		r0 = rf(receiptNo)
+	} else {
+		r0 = ret.Get(0).(models.ReceiptMail)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(receiptNo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReceiptMails provides a mock function with given fields: status
+func (_m *IRepo) GetReceiptMails(status string) ([]models.ReceiptMail, error) {
+	ret := _m.Called(status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReceiptMails")
+	}
+
+	var r0 []models.ReceiptMail
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]models.ReceiptMail, error)); ok {
+		return rf(status)
+	}
+	if rf, ok := ret.Get(0).(func(string) []models.ReceiptMail); ok {
+		r0 = rf(status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ReceiptMail)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReferenceRules provides a mock function with given fields:
+func (_m *IRepo) GetReferenceRules() ([]models.ReferenceRule, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReferenceRules")
+	}
+
+	var r0 []models.ReferenceRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]models.ReferenceRule, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []models.ReferenceRule); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ReferenceRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReportTotals provides a mock function with given fields: fromPeriod, toPeriod, from, to
+func (_m *IRepo) GetReportTotals(fromPeriod string, toPeriod string, from time.Time, to time.Time) (models.ReportTotals, error) {
+	ret := _m.Called(fromPeriod, toPeriod, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReportTotals")
+	}
+
+	var r0 models.ReportTotals
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, time.Time, time.Time) (models.ReportTotals, error)); ok {
+		return rf(fromPeriod, toPeriod, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, time.Time, time.Time) models.ReportTotals); ok {
+		r0 = rf(fromPeriod, toPeriod, from, to)
+	} else {
+		r0 = ret.Get(0).(models.ReportTotals)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, time.Time, time.Time) error); ok {
+		r1 = rf(fromPeriod, toPeriod, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSetting provides a mock function with given fields: key, at
+func (_m *IRepo) GetSetting(key string, at time.Time) (models.Setting, error) {
+	ret := _m.Called(key, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSetting")
+	}
+
+	var r0 models.Setting
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) (models.Setting, error)); ok {
+		return rf(key, at)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time) models.Setting); ok {
+		r0 = rf(key, at)
+	} else {
+		r0 = ret.Get(0).(models.Setting)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
+		r1 = rf(key, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSettingHistory provides a mock function with given fields: key
+func (_m *IRepo) GetSettingHistory(key string) ([]models.Setting, error) {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSettingHistory")
+	}
+
+	var r0 []models.Setting
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]models.Setting, error)); ok {
+		return rf(key)
+	}
+	if rf, ok := ret.Get(0).(func(string) []models.Setting); ok {
+		r0 = rf(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Setting)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUtilityBill provides a mock function with given fields: id
+func (_m *IRepo) GetUtilityBill(id int) (models.UtilityBill, []models.UtilityBillLine, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUtilityBill")
+	}
+
+	var r0 models.UtilityBill
+	var r1 []models.UtilityBillLine
+	var r2 error
+	if rf, ok := ret.Get(0).(func(int) (models.UtilityBill, []models.UtilityBillLine, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) models.UtilityBill); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(models.UtilityBill)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) []models.UtilityBillLine); ok {
+		r1 = rf(id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]models.UtilityBillLine)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(int) error); ok {
+		r2 = rf(id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetUtilityBills provides a mock function with given fields:
+func (_m *IRepo) GetUtilityBills() ([]models.UtilityBill, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUtilityBills")
+	}
+
+	var r0 []models.UtilityBill
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]models.UtilityBill, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []models.UtilityBill); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.UtilityBill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetVendors provides a mock function with given fields:
+func (_m *IRepo) GetVendors() ([]models.Vendor, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVendors")
+	}
+
+	var r0 []models.Vendor
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]models.Vendor, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []models.Vendor); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Vendor)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IgnoreBankTransaction provides a mock function with given fields: id, resolvedBy
+func (_m *IRepo) IgnoreBankTransaction(id int, resolvedBy string) (models.BankTransaction, error) {
+	ret := _m.Called(id, resolvedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IgnoreBankTransaction")
+	}
+
+	var r0 models.BankTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string) (models.BankTransaction, error)); ok {
+		return rf(id, resolvedBy)
+	}
+	if rf, ok := ret.Get(0).(func(int, string) models.BankTransaction); ok {
+		r0 = rf(id, resolvedBy)
+	} else {
+		r0 = ret.Get(0).(models.BankTransaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string) error); ok {
+		r1 = rf(id, resolvedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportBankTransactions provides a mock function with given fields: bankImport, transactions
+func (_m *IRepo) ImportBankTransactions(bankImport *models.BankImport, transactions []models.BankTransaction) error {
+	ret := _m.Called(bankImport, transactions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportBankTransactions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.BankImport, []models.BankTransaction) error); ok {
+		r0 = rf(bankImport, transactions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MatchBankTransaction provides a mock function with given fields: id, flatNo, resolvedBy
+func (_m *IRepo) MatchBankTransaction(id int, flatNo int, resolvedBy string) (models.BankTransaction, error) {
+	ret := _m.Called(id, flatNo, resolvedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MatchBankTransaction")
+	}
+
+	var r0 models.BankTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int, string) (models.BankTransaction, error)); ok {
+		return rf(id, flatNo, resolvedBy)
+	}
+	if rf, ok := ret.Get(0).(func(int, int, string) models.BankTransaction); ok {
+		r0 = rf(id, flatNo, resolvedBy)
+	} else {
+		r0 = ret.Get(0).(models.BankTransaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int, string) error); ok {
+		r1 = rf(id, flatNo, resolvedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MovePaymentTransaction provides a mock function with given fields: transaction, from
+func (_m *IRepo) MovePaymentTransaction(transaction models.PaymentTransaction, from []string) error {
+	ret := _m.Called(transaction, from)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MovePaymentTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.PaymentTransaction, []string) error); ok {
+		r0 = rf(transaction, from)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReservePaymentRefund provides a mock function with given fields: refund
+func (_m *IRepo) ReservePaymentRefund(refund *models.PaymentRefund) error {
+	ret := _m.Called(refund)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReservePaymentRefund")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.PaymentRefund) error); ok {
+		r0 = rf(refund)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveDuesReminder provides a mock function with given fields: reminder
+func (_m *IRepo) SaveDuesReminder(reminder *models.DuesReminder) error {
+	ret := _m.Called(reminder)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveDuesReminder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.DuesReminder) error); ok {
+		r0 = rf(reminder)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveSetting provides a mock function with given fields: setting
+func (_m *IRepo) SaveSetting(setting models.Setting) error {
+	ret := _m.Called(setting)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSetting")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.Setting) error); ok {
+		r0 = rf(setting)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetExpenseReceipt provides a mock function with given fields: id, receiptFile
+func (_m *IRepo) SetExpenseReceipt(id int, receiptFile string) (string, error) {
+	ret := _m.Called(id, receiptFile)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetExpenseReceipt")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string) (string, error)); ok {
+		return rf(id, receiptFile)
+	}
+	if rf, ok := ret.Get(0).(func(int, string) string); ok {
+		r0 = rf(id, receiptFile)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string) error); ok {
+		r1 = rf(id, receiptFile)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SettleOnlinePayment provides a mock function with given fields: merchantOID, paid, at
+func (_m *IRepo) SettleOnlinePayment(merchantOID string, paid money.Money, at time.Time) (models.PaymentReceipt, error) {
+	ret := _m.Called(merchantOID, paid, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SettleOnlinePayment")
+	}
+
+	var r0 models.PaymentReceipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, money.Money, time.Time) (models.PaymentReceipt, error)); ok {
+		return rf(merchantOID, paid, at)
+	}
+	if rf, ok := ret.Get(0).(func(string, money.Money, time.Time) models.PaymentReceipt); ok {
+		r0 = rf(merchantOID, paid, at)
+	} else {
+		r0 = ret.Get(0).(models.PaymentReceipt)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, money.Money, time.Time) error); ok {
+		r1 = rf(merchantOID, paid, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateDuesProfile provides a mock function with given fields: apartment
+func (_m *IRepo) UpdateDuesProfile(apartment models.Apartment) error {
+	ret := _m.Called(apartment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDuesProfile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.Apartment) error); ok {
+		r0 = rf(apartment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateExpense provides a mock function with given fields: expense
+func (_m *IRepo) UpdateExpense(expense models.Expense) error {
+	ret := _m.Called(expense)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateExpense")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.Expense) error); ok {
+		r0 = rf(expense)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateFlatOwner provides a mock function with given fields: apartment
+func (_m *IRepo) UpdateFlatOwner(apartment models.Apartment) error {
+	ret := _m.Called(apartment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateFlatOwner")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.Apartment) error); ok {
+		r0 = rf(apartment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateReceiptMail provides a mock function with given fields: mail
+func (_m *IRepo) UpdateReceiptMail(mail models.ReceiptMail) error {
+	ret := _m.Called(mail)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateReceiptMail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.ReceiptMail) error); ok {
+		r0 = rf(mail)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateVendor provides a mock function with given fields: vendor
+func (_m *IRepo) UpdateVendor(vendor models.Vendor) error {
+	ret := _m.Called(vendor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateVendor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.Vendor) error); ok {
+		r0 = rf(vendor)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -0,0 +1,93 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IScheduler is an autogenerated mock type for the IScheduler type
+type IScheduler struct {
+	mock.Mock
+}
+
+// NextRuns provides a mock function with given fields:
+func (_m *IScheduler) NextRuns() map[string]time.Time {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for NextRuns")
+	}
+
+	var r0 map[string]time.Time
+	if rf, ok := ret.Get(0).(func() map[string]time.Time); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]time.Time)
+		}
+	}
+
+	return r0
+}
+
+// Schedule provides a mock function with given fields: name, spec, job
+func (_m *IScheduler) Schedule(name string, spec string, job func()) error {
+	ret := _m.Called(name, spec, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Schedule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, func()) error); ok {
+		r0 = rf(name, spec, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Start provides a mock function with given fields:
+func (_m *IScheduler) Start() {
+	_m.Called()
+}
+
+// Stop provides a mock function with given fields:
+func (_m *IScheduler) Stop() context.Context {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stop")
+	}
+
+	var r0 context.Context
+	if rf, ok := ret.Get(0).(func() context.Context); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(context.Context)
+		}
+	}
+
+	return r0
+}
+
+// NewIScheduler creates a new instance of IScheduler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIScheduler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IScheduler {
+	mock := &IScheduler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
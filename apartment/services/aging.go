@@ -0,0 +1,106 @@
+package services
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+)
+
+// GetAgingReport groups what each flat owes by how long it has been overdue.
+// Only charges that fell due before At count; charges billed but not yet due,
+// such as later assessment installments, are left out. Days are whole days
+// since the due date, so a charge due yesterday is one day old.
+func (s *service) GetAgingReport(filter AgingFilter) (AgingReport, error) {
+	switch filter.Sort {
+	case "":
+		filter.Sort = AgingSortFlat
+	case AgingSortFlat, AgingSortTotal, AgingSortDays:
+	default:
+		return AgingReport{}, dto.AgingReportError{Message: "sort must be flat_no, total or days_overdue"}
+	}
+	if filter.MinDays < 0 {
+		return AgingReport{}, dto.AgingReportError{Message: "min_days must not be negative"}
+	}
+	if filter.MinAmount.IsNegative() {
+		return AgingReport{}, dto.AgingReportError{Message: "min_amount must not be negative"}
+	}
+	if filter.At.IsZero() {
+		filter.At = time.Now()
+	}
+
+	charges, err := s.Repo.GetDueCharges(filter.At)
+	if err != nil {
+		return AgingReport{}, err
+	}
+
+	modelFlats, err := s.Repo.GetAllInfoAboutAllFlats()
+	if err != nil {
+		return AgingReport{}, err
+	}
+
+	flats := map[int]*AgingFlat{}
+	order := []int{}
+	for _, charge := range charges {
+		flat, ok := flats[charge.FlatNo]
+		if !ok {
+			flat = &AgingFlat{FlatNo: charge.FlatNo, OldestDueDate: charge.DueDate}
+			flats[charge.FlatNo] = flat
+			order = append(order, charge.FlatNo)
+		}
+
+		days := daysBetween(charge.DueDate, filter.At)
+		flat.Buckets.add(days, charge.Remaining)
+		if charge.DueDate.Before(flat.OldestDueDate) {
+			flat.OldestDueDate = charge.DueDate
+		}
+		if days > flat.DaysOverdue {
+			flat.DaysOverdue = days
+		}
+	}
+
+	for _, modelFlat := range modelFlats {
+		if flat, ok := flats[modelFlat.FlatNo]; ok {
+			flat.OwnerName = strings.TrimSpace(modelFlat.OwnerName + " " + modelFlat.OwnerSurname)
+			flat.Mail = modelFlat.Mail
+		}
+	}
+
+	report := AgingReport{At: filter.At, Flats: []AgingFlat{}}
+	for _, flatNo := range order {
+		flat := flats[flatNo]
+		if flat.DaysOverdue < filter.MinDays || flat.Buckets.Total.Cmp(filter.MinAmount) < 0 {
+			continue
+		}
+		report.Flats = append(report.Flats, *flat)
+		report.Totals.merge(flat.Buckets)
+	}
+
+	sort.SliceStable(report.Flats, func(i, j int) bool {
+		a, b := report.Flats[i], report.Flats[j]
+		if filter.Desc {
+			a, b = b, a
+		}
+		switch filter.Sort {
+		case AgingSortTotal:
+			if cmp := a.Buckets.Total.Cmp(b.Buckets.Total); cmp != 0 {
+				return cmp < 0
+			}
+		case AgingSortDays:
+			if a.DaysOverdue != b.DaysOverdue {
+				return a.DaysOverdue < b.DaysOverdue
+			}
+		}
+		return a.FlatNo < b.FlatNo
+	})
+
+	return report, nil
+}
+
+// daysBetween counts the calendar days from one date to a later one.
+func daysBetween(from time.Time, to time.Time) int {
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(start).Hours() / 24)
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	mocks "github.com/pragmataW/apartment_management/service_mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func agingCharge(flatNo int, dueDate time.Time, remaining int64) models.OpenCharge {
+	return models.OpenCharge{
+		LedgerEntry: models.LedgerEntry{FlatNo: flatNo, Type: models.LedgerAccrual, Amount: money.New(remaining), DueDate: dueDate},
+		Remaining:   money.New(remaining),
+	}
+}
+
+func TestGetAgingReport(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	at := time.Date(2026, 10, 17, 12, 0, 0, 0, time.Local)
+	repoMock.On("GetDueCharges", at).Return([]models.OpenCharge{
+		agingCharge(1, time.Date(2026, 6, 5, 0, 0, 0, 0, time.Local), 4000),
+		agingCharge(1, time.Date(2026, 8, 5, 0, 0, 0, 0, time.Local), 4000),
+		agingCharge(1, time.Date(2026, 10, 5, 0, 0, 0, 0, time.Local), 4000),
+		agingCharge(2, time.Date(2026, 9, 17, 0, 0, 0, 0, time.Local), 2500),
+		agingCharge(3, time.Date(2026, 10, 5, 0, 0, 0, 0, time.Local), 10000),
+	}, nil)
+	repoMock.On("GetAllInfoAboutAllFlats").Return([]models.Apartment{
+		{FlatNo: 1, OwnerName: "John", OwnerSurname: "Doe", Mail: "john@mail.com"},
+		{FlatNo: 2},
+		{FlatNo: 3},
+	}, nil)
+
+	report, err := service.GetAgingReport(AgingFilter{At: at})
+	assert.NoError(t, err)
+	assert.Len(t, report.Flats, 3)
+
+	flat := report.Flats[0]
+	assert.Equal(t, 1, flat.FlatNo)
+	assert.Equal(t, "John Doe", flat.OwnerName)
+	assert.Equal(t, money.New(4000), flat.Buckets.Days0To30)
+	assert.Equal(t, money.New(0), flat.Buckets.Days31To60)
+	assert.Equal(t, money.New(4000), flat.Buckets.Days61To90)
+	assert.Equal(t, money.New(4000), flat.Buckets.Over90)
+	assert.Equal(t, money.New(12000), flat.Buckets.Total)
+	assert.Equal(t, 134, flat.DaysOverdue)
+	assert.Equal(t, time.Date(2026, 6, 5, 0, 0, 0, 0, time.Local), flat.OldestDueDate)
+
+	assert.Equal(t, 30, report.Flats[1].DaysOverdue)
+	assert.Equal(t, money.New(2500), report.Flats[1].Buckets.Days0To30)
+	assert.Equal(t, money.New(16500), report.Totals.Days0To30)
+	assert.Equal(t, money.New(24500), report.Totals.Total)
+}
+
+func TestGetAgingReportFilterAndSort(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	at := time.Date(2026, 10, 17, 12, 0, 0, 0, time.Local)
+	repoMock.On("GetDueCharges", at).Return([]models.OpenCharge{
+		agingCharge(1, time.Date(2026, 6, 5, 0, 0, 0, 0, time.Local), 4000),
+		agingCharge(2, time.Date(2026, 5, 5, 0, 0, 0, 0, time.Local), 9000),
+		agingCharge(3, time.Date(2026, 10, 5, 0, 0, 0, 0, time.Local), 10000),
+	}, nil)
+	repoMock.On("GetAllInfoAboutAllFlats").Return([]models.Apartment{}, nil)
+
+	report, err := service.GetAgingReport(AgingFilter{At: at, MinDays: 91, Sort: AgingSortTotal, Desc: true})
+	assert.NoError(t, err)
+	assert.Len(t, report.Flats, 2)
+	assert.Equal(t, 2, report.Flats[0].FlatNo)
+	assert.Equal(t, 1, report.Flats[1].FlatNo)
+	assert.Equal(t, money.New(13000), report.Totals.Over90)
+
+	report, err = service.GetAgingReport(AgingFilter{At: at, MinAmount: money.New(5000), Sort: AgingSortDays})
+	assert.NoError(t, err)
+	assert.Len(t, report.Flats, 2)
+	assert.Equal(t, 3, report.Flats[0].FlatNo)
+	assert.Equal(t, 2, report.Flats[1].FlatNo)
+}
+
+func TestGetAgingReportInvalidFilter(t *testing.T) {
+	service := NewService(WithRepo(new(mocks.IRepo)))
+
+	_, err := service.GetAgingReport(AgingFilter{Sort: "owner"})
+	assert.IsType(t, dto.AgingReportError{}, err)
+
+	_, err = service.GetAgingReport(AgingFilter{MinDays: -1})
+	assert.IsType(t, dto.AgingReportError{}, err)
+
+	_, err = service.GetAgingReport(AgingFilter{MinAmount: money.New(-100)})
+	assert.IsType(t, dto.AgingReportError{}, err)
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+)
+
+const maxInstallments = 60
+
+// CreateAssessment splits the assessment total among the flats by its split
+// rule into equal monthly installments per flat, the first one falling due on
+// FirstDueDate. Each installment is charged to the flat when it falls due.
+// Amounts are split in kuruş so that the installments always add up to the
+// total exactly.
+func (s *service) CreateAssessment(assessment Assessment) (Assessment, error) {
+	if !assessment.Total.IsPositive() {
+		return Assessment{}, dto.AssessmentError{Message: "assessment total must be positive"}
+	}
+	if assessment.Installments == 0 {
+		assessment.Installments = 1
+	}
+	if assessment.Installments < 1 || assessment.Installments > maxInstallments {
+		return Assessment{}, dto.AssessmentError{Message: "installments must be between 1 and " + strconv.Itoa(maxInstallments)}
+	}
+	if assessment.FirstDueDate.IsZero() {
+		assessment.FirstDueDate = time.Now()
+	}
+
+	flats, err := s.Repo.GetAllInfoAboutAllFlats()
+	if err != nil {
+		return Assessment{}, err
+	}
+	sort.Slice(flats, func(i, j int) bool {
+		return flats[i].FlatNo < flats[j].FlatNo
+	})
+
+	weights, ok := ruleWeights(flats, assessment.SplitRule)
+	if !ok {
+		return Assessment{}, dto.AssessmentError{Message: "unknown split rule: " + assessment.SplitRule}
+	}
+
+	shares, err := splitAmount(assessment.Total, weights)
+	if err != nil {
+		return Assessment{}, err
+	}
+
+	equal := make([]float64, assessment.Installments)
+	for i := range equal {
+		equal[i] = 1
+	}
+
+	now := time.Now()
+	billed := money.New(0)
+	var schedule []models.AssessmentInstallment
+	for i, flat := range flats {
+		if !shares[i].IsPositive() {
+			continue
+		}
+
+		installments, err := splitAmount(shares[i], equal)
+		if err != nil {
+			return Assessment{}, err
+		}
+		for n, amount := range installments {
+			dueDate := addMonths(assessment.FirstDueDate, n)
+			note := assessment.Name
+			if assessment.Installments > 1 {
+				note += " (" + strconv.Itoa(n+1) + "/" + strconv.Itoa(assessment.Installments) + ")"
+			}
+			schedule = append(schedule, models.AssessmentInstallment{
+				FlatNo:  flat.FlatNo,
+				Period:  BillingPeriod(dueDate),
+				Amount:  amount,
+				Note:    note,
+				DueDate: dueDate,
+			})
+			if !dueDate.After(now) {
+				billed = billed.Add(amount)
+			}
+		}
+	}
+
+	modelAssessment := assessment.ToAssessmentModel()
+	err = s.Repo.CreateAssessment(&modelAssessment, schedule)
+	if err != nil {
+		return Assessment{}, err
+	}
+
+	assessment.ToAssessmentServiceObject(models.AssessmentStatus{Assessment: modelAssessment, Billed: billed})
+	return assessment, nil
+}
+
+// ChargeDueInstallments charges the assessment installments that have fallen
+// due by now.
+func (s *service) ChargeDueInstallments(now time.Time) error {
+	charged, err := s.Repo.ChargeDueInstallments(now)
+	if err != nil {
+		return err
+	}
+	if charged > 0 {
+		log.Println("charged " + strconv.Itoa(charged) + " assessment installments")
+	}
+	return nil
+}
+
+func (s *service) chargeDueInstallments() {
+	err := s.ChargeDueInstallments(time.Now())
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (s *service) GetAssessments() ([]Assessment, error) {
+	modelAssessments, err := s.Repo.GetAssessments()
+	if err != nil {
+		return []Assessment{}, err
+	}
+
+	var assessments []Assessment
+	for _, modelAssessment := range modelAssessments {
+		assessment := Assessment{}
+		assessment.ToAssessmentServiceObject(modelAssessment)
+		assessments = append(assessments, assessment)
+	}
+	return assessments, nil
+}
+
+func (s *service) GetAssessmentCharges(assessmentID int) ([]AssessmentCharge, error) {
+	modelCharges, err := s.Repo.GetAssessmentCharges(assessmentID)
+	if err != nil {
+		return []AssessmentCharge{}, err
+	}
+
+	var charges []AssessmentCharge
+	for _, modelCharge := range modelCharges {
+		charge := AssessmentCharge{}
+		charge.ToAssessmentChargeServiceObject(modelCharge)
+		charges = append(charges, charge)
+	}
+	return charges, nil
+}
+
+// ruleWeights weighs each flat by the split rule: one each, by share or by
+// area. It reports false for a rule it does not know.
+func ruleWeights(flats []models.Apartment, rule string) ([]float64, bool) {
+	weights := make([]float64, len(flats))
+	for i, flat := range flats {
+		switch rule {
+		case models.SplitEqual:
+			weights[i] = 1
+		case models.SplitShare:
+			weights[i] = flat.Share
+		case models.SplitArea:
+			weights[i] = flat.Area
+		default:
+			return nil, false
+		}
+	}
+	return weights, true
+}
+
+// splitAmount divides total in proportion to the weights. The kuruş lost to
+// rounding go to the largest remainders, so the parts add up to the total.
+func splitAmount(total money.Money, weights []float64) ([]money.Money, error) {
+	parts := total.Allocate(weights)
+	if parts == nil {
+		return nil, dto.AssessmentError{Message: "split weights must not be negative and must not all be zero"}
+	}
+	return parts, nil
+}
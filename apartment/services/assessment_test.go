@@ -0,0 +1,120 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	mocks "github.com/pragmataW/apartment_management/service_mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSplitAmount(t *testing.T) {
+	parts, err := splitAmount(money.New(10000), []float64{1, 1, 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []money.Money{money.New(3334), money.New(3333), money.New(3333)}, parts)
+
+	parts, err = splitAmount(money.New(100000), []float64{120, 80, 0})
+	assert.NoError(t, err)
+	assert.Equal(t, []money.Money{money.New(60000), money.New(40000), money.New(0)}, parts)
+
+	_, err = splitAmount(money.New(10000), []float64{0, 0})
+	assert.IsType(t, dto.AssessmentError{}, err)
+}
+
+func TestCreateAssessment(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetAllInfoAboutAllFlats").Return([]models.Apartment{
+		{FlatNo: 2, Area: 100},
+		{FlatNo: 1, Area: 200},
+		{FlatNo: 3, Area: 0},
+	}, nil)
+	repoMock.On("CreateAssessment", mock.AnythingOfType("*models.Assessment"), mock.MatchedBy(func(installments []models.AssessmentInstallment) bool {
+		if len(installments) != 6 {
+			return false
+		}
+		total := money.New(0)
+		for _, installment := range installments {
+			total = total.Add(installment.Amount)
+		}
+		return installments[0].FlatNo == 1 && installments[0].Amount == money.New(66667) &&
+			installments[2].Amount == money.New(66666) && installments[2].Period == "2027-01" &&
+			installments[2].DueDate.Equal(time.Date(2027, 1, 1, 0, 0, 0, 0, time.Local)) &&
+			installments[3].FlatNo == 2 && installments[0].Note == "facade (1/3)" &&
+			total == money.New(300000)
+	})).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.Assessment).ID = 9
+	}).Return(nil)
+
+	assessment, err := service.CreateAssessment(Assessment{
+		Name:         "facade",
+		Total:        money.New(300000),
+		SplitRule:    models.SplitArea,
+		Installments: 3,
+		FirstDueDate: time.Date(2026, 11, 1, 0, 0, 0, 0, time.Local),
+		CreatedBy:    "admin",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 9, assessment.ID)
+	repoMock.AssertExpectations(t)
+}
+
+func TestCreateAssessment_Invalid(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	_, err := service.CreateAssessment(Assessment{Name: "roof", Total: money.New(0), SplitRule: models.SplitEqual})
+	assert.IsType(t, dto.AssessmentError{}, err)
+
+	_, err = service.CreateAssessment(Assessment{Name: "roof", Total: money.New(10000), SplitRule: models.SplitEqual, Installments: 61})
+	assert.IsType(t, dto.AssessmentError{}, err)
+
+	repoMock.On("GetAllInfoAboutAllFlats").Return([]models.Apartment{{FlatNo: 1}}, nil)
+	_, err = service.CreateAssessment(Assessment{Name: "roof", Total: money.New(10000), SplitRule: models.SplitShare})
+	assert.IsType(t, dto.AssessmentError{}, err)
+	repoMock.AssertNotCalled(t, "CreateAssessment", mock.Anything, mock.Anything)
+}
+
+func TestGetAssessments(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetAssessments").Return([]models.AssessmentStatus{
+		{Assessment: models.Assessment{ID: 2, Name: "elevator", Total: money.New(500000)}, Billed: money.New(500000), Collected: money.New(125000)},
+	}, nil)
+
+	assessments, err := service.GetAssessments()
+	assert.NoError(t, err)
+	assert.Len(t, assessments, 1)
+	assert.Equal(t, "elevator", assessments[0].Name)
+	assert.Equal(t, money.New(125000), assessments[0].Collected)
+}
+
+func TestGetAssessmentCharges_Error(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	expectedError := errors.New("some error")
+	repoMock.On("GetAssessmentCharges", 2).Return(nil, expectedError)
+
+	_, err := service.GetAssessmentCharges(2)
+	assert.Equal(t, expectedError, err)
+}
+
+func TestChargeDueInstallments(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	now := time.Date(2026, 11, 1, 0, 0, 0, 0, time.Local)
+	repoMock.On("ChargeDueInstallments", now).Return(2, nil)
+
+	err := service.ChargeDueInstallments(now)
+	assert.NoError(t, err)
+	repoMock.AssertExpectations(t)
+}
@@ -0,0 +1,298 @@
+package services
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	bankstatement "github.com/pragmataW/apartment_management/pkg/bank_statement"
+	"github.com/pragmataW/apartment_management/pkg/money"
+)
+
+const (
+	FormatCSV     = "csv"
+	FormatCamt053 = "camt053"
+)
+
+// defaultReferenceRules are used while no rule has been set up. They catch
+// descriptions like "DAİRE NO: 5 EKİM AİDAT" and "flat 5".
+var defaultReferenceRules = []models.ReferenceRule{
+	{Name: "daire", Pattern: `(?i)\bda[iıİ]re\s*(?:no)?\s*[:.\-]?\s*(\d{1,4})\b`},
+	{Name: "flat", Pattern: `(?i)\b(?:flat|apt|apartment)\s*(?:no)?\s*[:.\-]?\s*(\d{1,4})\b`},
+}
+
+// BankStatement is a statement file to import. Mapping is only used for CSV
+// files.
+type BankStatement struct {
+	FileName   string
+	Format     string
+	Content    io.Reader
+	Mapping    bankstatement.Mapping
+	ImportedBy string
+}
+
+type compiledRule struct {
+	id      *int
+	flatNo  *int
+	pattern *regexp.Regexp
+}
+
+// ImportBankStatement reads the statement and books every incoming transfer
+// whose flat the reference rules can tell as that flat's payment. The rest
+// waits in the review queue, as do transfers in a currency other than TRY,
+// which are never booked. Lines without a currency are taken as TRY. When no
+// format is given it is taken from the file extension.
+func (s *service) ImportBankStatement(statement BankStatement) (BankImport, error) {
+	format := statementFormat(statement.FileName, statement.Format)
+
+	var lines []bankstatement.Line
+	var err error
+	switch format {
+	case FormatCSV:
+		lines, err = bankstatement.ParseCSV(statement.Content, statement.Mapping)
+	case FormatCamt053:
+		lines, err = bankstatement.ParseCamt053(statement.Content)
+	default:
+		return BankImport{}, dto.BankImportError{Message: "statement format must be csv or camt053"}
+	}
+	if err != nil {
+		return BankImport{}, dto.BankImportError{Message: err.Error()}
+	}
+
+	rules, err := s.referenceRules()
+	if err != nil {
+		return BankImport{}, err
+	}
+
+	bankImport := models.BankImport{
+		FileName:   statement.FileName,
+		Format:     format,
+		Lines:      len(lines),
+		ImportedBy: statement.ImportedBy,
+	}
+
+	var transactions []models.BankTransaction
+	seen := map[string]int{}
+	for _, line := range lines {
+		if !line.Amount.IsPositive() {
+			bankImport.Skipped++
+			continue
+		}
+
+		currency := strings.ToUpper(strings.TrimSpace(line.Currency))
+		if currency == "" {
+			currency = money.TRY
+		}
+
+		transaction := models.BankTransaction{
+			BankRef:      bankRef(line, seen),
+			Date:         line.Date,
+			Amount:       line.Amount,
+			Currency:     currency,
+			Description:  line.Description,
+			Counterparty: line.Counterparty,
+		}
+		if currency == money.TRY {
+			transaction.FlatNo, transaction.RuleID = matchFlat(rules, line)
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	if err := s.Repo.ImportBankTransactions(&bankImport, transactions); err != nil {
+		return BankImport{}, err
+	}
+
+	var result BankImport
+	result.ToBankImportServiceObject(bankImport)
+	return result, nil
+}
+
+func (s *service) GetBankImports() ([]BankImport, error) {
+	importModels, err := s.Repo.GetBankImports()
+	if err != nil {
+		return nil, err
+	}
+
+	imports := []BankImport{}
+	for _, importModel := range importModels {
+		bankImport := BankImport{}
+		bankImport.ToBankImportServiceObject(importModel)
+		imports = append(imports, bankImport)
+	}
+	return imports, nil
+}
+
+// GetBankTransactions lists the imported transactions in the given status;
+// the review queue is the unmatched ones.
+func (s *service) GetBankTransactions(status string) ([]BankTransaction, error) {
+	switch status {
+	case "", models.BankUnmatched, models.BankMatched, models.BankIgnored:
+	default:
+		return nil, dto.BankImportError{Message: "status must be unmatched, matched or ignored"}
+	}
+
+	transactionModels, err := s.Repo.GetBankTransactions(status)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := []BankTransaction{}
+	for _, transactionModel := range transactionModels {
+		transaction := BankTransaction{}
+		transaction.ToBankTransactionServiceObject(transactionModel)
+		transactions = append(transactions, transaction)
+	}
+	return transactions, nil
+}
+
+func (s *service) MatchBankTransaction(id int, flatNo int, resolvedBy string) (BankTransaction, error) {
+	transactionModel, err := s.Repo.MatchBankTransaction(id, flatNo, resolvedBy)
+	if err != nil {
+		return BankTransaction{}, err
+	}
+
+	var transaction BankTransaction
+	transaction.ToBankTransactionServiceObject(transactionModel)
+	return transaction, nil
+}
+
+func (s *service) IgnoreBankTransaction(id int, resolvedBy string) (BankTransaction, error) {
+	transactionModel, err := s.Repo.IgnoreBankTransaction(id, resolvedBy)
+	if err != nil {
+		return BankTransaction{}, err
+	}
+
+	var transaction BankTransaction
+	transaction.ToBankTransactionServiceObject(transactionModel)
+	return transaction, nil
+}
+
+// CreateReferenceRule checks that the pattern compiles and, for a rule
+// without a fixed flat, that it has a group to read the flat number from.
+func (s *service) CreateReferenceRule(rule ReferenceRule) (ReferenceRule, error) {
+	rule.Name = strings.TrimSpace(rule.Name)
+	if rule.Name == "" {
+		return ReferenceRule{}, dto.BankImportError{Message: "reference rule name is required"}
+	}
+
+	pattern, err := regexp.Compile(rule.Pattern)
+	if err != nil {
+		return ReferenceRule{}, dto.BankImportError{Message: "reference rule pattern is invalid: " + err.Error()}
+	}
+	if rule.FlatNo == nil && pattern.NumSubexp() == 0 {
+		return ReferenceRule{}, dto.BankImportError{Message: "reference rule needs a flat number or a group that captures it"}
+	}
+
+	ruleModel := rule.ToReferenceRuleModel()
+	if err := s.Repo.CreateReferenceRule(&ruleModel); err != nil {
+		return ReferenceRule{}, err
+	}
+
+	rule.ToReferenceRuleServiceObject(ruleModel)
+	return rule, nil
+}
+
+func (s *service) GetReferenceRules() ([]ReferenceRule, error) {
+	ruleModels, err := s.Repo.GetReferenceRules()
+	if err != nil {
+		return nil, err
+	}
+
+	rules := []ReferenceRule{}
+	for _, ruleModel := range ruleModels {
+		rule := ReferenceRule{}
+		rule.ToReferenceRuleServiceObject(ruleModel)
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func (s *service) DeleteReferenceRule(id int) error {
+	return s.Repo.DeleteReferenceRule(id)
+}
+
+func (s *service) referenceRules() ([]compiledRule, error) {
+	ruleModels, err := s.Repo.GetReferenceRules()
+	if err != nil {
+		return nil, err
+	}
+	if len(ruleModels) == 0 {
+		ruleModels = defaultReferenceRules
+	}
+
+	var rules []compiledRule
+	for _, ruleModel := range ruleModels {
+		pattern, err := regexp.Compile(ruleModel.Pattern)
+		if err != nil {
+			return nil, dto.BankImportError{Message: fmt.Sprintf("reference rule %q is invalid: %v", ruleModel.Name, err)}
+		}
+
+		rule := compiledRule{flatNo: ruleModel.FlatNo, pattern: pattern}
+		if ruleModel.ID != 0 {
+			id := ruleModel.ID
+			rule.id = &id
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// matchFlat runs the rules in order on the line and returns the flat of the
+// first one that matches, with the rule's id.
+func matchFlat(rules []compiledRule, line bankstatement.Line) (*int, *int) {
+	text := strings.TrimSpace(line.Description + " " + line.Counterparty)
+	for _, rule := range rules {
+		match := rule.pattern.FindStringSubmatch(text)
+		if match == nil {
+			continue
+		}
+		if rule.flatNo != nil {
+			flatNo := *rule.flatNo
+			return &flatNo, rule.id
+		}
+		if len(match) < 2 {
+			continue
+		}
+		flatNo, err := strconv.Atoi(match[1])
+		if err != nil || flatNo <= 0 {
+			continue
+		}
+		return &flatNo, rule.id
+	}
+	return nil, nil
+}
+
+// bankRef is the bank's own reference of the line. Lines without one get a
+// hash of their contents, numbered so that two identical transfers on the
+// same day in a statement are both kept.
+func bankRef(line bankstatement.Line, seen map[string]int) string {
+	if line.Reference != "" {
+		return line.Reference
+	}
+
+	key := fmt.Sprintf("%s|%s|%s|%s", line.Date.Format("2006-01-02"), line.Amount, line.Description, line.Counterparty)
+	seen[key]++
+	sum := sha1.Sum([]byte(key + "|" + strconv.Itoa(seen[key])))
+	return "sha1:" + hex.EncodeToString(sum[:])
+}
+
+func statementFormat(fileName string, format string) string {
+	if format != "" {
+		return strings.ToLower(format)
+	}
+
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		return FormatCSV
+	case ".xml":
+		return FormatCamt053
+	}
+	return ""
+}
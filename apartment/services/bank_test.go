@@ -0,0 +1,156 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	bankstatement "github.com/pragmataW/apartment_management/pkg/bank_statement"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	mocks "github.com/pragmataW/apartment_management/service_mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const camtStatement = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+  <BkToCstmrStmt>
+    <Stmt>
+      <Ntry>
+        <Amt Ccy="TRY">500.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <BookgDt><Dt>2026-10-03</Dt></BookgDt>
+        <AcctSvcrRef>TRX-1</AcctSvcrRef>
+        <NtryDtls><TxDtls>
+          <RltdPties><Dbtr><Nm>AYSE YILMAZ</Nm></Dbtr></RltdPties>
+          <RmtInf><Ustrd>EKIM AIDAT</Ustrd></RmtInf>
+        </TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="TRY">1200.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <BookgDt><Dt>2026-10-04</Dt></BookgDt>
+        <AddtlNtryInf>ELEKTRIK FATURASI</AddtlNtryInf>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>`
+
+func TestImportBankStatement_CSV(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	content := "date;amount;description;reference\n" +
+		"01.10.2026;1.250,50;DAİRE NO:5 EKİM AİDAT;B-1\n" +
+		"02.10.2026;40,00;aidat;\n" +
+		"02.10.2026;-300,00;temizlik;B-3\n"
+
+	repoMock.On("GetReferenceRules").Return([]models.ReferenceRule{}, nil)
+	repoMock.On("ImportBankTransactions", mock.Anything, mock.MatchedBy(func(transactions []models.BankTransaction) bool {
+		return len(transactions) == 2 &&
+			transactions[0].BankRef == "B-1" && transactions[0].Amount == money.New(125050) && *transactions[0].FlatNo == 5 &&
+			transactions[1].FlatNo == nil && strings.HasPrefix(transactions[1].BankRef, "sha1:")
+	})).Run(func(args mock.Arguments) {
+		bankImport := args.Get(0).(*models.BankImport)
+		bankImport.ID = 2
+		bankImport.Matched = 1
+		bankImport.Unmatched = 1
+	}).Return(nil)
+
+	bankImport, err := service.ImportBankStatement(BankStatement{
+		FileName:   "ekstre.csv",
+		Content:    strings.NewReader(content),
+		Mapping:    bankstatement.DefaultMapping(),
+		ImportedBy: "admin",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, FormatCSV, bankImport.Format)
+	assert.Equal(t, 3, bankImport.Lines)
+	assert.Equal(t, 1, bankImport.Skipped)
+	assert.Equal(t, 1, bankImport.Matched)
+}
+
+func TestImportBankStatement_Camt053(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	flatNo := 7
+	repoMock.On("GetReferenceRules").Return([]models.ReferenceRule{
+		{ID: 3, Name: "sender", Pattern: "(?i)ayse yilmaz", FlatNo: &flatNo},
+	}, nil)
+	repoMock.On("ImportBankTransactions", mock.Anything, mock.MatchedBy(func(transactions []models.BankTransaction) bool {
+		return len(transactions) == 1 && transactions[0].BankRef == "TRX-1" && transactions[0].Amount == money.New(50000) &&
+			transactions[0].Currency == money.TRY &&
+			*transactions[0].FlatNo == 7 && *transactions[0].RuleID == 3 &&
+			transactions[0].Date.Format("2006-01-02") == "2026-10-03"
+	})).Return(nil)
+
+	bankImport, err := service.ImportBankStatement(BankStatement{
+		FileName: "statement.xml",
+		Content:  strings.NewReader(camtStatement),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, FormatCamt053, bankImport.Format)
+	assert.Equal(t, 1, bankImport.Skipped)
+}
+
+func TestImportBankStatement_ForeignCurrency(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	statement := strings.Replace(camtStatement, `<Amt Ccy="TRY">500.00</Amt>`, `<Amt Ccy="EUR">500.00</Amt>`, 1)
+	flatNo := 7
+	repoMock.On("GetReferenceRules").Return([]models.ReferenceRule{
+		{ID: 3, Name: "sender", Pattern: "(?i)ayse yilmaz", FlatNo: &flatNo},
+	}, nil)
+	repoMock.On("ImportBankTransactions", mock.Anything, mock.MatchedBy(func(transactions []models.BankTransaction) bool {
+		return len(transactions) == 1 && transactions[0].Currency == "EUR" &&
+			transactions[0].FlatNo == nil && transactions[0].RuleID == nil
+	})).Return(nil)
+
+	_, err := service.ImportBankStatement(BankStatement{
+		FileName: "statement.xml",
+		Content:  strings.NewReader(statement),
+	})
+	assert.NoError(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestImportBankStatement_Invalid(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	_, err := service.ImportBankStatement(BankStatement{FileName: "ekstre.pdf", Content: strings.NewReader("")})
+	assert.IsType(t, dto.BankImportError{}, err)
+
+	_, err = service.ImportBankStatement(BankStatement{
+		FileName: "ekstre.csv",
+		Content:  strings.NewReader("tarih;tutar\n01.10.2026;10\n"),
+		Mapping:  bankstatement.DefaultMapping(),
+	})
+	assert.IsType(t, dto.BankImportError{}, err)
+
+	repoMock.AssertNotCalled(t, "ImportBankTransactions", mock.Anything, mock.Anything)
+}
+
+func TestCreateReferenceRule_Invalid(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	_, err := service.CreateReferenceRule(ReferenceRule{Name: "broken", Pattern: "daire (\\d+"})
+	assert.IsType(t, dto.BankImportError{}, err)
+
+	_, err = service.CreateReferenceRule(ReferenceRule{Name: "no group", Pattern: "daire"})
+	assert.IsType(t, dto.BankImportError{}, err)
+
+	repoMock.AssertNotCalled(t, "CreateReferenceRule", mock.Anything)
+}
+
+func TestGetBankTransactions_InvalidStatus(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	_, err := service.GetBankTransactions("pending")
+	assert.IsType(t, dto.BankImportError{}, err)
+}
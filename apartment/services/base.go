@@ -1,8 +1,14 @@
 package services
 
 import (
+	"context"
+	"io"
+	"time"
+
 	"github.com/go-resty/resty/v2"
 	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	paymentprovider "github.com/pragmataW/apartment_management/pkg/payment_provider"
 )
 
 type IRepo interface {
@@ -11,16 +17,92 @@ type IRepo interface {
 	DeleteFlat(flatNo int) error
 	GetAllInfoAboutFlat(flatNo int) (models.Apartment, error)
 	GetAllInfoAboutAllFlats() ([]models.Apartment, error)
+	GetFlatByEmail(email string) (models.Apartment, error)
+	UpdateDuesProfile(apartment models.Apartment) error
 	GetDuesCount(flatNo int) (int, error)
-	AddDues(flatNo int) error
-	AddDuesForAll() error
+	AddDues(flatNo int, period string, amount money.Money) error
+	AddDuesForAll(run models.AccrualRun, amounts map[int]money.Money) error
+	GetLastAccrualRun() (models.AccrualRun, error)
+	GetAccrualRuns() ([]models.AccrualRun, error)
 	DeleteDues(flatNo int) error
-	DeleteDuesByEmail(email string) error
+	GetOpenChargeGroups(flatNo int) ([]models.ChargeGroup, error)
+	GetOverdueCharges(before time.Time) ([]models.OpenCharge, error)
+	AddPenalties(penalties []models.LedgerEntry) (int, error)
+	CreateAssessment(assessment *models.Assessment, installments []models.AssessmentInstallment) error
+	ChargeDueInstallments(at time.Time) (int, error)
+	GetAssessments() ([]models.AssessmentStatus, error)
+	GetAssessmentCharges(assessmentID int) ([]models.OpenCharge, error)
+	CreateExpenseCategory(category *models.ExpenseCategory) error
+	GetExpenseCategories() ([]models.ExpenseCategory, error)
+	DeleteExpenseCategory(id int) error
+	CreateVendor(vendor *models.Vendor) error
+	GetVendors() ([]models.Vendor, error)
+	UpdateVendor(vendor models.Vendor) error
+	DeleteVendor(id int) error
+	CreateExpense(expense *models.Expense) error
+	UpdateExpense(expense models.Expense) error
+	DeleteExpense(id int) (models.Expense, error)
+	GetExpense(id int) (models.ExpenseRecord, error)
+	GetExpenses(filter models.ExpenseFilter) ([]models.ExpenseRecord, error)
+	SetExpenseReceipt(id int, receiptFile string) (string, error)
+	GetReportTotals(fromPeriod string, toPeriod string, from time.Time, to time.Time) (models.ReportTotals, error)
+	GetDueCharges(before time.Time) ([]models.OpenCharge, error)
+	CreateMeter(meter *models.Meter) error
+	GetMeters(flatNo int) ([]models.Meter, error)
+	GetMeter(id int) (models.Meter, error)
+	DeleteMeter(id int) error
+	AddMeterReadings(readings []models.MeterReading) error
+	GetMeterReadings(meterID int) ([]models.MeterReading, error)
+	GetLatestReadings(meterType string, on time.Time) ([]models.MeterReading, error)
+	CreateUtilityBill(bill *models.UtilityBill, lines []models.UtilityBillLine, charges []models.LedgerEntry) error
+	GetUtilityBills() ([]models.UtilityBill, error)
+	GetUtilityBill(id int) (models.UtilityBill, []models.UtilityBillLine, error)
+	SaveDuesReminder(reminder *models.DuesReminder) error
+	GetLastReminderTimes() (map[int]time.Time, error)
+	GetDuesReminders(flatNo int) ([]models.DuesReminder, error)
+	AddManualPayment(receipt *models.PaymentReceipt) error
+	SettleOnlinePayment(merchantOID string, paid money.Money, at time.Time) (models.PaymentReceipt, error)
+	AcceptPaymentReview(merchantOID string, reviewedBy string, at time.Time) (models.PaymentReceipt, error)
+	GetPaymentPeriods(entryID int) ([]string, error)
+	GetReceiptMailByReceipt(receiptNo int) (models.ReceiptMail, error)
+	GetDueReceiptMails(at time.Time) ([]models.ReceiptMail, error)
+	GetReceiptMails(status string) ([]models.ReceiptMail, error)
+	ClaimReceiptMail(id int, at time.Time, until time.Time) (models.ReceiptMail, error)
+	ClaimReceiptMailForRetry(id int, at time.Time, until time.Time) (models.ReceiptMail, error)
+	UpdateReceiptMail(mail models.ReceiptMail) error
+	ImportBankTransactions(bankImport *models.BankImport, transactions []models.BankTransaction) error
+	GetBankImports() ([]models.BankImport, error)
+	GetBankTransactions(status string) ([]models.BankTransaction, error)
+	MatchBankTransaction(id int, flatNo int, resolvedBy string) (models.BankTransaction, error)
+	IgnoreBankTransaction(id int, resolvedBy string) (models.BankTransaction, error)
+	CreateReferenceRule(rule *models.ReferenceRule) error
+	GetReferenceRules() ([]models.ReferenceRule, error)
+	DeleteReferenceRule(id int) error
+	CancelManualPayment(receiptNo int, cancelledBy string, reason string) (models.PaymentReceipt, error)
+	GetPaymentReceipt(receiptNo int) (models.PaymentReceipt, error)
+	GetPaymentReceipts(flatNo int) ([]models.PaymentReceipt, error)
+	AddLedgerEntry(entry models.LedgerEntry) error
+	GetLedgerEntries(flatNo int) ([]models.LedgerEntry, error)
+	GetPayments(flatNo int) ([]models.LedgerEntry, error)
+	GetOpenCharges(flatNo int) ([]models.OpenCharge, error)
+	GetDuesSummary(flatNo int) (models.DuesSummary, error)
+	GetDuesSummaries() ([]models.DuesSummary, error)
+	GetSetting(key string, at time.Time) (models.Setting, error)
+	GetPendingSetting(key string, at time.Time) (models.Setting, error)
+	SaveSetting(setting models.Setting) error
+	GetSettingHistory(key string) ([]models.Setting, error)
 	GetPasswordAndFlatNoByEmail(email string) (string, int, error)
 	GetAllAnnouncements() ([]models.Announcement, error)
 	AddAnnouncement(announcement models.Announcement) error
-	AddMerchant(uuid string, email string) error
-	GetEmailFromMerchant(merchantOID string) (string, error)
+	CreatePaymentTransaction(transaction *models.PaymentTransaction) error
+	GetPaymentTransaction(merchantOID string) (models.PaymentTransaction, error)
+	GetPaymentTransactions(filter models.PaymentTransactionFilter) ([]models.PaymentTransaction, error)
+	MovePaymentTransaction(transaction models.PaymentTransaction, from []string) error
+	ExpirePaymentTransactions(before time.Time) (int, error)
+	ReservePaymentRefund(refund *models.PaymentRefund) error
+	FailPaymentRefund(id int, reason string) error
+	CompletePaymentRefund(id int) (models.PaymentTransaction, error)
+	GetPaymentRefunds(merchantOID string) ([]models.PaymentRefund, error)
 }
 
 type IEncrypt interface {
@@ -33,13 +115,40 @@ type IConfigManager interface {
 	GetJwtKey() string
 	GetFromMail() string
 	GetMailServer() string
+	GetPaymentPageUrl() string
+}
+
+type IScheduler interface {
+	Schedule(name string, spec string, job func()) error
+	NextRuns() map[string]time.Time
+	Start()
+	Stop() context.Context
+}
+
+type IFileStore interface {
+	Save(name string, content io.Reader) error
+	Open(name string) (io.ReadCloser, error)
+	Remove(name string) error
+}
+
+// IPaymentProvider takes online payments. It hands out the token the
+// resident pays with, checks the callback it sends when the payment ends and
+// gives money back on a completed payment.
+type IPaymentProvider interface {
+	Name() string
+	GetToken(payment paymentprovider.Payment) (string, error)
+	VerifyCallback(form map[string]string) (paymentprovider.Notice, error)
+	Refund(orderID string, amount money.Money) error
 }
 
 type service struct {
-	Repo          IRepo
-	Encryptor     IEncrypt
-	ConfigManager IConfigManager
-	RestyClient   *resty.Client
+	Repo            IRepo
+	Encryptor       IEncrypt
+	ConfigManager   IConfigManager
+	RestyClient     *resty.Client
+	Scheduler       IScheduler
+	ReceiptStore    IFileStore
+	PaymentProvider IPaymentProvider
 }
 
 type serviceOption func(*service)
@@ -75,3 +184,21 @@ func WithRestyClient(client *resty.Client) serviceOption {
 		s.RestyClient = client
 	}
 }
+
+func WithScheduler(scheduler IScheduler) serviceOption {
+	return func(s *service) {
+		s.Scheduler = scheduler
+	}
+}
+
+func WithReceiptStore(store IFileStore) serviceOption {
+	return func(s *service) {
+		s.ReceiptStore = store
+	}
+}
+
+func WithPaymentProvider(provider IPaymentProvider) serviceOption {
+	return func(s *service) {
+		s.PaymentProvider = provider
+	}
+}
@@ -1,24 +1,43 @@
 package services
 
-import "github.com/pragmataW/apartment_management/models"
+import (
+	"time"
+
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+)
 
 type Apartment struct {
-	FlatNo       int
-	OwnerName    string
-	OwnerSurname string
-	Mail         string
-	Password     string
-	DuesCount    int
+	FlatNo          int
+	OwnerName       string
+	OwnerSurname    string
+	Mail            string
+	Password        string
+	FlatType        string
+	Area            float64
+	Share           float64
+	DuesCoefficient float64
+	DuesOverride    *money.Money
+	MonthlyDues     money.Money
+	DuesCount       int
+	Penalties       money.Money
+	Credit          money.Money
+	Assessments     money.Money
+	Balance         money.Money
 }
 
 func (ar *Apartment) ToApartmentModel() models.Apartment {
 	apartment := models.Apartment{
-		FlatNo:       ar.FlatNo,
-		OwnerName:    ar.OwnerName,
-		OwnerSurname: ar.OwnerSurname,
-		Mail:         ar.Mail,
-		Password:     ar.Password,
-		DuesCount:    ar.DuesCount,
+		FlatNo:          ar.FlatNo,
+		OwnerName:       ar.OwnerName,
+		OwnerSurname:    ar.OwnerSurname,
+		Mail:            ar.Mail,
+		Password:        ar.Password,
+		FlatType:        ar.FlatType,
+		Area:            ar.Area,
+		Share:           ar.Share,
+		DuesCoefficient: ar.DuesCoefficient,
+		DuesOverride:    ar.DuesOverride,
 	}
 	return apartment
 }
@@ -29,7 +48,37 @@ func (ar *Apartment) ToApartmentServiceObject(apartment models.Apartment){
 	ar.OwnerSurname = apartment.OwnerSurname
 	ar.Mail = apartment.Mail
 	ar.Password = apartment.Password
-	ar.DuesCount = apartment.DuesCount
+	ar.FlatType = apartment.FlatType
+	ar.Area = apartment.Area
+	ar.Share = apartment.Share
+	ar.DuesCoefficient = apartment.DuesCoefficient
+	ar.DuesOverride = apartment.DuesOverride
+}
+
+func (ar *Apartment) ApplyDuesSummary(summary models.DuesSummary) {
+	ar.DuesCount = summary.OpenDues
+	ar.Penalties = summary.OpenPenalties
+	ar.Credit = summary.Credit
+	ar.Assessments = summary.Assessments
+	ar.Balance = summary.Balance
+}
+
+// AccountBalance is a resident's view of their own account.
+type AccountBalance struct {
+	FlatNo      int
+	Balance     money.Money
+	Credit      money.Money
+	OpenDues    int
+	Penalties   money.Money
+	MonthlyDues money.Money
+}
+
+func (ab *AccountBalance) ToAccountBalanceServiceObject(summary models.DuesSummary) {
+	ab.FlatNo = summary.FlatNo
+	ab.Balance = summary.Balance
+	ab.Credit = summary.Credit
+	ab.OpenDues = summary.OpenDues
+	ab.Penalties = summary.OpenPenalties
 }
 
 //announcement
@@ -52,4 +101,778 @@ func (an Announcement) ToAnnouncementModel() models.Announcement{
 		Title: an.Title,
 		Content: an.Content,
 	}
-}
\ No newline at end of file
+}
+//ledger
+
+type LedgerEntry struct {
+	ID        int
+	FlatNo    int
+	Period    string
+	Type      string
+	Amount    money.Money
+	Source    string
+	Reference string
+	Note      string
+	DueDate   time.Time
+	CreatedAt time.Time
+}
+
+type Ledger struct {
+	FlatNo  int
+	Balance money.Money
+	Entries []LedgerEntry
+}
+
+func (le *LedgerEntry) ToLedgerEntryServiceObject(entry models.LedgerEntry) {
+	le.ID = entry.ID
+	le.FlatNo = entry.FlatNo
+	le.Period = entry.Period
+	le.Type = entry.Type
+	le.Amount = entry.Amount
+	le.Source = entry.Source
+	le.Reference = entry.Reference
+	le.Note = entry.Note
+	le.DueDate = entry.DueDate
+	le.CreatedAt = entry.CreatedAt
+}
+
+//settings
+
+type DuesPrice struct {
+	Price             money.Money
+	NextPrice         money.Money
+	NextEffectiveFrom time.Time
+}
+
+type SettingChange struct {
+	Key           string
+	Value         string
+	PreviousValue string
+	ChangedBy     string
+	ChangedAt     time.Time
+	EffectiveFrom time.Time
+}
+
+func (sc *SettingChange) ToSettingChangeServiceObject(setting models.Setting) {
+	sc.Key = setting.Key
+	sc.Value = setting.Value
+	sc.PreviousValue = setting.PreviousValue
+	sc.ChangedBy = setting.ChangedBy
+	sc.ChangedAt = setting.ChangedAt
+	sc.EffectiveFrom = setting.EffectiveFrom
+}
+
+//jobs
+
+type ScheduledJob struct {
+	Name    string
+	NextRun time.Time
+}
+
+type AccrualRun struct {
+	Period    string
+	Amount    money.Money
+	FlatCount int
+	DueDate   time.Time
+	RunAt     time.Time
+}
+
+func (ar *AccrualRun) ToAccrualRunServiceObject(run models.AccrualRun) {
+	ar.Period = run.Period
+	ar.Amount = run.Amount
+	ar.FlatCount = run.FlatCount
+	ar.DueDate = run.DueDate
+	ar.RunAt = run.RunAt
+}
+
+type PenaltyPolicy struct {
+	GraceDays int
+	Rate      float64
+	Fee       money.Money
+}
+
+type Assessment struct {
+	ID           int
+	Name         string
+	Description  string
+	Total        money.Money
+	SplitRule    string
+	Installments int
+	FirstDueDate time.Time
+	CreatedBy    string
+	CreatedAt    time.Time
+	Billed       money.Money
+	Collected    money.Money
+}
+
+func (a *Assessment) ToAssessmentModel() models.Assessment {
+	return models.Assessment{
+		ID:           a.ID,
+		Name:         a.Name,
+		Description:  a.Description,
+		Total:        a.Total,
+		SplitRule:    a.SplitRule,
+		Installments: a.Installments,
+		FirstDueDate: a.FirstDueDate,
+		CreatedBy:    a.CreatedBy,
+	}
+}
+
+func (a *Assessment) ToAssessmentServiceObject(assessment models.AssessmentStatus) {
+	a.ID = assessment.ID
+	a.Name = assessment.Name
+	a.Description = assessment.Description
+	a.Total = assessment.Total
+	a.SplitRule = assessment.SplitRule
+	a.Installments = assessment.Installments
+	a.FirstDueDate = assessment.FirstDueDate
+	a.CreatedBy = assessment.CreatedBy
+	a.CreatedAt = assessment.CreatedAt
+	a.Billed = assessment.Billed
+	a.Collected = assessment.Collected
+}
+
+type AssessmentCharge struct {
+	FlatNo    int
+	Period    string
+	Amount    money.Money
+	Remaining money.Money
+	Note      string
+	DueDate   time.Time
+}
+
+func (ac *AssessmentCharge) ToAssessmentChargeServiceObject(charge models.OpenCharge) {
+	ac.FlatNo = charge.FlatNo
+	ac.Period = charge.Period
+	ac.Amount = charge.Amount
+	ac.Remaining = charge.Remaining
+	ac.Note = charge.Note
+	ac.DueDate = charge.DueDate
+}
+
+type ExpenseCategory struct {
+	ID   int
+	Name string
+}
+
+func (ec *ExpenseCategory) ToExpenseCategoryModel() models.ExpenseCategory {
+	return models.ExpenseCategory{
+		ID:   ec.ID,
+		Name: ec.Name,
+	}
+}
+
+func (ec *ExpenseCategory) ToExpenseCategoryServiceObject(category models.ExpenseCategory) {
+	ec.ID = category.ID
+	ec.Name = category.Name
+}
+
+type Vendor struct {
+	ID    int
+	Name  string
+	TaxNo string
+	Phone string
+	Email string
+}
+
+func (v *Vendor) ToVendorModel() models.Vendor {
+	return models.Vendor{
+		ID:    v.ID,
+		Name:  v.Name,
+		TaxNo: v.TaxNo,
+		Phone: v.Phone,
+		Email: v.Email,
+	}
+}
+
+func (v *Vendor) ToVendorServiceObject(vendor models.Vendor) {
+	v.ID = vendor.ID
+	v.Name = vendor.Name
+	v.TaxNo = vendor.TaxNo
+	v.Phone = vendor.Phone
+	v.Email = vendor.Email
+}
+
+type Expense struct {
+	ID           int
+	CategoryID   int
+	CategoryName string
+	VendorID     *int
+	VendorName   string
+	Date         time.Time
+	Amount       money.Money
+	Description  string
+	HasReceipt   bool
+	CreatedBy    string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (e *Expense) ToExpenseModel() models.Expense {
+	return models.Expense{
+		ID:          e.ID,
+		CategoryID:  e.CategoryID,
+		VendorID:    e.VendorID,
+		Date:        e.Date,
+		Amount:      e.Amount,
+		Description: e.Description,
+		CreatedBy:   e.CreatedBy,
+	}
+}
+
+func (e *Expense) ToExpenseServiceObject(expense models.ExpenseRecord) {
+	e.ID = expense.ID
+	e.CategoryID = expense.CategoryID
+	e.CategoryName = expense.CategoryName
+	e.VendorID = expense.VendorID
+	e.VendorName = expense.VendorName
+	e.Date = expense.Date
+	e.Amount = expense.Amount
+	e.Description = expense.Description
+	e.HasReceipt = expense.ReceiptFile != ""
+	e.CreatedBy = expense.CreatedBy
+	e.CreatedAt = expense.CreatedAt
+	e.UpdatedAt = expense.UpdatedAt
+}
+
+type ExpenseFilter struct {
+	From       time.Time
+	To         time.Time
+	CategoryID int
+	VendorID   int
+}
+
+func (ef *ExpenseFilter) ToExpenseFilterModel() models.ExpenseFilter {
+	return models.ExpenseFilter{
+		From:       ef.From,
+		To:         ef.To,
+		CategoryID: ef.CategoryID,
+		VendorID:   ef.VendorID,
+	}
+}
+
+// FinancialReport is income against spending over a report period. Amounts
+// are in TRY and CollectionRate is a percentage.
+type FinancialReport struct {
+	Period             string
+	From               time.Time
+	To                 time.Time
+	DuesAccrued        money.Money
+	PenaltiesAccrued   money.Money
+	AssessmentsAccrued money.Money
+	OtherCharges       money.Money
+	TotalAccrued       money.Money
+	CollectedPaytr     money.Money
+	CollectedManual    money.Money
+	TotalCollected     money.Money
+	Waived             money.Money
+	OtherCredits       money.Money
+	Expenses           []ExpenseLine
+	TotalExpenses      money.Money
+	Balance            money.Money
+	CollectionRate     float64
+	PeriodOutstanding  money.Money
+	TotalOutstanding   money.Money
+}
+
+type ExpenseLine struct {
+	Category string
+	Count    int
+	Amount   money.Money
+}
+
+const (
+	AgingSortFlat  = "flat_no"
+	AgingSortTotal = "total"
+	AgingSortDays  = "days_overdue"
+)
+
+// AgingFilter narrows and orders the aging report. MinDays keeps the flats
+// whose oldest overdue charge is at least that many days old and MinAmount
+// those owing at least that much. Sort is one of the AgingSort values and
+// defaults to the flat number; Desc reverses it. A zero At means now.
+type AgingFilter struct {
+	At        time.Time
+	MinDays   int
+	MinAmount money.Money
+	Sort      string
+	Desc      bool
+}
+
+// AgingBuckets splits an overdue balance by how many days ago each charge
+// fell due.
+type AgingBuckets struct {
+	Days0To30  money.Money
+	Days31To60 money.Money
+	Days61To90 money.Money
+	Over90     money.Money
+	Total      money.Money
+}
+
+func (b *AgingBuckets) add(days int, amount money.Money) {
+	switch {
+	case days <= 30:
+		b.Days0To30 = b.Days0To30.Add(amount)
+	case days <= 60:
+		b.Days31To60 = b.Days31To60.Add(amount)
+	case days <= 90:
+		b.Days61To90 = b.Days61To90.Add(amount)
+	default:
+		b.Over90 = b.Over90.Add(amount)
+	}
+	b.Total = b.Total.Add(amount)
+}
+
+func (b *AgingBuckets) merge(other AgingBuckets) {
+	b.Days0To30 = b.Days0To30.Add(other.Days0To30)
+	b.Days31To60 = b.Days31To60.Add(other.Days31To60)
+	b.Days61To90 = b.Days61To90.Add(other.Days61To90)
+	b.Over90 = b.Over90.Add(other.Over90)
+	b.Total = b.Total.Add(other.Total)
+}
+
+// AgingReport is what every flat owes past its due dates as of At. Totals
+// adds up the flats listed, after filtering.
+type AgingReport struct {
+	At     time.Time
+	Totals AgingBuckets
+	Flats  []AgingFlat
+}
+
+type AgingFlat struct {
+	FlatNo        int
+	OwnerName     string
+	Mail          string
+	Buckets       AgingBuckets
+	OldestDueDate time.Time
+	DaysOverdue   int
+}
+
+// Statement is a flat's account over a date range. Debits are charges and
+// credits are payments and waivers; a positive balance is owed by the flat.
+type Statement struct {
+	FlatNo         int
+	OwnerName      string
+	From           time.Time
+	To             time.Time
+	OpeningBalance money.Money
+	TotalDebit     money.Money
+	TotalCredit    money.Money
+	ClosingBalance money.Money
+	Lines          []StatementLine
+}
+
+type StatementLine struct {
+	Date        time.Time
+	Period      string
+	Type        string
+	Description string
+	Debit       money.Money
+	Credit      money.Money
+	Balance     money.Money
+}
+
+type OpenCharge struct {
+	ID        int
+	Period    string
+	Type      string
+	Note      string
+	Amount    money.Money
+	Remaining money.Money
+	DueDate   time.Time
+}
+
+func (oc *OpenCharge) ToOpenChargeServiceObject(charge models.OpenCharge) {
+	oc.ID = charge.ID
+	oc.Period = charge.Period
+	oc.Type = charge.Type
+	oc.Note = charge.Note
+	oc.Amount = charge.Amount
+	oc.Remaining = charge.Remaining
+	oc.DueDate = charge.DueDate
+}
+
+type PaymentReceipt struct {
+	ReceiptNo    int
+	Reference    string
+	FlatNo       int
+	Amount       money.Money
+	Method       string
+	PaidAt       time.Time
+	CollectedBy  string
+	Note         string
+	CreatedBy    string
+	CreatedAt    time.Time
+	CancelledAt  *time.Time
+	CancelledBy  string
+	CancelReason string
+}
+
+func (pr *PaymentReceipt) ToPaymentReceiptModel() models.PaymentReceipt {
+	return models.PaymentReceipt{
+		ReceiptNo:   pr.ReceiptNo,
+		FlatNo:      pr.FlatNo,
+		Amount:      pr.Amount,
+		Method:      pr.Method,
+		PaidAt:      pr.PaidAt,
+		CollectedBy: pr.CollectedBy,
+		Note:        pr.Note,
+		CreatedBy:   pr.CreatedBy,
+	}
+}
+
+func (pr *PaymentReceipt) ToPaymentReceiptServiceObject(receipt models.PaymentReceipt) {
+	pr.ReceiptNo = receipt.ReceiptNo
+	pr.Reference = receipt.Reference()
+	pr.FlatNo = receipt.FlatNo
+	pr.Amount = receipt.Amount
+	pr.Method = receipt.Method
+	pr.PaidAt = receipt.PaidAt
+	pr.CollectedBy = receipt.CollectedBy
+	pr.Note = receipt.Note
+	pr.CreatedBy = receipt.CreatedBy
+	pr.CreatedAt = receipt.CreatedAt
+	pr.CancelledAt = receipt.CancelledAt
+	pr.CancelledBy = receipt.CancelledBy
+	pr.CancelReason = receipt.CancelReason
+}
+
+type BankImport struct {
+	ID         int
+	FileName   string
+	Format     string
+	Lines      int
+	Matched    int
+	Unmatched  int
+	Duplicates int
+	Skipped    int
+	ImportedBy string
+	ImportedAt time.Time
+}
+
+func (bi *BankImport) ToBankImportServiceObject(bankImport models.BankImport) {
+	bi.ID = bankImport.ID
+	bi.FileName = bankImport.FileName
+	bi.Format = bankImport.Format
+	bi.Lines = bankImport.Lines
+	bi.Matched = bankImport.Matched
+	bi.Unmatched = bankImport.Unmatched
+	bi.Duplicates = bankImport.Duplicates
+	bi.Skipped = bankImport.Skipped
+	bi.ImportedBy = bankImport.ImportedBy
+	bi.ImportedAt = bankImport.ImportedAt
+}
+
+type BankTransaction struct {
+	ID           int
+	ImportID     int
+	BankRef      string
+	Date         time.Time
+	Amount       money.Money
+	Currency     string
+	Description  string
+	Counterparty string
+	Status       string
+	FlatNo       *int
+	RuleID       *int
+	ResolvedBy   string
+	ResolvedAt   *time.Time
+}
+
+func (bt *BankTransaction) ToBankTransactionServiceObject(transaction models.BankTransaction) {
+	bt.ID = transaction.ID
+	bt.ImportID = transaction.ImportID
+	bt.BankRef = transaction.BankRef
+	bt.Date = transaction.Date
+	bt.Amount = transaction.Amount
+	bt.Currency = transaction.Currency
+	bt.Description = transaction.Description
+	bt.Counterparty = transaction.Counterparty
+	bt.Status = transaction.Status
+	bt.FlatNo = transaction.FlatNo
+	bt.RuleID = transaction.RuleID
+	bt.ResolvedBy = transaction.ResolvedBy
+	bt.ResolvedAt = transaction.ResolvedAt
+}
+
+type ReferenceRule struct {
+	ID        int
+	Name      string
+	Pattern   string
+	FlatNo    *int
+	Priority  int
+	CreatedBy string
+}
+
+func (rr *ReferenceRule) ToReferenceRuleModel() models.ReferenceRule {
+	return models.ReferenceRule{
+		ID:        rr.ID,
+		Name:      rr.Name,
+		Pattern:   rr.Pattern,
+		FlatNo:    rr.FlatNo,
+		Priority:  rr.Priority,
+		CreatedBy: rr.CreatedBy,
+	}
+}
+
+func (rr *ReferenceRule) ToReferenceRuleServiceObject(rule models.ReferenceRule) {
+	rr.ID = rule.ID
+	rr.Name = rule.Name
+	rr.Pattern = rule.Pattern
+	rr.FlatNo = rule.FlatNo
+	rr.Priority = rule.Priority
+	rr.CreatedBy = rule.CreatedBy
+}
+
+type Meter struct {
+	ID        int
+	FlatNo    int
+	Type      string
+	Serial    string
+	Unit      string
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+func (m *Meter) ToMeterModel() models.Meter {
+	return models.Meter{
+		ID:        m.ID,
+		FlatNo:    m.FlatNo,
+		Type:      m.Type,
+		Serial:    m.Serial,
+		Unit:      m.Unit,
+		CreatedBy: m.CreatedBy,
+	}
+}
+
+func (m *Meter) ToMeterServiceObject(meter models.Meter) {
+	m.ID = meter.ID
+	m.FlatNo = meter.FlatNo
+	m.Type = meter.Type
+	m.Serial = meter.Serial
+	m.Unit = meter.Unit
+	m.CreatedBy = meter.CreatedBy
+	m.CreatedAt = meter.CreatedAt
+}
+
+type MeterReading struct {
+	ID        int
+	MeterID   int
+	ReadAt    time.Time
+	Value     float64
+	Source    string
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+func (r *MeterReading) ToMeterReadingModel() models.MeterReading {
+	return models.MeterReading{
+		ID:        r.ID,
+		MeterID:   r.MeterID,
+		ReadAt:    r.ReadAt,
+		Value:     r.Value,
+		Source:    r.Source,
+		CreatedBy: r.CreatedBy,
+	}
+}
+
+func (r *MeterReading) ToMeterReadingServiceObject(reading models.MeterReading) {
+	r.ID = reading.ID
+	r.MeterID = reading.MeterID
+	r.ReadAt = reading.ReadAt
+	r.Value = reading.Value
+	r.Source = reading.Source
+	r.CreatedBy = reading.CreatedBy
+	r.CreatedAt = reading.CreatedAt
+}
+
+// UtilityBill is a building-level bill split among the flats. CommonShare is
+// a percentage of Total.
+type UtilityBill struct {
+	ID          int
+	Type        string
+	Period      string
+	Total       money.Money
+	CommonShare float64
+	CommonRule  string
+	From        time.Time
+	To          time.Time
+	DueDate     time.Time
+	Note        string
+	CreatedBy   string
+	CreatedAt   time.Time
+	Lines       []UtilityBillLine
+}
+
+func (b *UtilityBill) ToUtilityBillModel() models.UtilityBill {
+	return models.UtilityBill{
+		ID:          b.ID,
+		Type:        b.Type,
+		Period:      b.Period,
+		Total:       b.Total,
+		CommonShare: b.CommonShare,
+		CommonRule:  b.CommonRule,
+		From:        b.From,
+		To:          b.To,
+		DueDate:     b.DueDate,
+		Note:        b.Note,
+		CreatedBy:   b.CreatedBy,
+	}
+}
+
+func (b *UtilityBill) ToUtilityBillServiceObject(bill models.UtilityBill, lines []models.UtilityBillLine) {
+	b.ID = bill.ID
+	b.Type = bill.Type
+	b.Period = bill.Period
+	b.Total = bill.Total
+	b.CommonShare = bill.CommonShare
+	b.CommonRule = bill.CommonRule
+	b.From = bill.From
+	b.To = bill.To
+	b.DueDate = bill.DueDate
+	b.Note = bill.Note
+	b.CreatedBy = bill.CreatedBy
+	b.CreatedAt = bill.CreatedAt
+	b.Lines = []UtilityBillLine{}
+	for _, line := range lines {
+		b.Lines = append(b.Lines, UtilityBillLine{
+			FlatNo:            line.FlatNo,
+			Consumption:       line.Consumption,
+			CommonAmount:      line.CommonAmount,
+			ConsumptionAmount: line.ConsumptionAmount,
+			Amount:            line.Amount,
+		})
+	}
+}
+
+type UtilityBillLine struct {
+	FlatNo            int
+	Consumption       float64
+	CommonAmount      money.Money
+	ConsumptionAmount money.Money
+	Amount            money.Money
+}
+
+// ReminderPolicy says whether overdue dues reminders go out and when:
+// DaysAfterPayDay days after pay day, from 0 for the pay day itself up to 27,
+// and to the same flat at most once every IntervalDays.
+type ReminderPolicy struct {
+	Enabled         bool
+	DaysAfterPayDay int
+	IntervalDays    int
+}
+
+type DuesReminder struct {
+	ID     int
+	FlatNo int
+	Mail   string
+	Amount money.Money
+	Status string
+	Error  string
+	SentAt time.Time
+}
+
+func (r *DuesReminder) ToDuesReminderServiceObject(reminder models.DuesReminder) {
+	r.ID = reminder.ID
+	r.FlatNo = reminder.FlatNo
+	r.Mail = reminder.Mail
+	r.Amount = reminder.Amount
+	r.Status = reminder.Status
+	r.Error = reminder.Error
+	r.SentAt = reminder.SentAt
+}
+
+type ReceiptMail struct {
+	ID          int
+	ReceiptNo   int
+	Mail        string
+	Status      string
+	Attempts    int
+	Error       string
+	NextAttempt time.Time
+	SentAt      *time.Time
+	CreatedAt   time.Time
+}
+
+func (rm *ReceiptMail) ToReceiptMailServiceObject(mail models.ReceiptMail) {
+	rm.ID = mail.ID
+	rm.ReceiptNo = mail.ReceiptNo
+	rm.Mail = mail.Mail
+	rm.Status = mail.Status
+	rm.Attempts = mail.Attempts
+	rm.Error = mail.Error
+	rm.NextAttempt = mail.NextAttempt
+	rm.SentAt = mail.SentAt
+	rm.CreatedAt = mail.CreatedAt
+}
+
+type PaymentTransaction struct {
+	MerchantOID string
+	Provider    string
+	FlatNo      int
+	Email       string
+	Amount      money.Money
+	PaidAmount  money.Money
+	Status      string
+	FailReason  string
+	ReceiptNo   int
+	ReviewedBy  string
+	CreatedAt   time.Time
+	CallbackAt  *time.Time
+
+	RefundedAmount money.Money
+}
+
+func (pt *PaymentTransaction) ToPaymentTransactionServiceObject(transaction models.PaymentTransaction) {
+	pt.MerchantOID = transaction.MerchantOID
+	pt.Provider = transaction.Provider
+	pt.FlatNo = transaction.FlatNo
+	pt.Email = transaction.Email
+	pt.Amount = transaction.Amount
+	pt.PaidAmount = transaction.PaidAmount
+	pt.Status = transaction.Status
+	pt.FailReason = transaction.FailReason
+	pt.ReceiptNo = transaction.ReceiptNo
+	pt.ReviewedBy = transaction.ReviewedBy
+	pt.CreatedAt = transaction.CreatedAt
+	pt.CallbackAt = transaction.CallbackAt
+	pt.RefundedAmount = transaction.RefundedAmount
+}
+
+type PaymentTransactionFilter struct {
+	Status string
+	FlatNo int
+	From   time.Time
+	To     time.Time
+}
+
+func (pf *PaymentTransactionFilter) ToPaymentTransactionFilterModel() models.PaymentTransactionFilter {
+	return models.PaymentTransactionFilter{
+		Status: pf.Status,
+		FlatNo: pf.FlatNo,
+		From:   pf.From,
+		To:     pf.To,
+	}
+}
+
+type PaymentRefund struct {
+	ID          int
+	MerchantOID string
+	Amount      money.Money
+	Reason      string
+	RefundedBy  string
+	Status      string
+	Error       string
+	CreatedAt   time.Time
+}
+
+func (pr *PaymentRefund) ToPaymentRefundServiceObject(refund models.PaymentRefund) {
+	pr.ID = refund.ID
+	pr.MerchantOID = refund.MerchantOID
+	pr.Amount = refund.Amount
+	pr.Reason = refund.Reason
+	pr.RefundedBy = refund.RefundedBy
+	pr.Status = refund.Status
+	pr.Error = refund.Error
+	pr.CreatedAt = refund.CreatedAt
+}
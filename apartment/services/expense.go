@@ -0,0 +1,217 @@
+package services
+
+import (
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+)
+
+// receiptTypes are the receipt file extensions that are accepted, with the
+// content type they are served back as.
+var receiptTypes = map[string]string{
+	".pdf":  "application/pdf",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+}
+
+func (s *service) CreateExpenseCategory(name string) (ExpenseCategory, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ExpenseCategory{}, dto.ExpenseError{Message: "expense category name is required"}
+	}
+
+	category := ExpenseCategory{Name: name}
+	categoryModel := category.ToExpenseCategoryModel()
+	if err := s.Repo.CreateExpenseCategory(&categoryModel); err != nil {
+		return ExpenseCategory{}, err
+	}
+
+	category.ToExpenseCategoryServiceObject(categoryModel)
+	return category, nil
+}
+
+func (s *service) GetExpenseCategories() ([]ExpenseCategory, error) {
+	modelCategories, err := s.Repo.GetExpenseCategories()
+	if err != nil {
+		return nil, err
+	}
+
+	categories := []ExpenseCategory{}
+	for _, modelCategory := range modelCategories {
+		category := ExpenseCategory{}
+		category.ToExpenseCategoryServiceObject(modelCategory)
+		categories = append(categories, category)
+	}
+	return categories, nil
+}
+
+func (s *service) DeleteExpenseCategory(id int) error {
+	return s.Repo.DeleteExpenseCategory(id)
+}
+
+func (s *service) CreateVendor(vendor Vendor) (Vendor, error) {
+	vendor.Name = strings.TrimSpace(vendor.Name)
+	if vendor.Name == "" {
+		return Vendor{}, dto.ExpenseError{Message: "vendor name is required"}
+	}
+
+	vendorModel := vendor.ToVendorModel()
+	if err := s.Repo.CreateVendor(&vendorModel); err != nil {
+		return Vendor{}, err
+	}
+
+	vendor.ToVendorServiceObject(vendorModel)
+	return vendor, nil
+}
+
+func (s *service) GetVendors() ([]Vendor, error) {
+	modelVendors, err := s.Repo.GetVendors()
+	if err != nil {
+		return nil, err
+	}
+
+	vendors := []Vendor{}
+	for _, modelVendor := range modelVendors {
+		vendor := Vendor{}
+		vendor.ToVendorServiceObject(modelVendor)
+		vendors = append(vendors, vendor)
+	}
+	return vendors, nil
+}
+
+func (s *service) UpdateVendor(vendor Vendor) error {
+	vendor.Name = strings.TrimSpace(vendor.Name)
+	if vendor.Name == "" {
+		return dto.ExpenseError{Message: "vendor name is required"}
+	}
+	return s.Repo.UpdateVendor(vendor.ToVendorModel())
+}
+
+func (s *service) DeleteVendor(id int) error {
+	return s.Repo.DeleteVendor(id)
+}
+
+func (s *service) CreateExpense(expense Expense) (Expense, error) {
+	if err := validateExpense(expense); err != nil {
+		return Expense{}, err
+	}
+
+	expenseModel := expense.ToExpenseModel()
+	if err := s.Repo.CreateExpense(&expenseModel); err != nil {
+		return Expense{}, err
+	}
+	return s.GetExpense(expenseModel.ID)
+}
+
+func (s *service) UpdateExpense(expense Expense) error {
+	if err := validateExpense(expense); err != nil {
+		return err
+	}
+	return s.Repo.UpdateExpense(expense.ToExpenseModel())
+}
+
+// DeleteExpense removes the expense together with its receipt. A receipt that
+// cannot be removed is left behind rather than failing the delete.
+func (s *service) DeleteExpense(id int) error {
+	expense, err := s.Repo.DeleteExpense(id)
+	if err != nil {
+		return err
+	}
+
+	if expense.ReceiptFile != "" {
+		s.ReceiptStore.Remove(expense.ReceiptFile)
+	}
+	return nil
+}
+
+func (s *service) GetExpense(id int) (Expense, error) {
+	modelExpense, err := s.Repo.GetExpense(id)
+	if err != nil {
+		return Expense{}, err
+	}
+
+	var expense Expense
+	expense.ToExpenseServiceObject(modelExpense)
+	return expense, nil
+}
+
+func (s *service) GetExpenses(filter ExpenseFilter) ([]Expense, error) {
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		return nil, dto.ExpenseError{Message: "from must not be after to"}
+	}
+
+	modelExpenses, err := s.Repo.GetExpenses(filter.ToExpenseFilterModel())
+	if err != nil {
+		return nil, err
+	}
+
+	expenses := []Expense{}
+	for _, modelExpense := range modelExpenses {
+		expense := Expense{}
+		expense.ToExpenseServiceObject(modelExpense)
+		expenses = append(expenses, expense)
+	}
+	return expenses, nil
+}
+
+// AttachExpenseReceipt stores the uploaded receipt and links it to the
+// expense, replacing the receipt it had before. The file is stored under a
+// generated name; only its extension is taken from the upload.
+func (s *service) AttachExpenseReceipt(id int, fileName string, content io.Reader) error {
+	ext := strings.ToLower(filepath.Ext(fileName))
+	if _, ok := receiptTypes[ext]; !ok {
+		return dto.ExpenseError{Message: "receipt must be a pdf, jpg or png file"}
+	}
+
+	name := "expense-" + strconv.Itoa(id) + "-" + strconv.FormatInt(time.Now().UnixNano(), 10) + ext
+	if err := s.ReceiptStore.Save(name, content); err != nil {
+		return err
+	}
+
+	previous, err := s.Repo.SetExpenseReceipt(id, name)
+	if err != nil {
+		s.ReceiptStore.Remove(name)
+		return err
+	}
+
+	if previous != "" {
+		s.ReceiptStore.Remove(previous)
+	}
+	return nil
+}
+
+// GetExpenseReceipt opens the receipt of the expense and returns it with its
+// file name and content type. The caller closes the reader.
+func (s *service) GetExpenseReceipt(id int) (string, string, io.ReadCloser, error) {
+	expense, err := s.Repo.GetExpense(id)
+	if err != nil {
+		return "", "", nil, err
+	}
+	if expense.ReceiptFile == "" {
+		return "", "", nil, dto.ThereIsNoExpense{Message: "expense has no receipt"}
+	}
+
+	content, err := s.ReceiptStore.Open(expense.ReceiptFile)
+	if err != nil {
+		return "", "", nil, err
+	}
+	return expense.ReceiptFile, receiptTypes[strings.ToLower(filepath.Ext(expense.ReceiptFile))], content, nil
+}
+
+func validateExpense(expense Expense) error {
+	if !expense.Amount.IsPositive() {
+		return dto.ExpenseError{Message: "expense amount must be positive"}
+	}
+	if expense.Date.IsZero() {
+		return dto.ExpenseError{Message: "expense date is required"}
+	}
+	if expense.CategoryID <= 0 {
+		return dto.ExpenseError{Message: "expense category is required"}
+	}
+	return nil
+}
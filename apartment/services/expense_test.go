@@ -0,0 +1,129 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	mocks "github.com/pragmataW/apartment_management/service_mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateExpense(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	date := time.Date(2026, 10, 5, 0, 0, 0, 0, time.Local)
+	repoMock.On("CreateExpense", mock.MatchedBy(func(expense *models.Expense) bool {
+		return expense.CategoryID == 1 && expense.Amount == money.New(150000) && expense.Date.Equal(date)
+	})).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.Expense).ID = 4
+	}).Return(nil)
+	repoMock.On("GetExpense", 4).Return(models.ExpenseRecord{
+		Expense:      models.Expense{ID: 4, CategoryID: 1, Date: date, Amount: money.New(150000), ReceiptFile: ""},
+		CategoryName: "cleaning",
+	}, nil)
+
+	expense, err := service.CreateExpense(Expense{CategoryID: 1, Date: date, Amount: money.New(150000), CreatedBy: "admin"})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, expense.ID)
+	assert.Equal(t, "cleaning", expense.CategoryName)
+	assert.False(t, expense.HasReceipt)
+}
+
+func TestCreateExpense_Invalid(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	_, err := service.CreateExpense(Expense{CategoryID: 1, Date: time.Now(), Amount: money.New(0)})
+	assert.IsType(t, dto.ExpenseError{}, err)
+
+	_, err = service.CreateExpense(Expense{CategoryID: 1, Amount: money.New(1000)})
+	assert.IsType(t, dto.ExpenseError{}, err)
+
+	repoMock.AssertNotCalled(t, "CreateExpense", mock.Anything)
+}
+
+func TestGetExpenses_InvalidRange(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	_, err := service.GetExpenses(ExpenseFilter{
+		From: time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local),
+		To:   time.Date(2026, 9, 1, 0, 0, 0, 0, time.Local),
+	})
+	assert.IsType(t, dto.ExpenseError{}, err)
+}
+
+func TestAttachExpenseReceipt(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	storeMock := new(mocks.IFileStore)
+	service := NewService(WithRepo(repoMock), WithReceiptStore(storeMock))
+
+	var stored string
+	storeMock.On("Save", mock.MatchedBy(func(name string) bool {
+		stored = name
+		return strings.HasPrefix(name, "expense-4-") && strings.HasSuffix(name, ".pdf")
+	}), mock.Anything).Return(nil)
+	repoMock.On("SetExpenseReceipt", 4, mock.AnythingOfType("string")).Return("expense-4-old.png", nil)
+	storeMock.On("Remove", "expense-4-old.png").Return(nil)
+
+	err := service.AttachExpenseReceipt(4, "Fatura.PDF", strings.NewReader("%PDF"))
+	assert.NoError(t, err)
+	repoMock.AssertCalled(t, "SetExpenseReceipt", 4, stored)
+	storeMock.AssertExpectations(t)
+}
+
+func TestAttachExpenseReceipt_Errors(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	storeMock := new(mocks.IFileStore)
+	service := NewService(WithRepo(repoMock), WithReceiptStore(storeMock))
+
+	err := service.AttachExpenseReceipt(4, "receipt.exe", strings.NewReader(""))
+	assert.IsType(t, dto.ExpenseError{}, err)
+	storeMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+
+	storeMock.On("Save", mock.Anything, mock.Anything).Return(nil)
+	repoMock.On("SetExpenseReceipt", 5, mock.Anything).Return("", dto.ThereIsNoExpense{Message: "there is no expense"})
+	storeMock.On("Remove", mock.Anything).Return(nil)
+
+	err = service.AttachExpenseReceipt(5, "receipt.jpg", strings.NewReader(""))
+	assert.IsType(t, dto.ThereIsNoExpense{}, err)
+	storeMock.AssertNumberOfCalls(t, "Remove", 1)
+}
+
+func TestDeleteExpense(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	storeMock := new(mocks.IFileStore)
+	service := NewService(WithRepo(repoMock), WithReceiptStore(storeMock))
+
+	repoMock.On("DeleteExpense", 4).Return(models.Expense{ID: 4, ReceiptFile: "expense-4-1.pdf"}, nil)
+	storeMock.On("Remove", "expense-4-1.pdf").Return(errors.New("permission denied"))
+
+	err := service.DeleteExpense(4)
+	assert.NoError(t, err)
+	storeMock.AssertExpectations(t)
+}
+
+func TestGetExpenseReceipt_NoReceipt(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetExpense", 4).Return(models.ExpenseRecord{Expense: models.Expense{ID: 4}}, nil)
+
+	_, _, _, err := service.GetExpenseReceipt(4)
+	assert.IsType(t, dto.ThereIsNoExpense{}, err)
+}
+
+func TestCreateExpenseCategory_Empty(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	_, err := service.CreateExpenseCategory("  ")
+	assert.IsType(t, dto.ExpenseError{}, err)
+}
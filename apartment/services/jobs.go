@@ -0,0 +1,202 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+)
+
+const (
+	JobDuesAccrual   = "dues_accrual"
+	JobLatePenalties = "late_penalties"
+	JobDuesReminders = "dues_reminders"
+	JobReceiptMails  = "receipt_mails"
+	JobPaymentExpiry = "payment_expiry"
+	JobInstallments  = "assessment_installments"
+
+	latePenaltiesSpec = "0 1 * * *"
+	duesRemindersSpec = "0 9 * * *"
+	receiptMailsSpec  = "*/10 * * * *"
+	paymentExpirySpec = "*/15 * * * *"
+	installmentsSpec  = "0 0 * * *"
+)
+
+func accrualSpec(payDay int) string {
+	return fmt.Sprintf("0 0 %d * *", payDay)
+}
+
+func (s *service) StartScheduledJobs() error {
+	payDay, err := s.GetPayDay()
+	if err != nil {
+		return err
+	}
+
+	if err := s.CatchUpAccruals(time.Now()); err != nil {
+		return err
+	}
+
+	if err := s.ChargeDueInstallments(time.Now()); err != nil {
+		return err
+	}
+
+	if err := s.ApplyLatePenalties(time.Now()); err != nil {
+		return err
+	}
+
+	if err := s.Scheduler.Schedule(JobDuesAccrual, accrualSpec(payDay), s.accrueDues); err != nil {
+		return err
+	}
+
+	if err := s.Scheduler.Schedule(JobInstallments, installmentsSpec, s.chargeDueInstallments); err != nil {
+		return err
+	}
+
+	if err := s.Scheduler.Schedule(JobLatePenalties, latePenaltiesSpec, s.applyLatePenalties); err != nil {
+		return err
+	}
+
+	if err := s.Scheduler.Schedule(JobDuesReminders, duesRemindersSpec, s.sendDuesReminders); err != nil {
+		return err
+	}
+
+	if err := s.Scheduler.Schedule(JobReceiptMails, receiptMailsSpec, s.sendPendingReceiptMails); err != nil {
+		return err
+	}
+
+	if err := s.Scheduler.Schedule(JobPaymentExpiry, paymentExpirySpec, s.expirePaymentTransactions); err != nil {
+		return err
+	}
+
+	s.Scheduler.Start()
+	return nil
+}
+
+func (s *service) StopScheduledJobs() {
+	<-s.Scheduler.Stop().Done()
+}
+
+func (s *service) GetScheduledJobs() []ScheduledJob {
+	var jobs []ScheduledJob
+	for name, next := range s.Scheduler.NextRuns() {
+		jobs = append(jobs, ScheduledJob{Name: name, NextRun: next})
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].Name < jobs[j].Name
+	})
+	return jobs
+}
+
+// rescheduleAccrual moves the dues accrual job to the new pay day. A pay day
+// that has already passed this month would only come round next month, so the
+// current period is caught up right away.
+func (s *service) rescheduleAccrual(payDay int) error {
+	if s.Scheduler == nil {
+		return nil
+	}
+	if err := s.Scheduler.Schedule(JobDuesAccrual, accrualSpec(payDay), s.accrueDues); err != nil {
+		return err
+	}
+	return s.CatchUpAccruals(time.Now())
+}
+
+func (s *service) accrueDues() {
+	err := s.accruePeriod(BillingPeriod(time.Now()))
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// CatchUpAccruals charges every period after the last recorded accrual run
+// whose pay day has already passed at now. Without any recorded run there is
+// nothing to catch up from, so the regular schedule takes over.
+func (s *service) CatchUpAccruals(now time.Time) error {
+	last, err := s.Repo.GetLastAccrualRun()
+	if err != nil {
+		if _, ok := err.(dto.ThereIsNoAccrualRun); ok {
+			return nil
+		}
+		return err
+	}
+
+	payDay, err := s.GetPayDay()
+	if err != nil {
+		return err
+	}
+
+	start, err := PeriodStart(last.Period)
+	if err != nil {
+		return err
+	}
+
+	for month := nextPeriodStart(start); !payDate(month, payDay).After(now); month = nextPeriodStart(month) {
+		period := BillingPeriod(month)
+		err := s.accruePeriod(period)
+		if err != nil {
+			if _, ok := err.(dto.PeriodAlreadyAccrued); ok {
+				continue
+			}
+			return err
+		}
+		log.Println("caught up dues accrual for " + period)
+	}
+	return nil
+}
+
+func (s *service) GetAccrualRuns() ([]AccrualRun, error) {
+	modelRuns, err := s.Repo.GetAccrualRuns()
+	if err != nil {
+		return []AccrualRun{}, err
+	}
+
+	var runs []AccrualRun
+	for _, modelRun := range modelRuns {
+		run := AccrualRun{}
+		run.ToAccrualRunServiceObject(modelRun)
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+func (s *service) accruePeriod(period string) error {
+	price, err := s.duesPriceForPeriod(period)
+	if err != nil {
+		return err
+	}
+
+	payDay, err := s.GetPayDay()
+	if err != nil {
+		return err
+	}
+
+	start, err := PeriodStart(period)
+	if err != nil {
+		return err
+	}
+
+	flats, err := s.Repo.GetAllInfoAboutAllFlats()
+	if err != nil {
+		return err
+	}
+
+	amounts := make(map[int]money.Money, len(flats))
+	for _, flat := range flats {
+		amounts[flat.FlatNo] = DuesAmount(flat, price)
+	}
+
+	run := models.AccrualRun{
+		Period:  period,
+		Amount:  price,
+		DueDate: payDate(start, payDay),
+	}
+	return s.Repo.AddDuesForAll(run, amounts)
+}
+
+func payDate(periodStart time.Time, payDay int) time.Time {
+	return time.Date(periodStart.Year(), periodStart.Month(), payDay, 0, 0, 0, 0, periodStart.Location())
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	mocks "github.com/pragmataW/apartment_management/service_mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestStartScheduledJobs(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	schedulerMock := new(mocks.IScheduler)
+	service := NewService(WithRepo(repoMock), WithScheduler(schedulerMock))
+
+	repoMock.On("GetSetting", models.SettingPayDay, mock.Anything).Return(models.Setting{Value: "20"}, nil)
+	repoMock.On("GetLastAccrualRun").Return(models.AccrualRun{}, dto.ThereIsNoAccrualRun{})
+	repoMock.On("ChargeDueInstallments", mock.Anything).Return(0, nil)
+	repoMock.On("GetSetting", mock.Anything, mock.Anything).Return(models.Setting{}, dto.ThereIsNoSetting{})
+	schedulerMock.On("Schedule", JobDuesAccrual, "0 0 20 * *", mock.AnythingOfType("func()")).Return(nil)
+	schedulerMock.On("Schedule", JobInstallments, "0 0 * * *", mock.AnythingOfType("func()")).Return(nil)
+	schedulerMock.On("Schedule", JobLatePenalties, "0 1 * * *", mock.AnythingOfType("func()")).Return(nil)
+	schedulerMock.On("Schedule", JobDuesReminders, "0 9 * * *", mock.AnythingOfType("func()")).Return(nil)
+	schedulerMock.On("Schedule", JobReceiptMails, "*/10 * * * *", mock.AnythingOfType("func()")).Return(nil)
+	schedulerMock.On("Schedule", JobPaymentExpiry, "*/15 * * * *", mock.AnythingOfType("func()")).Return(nil)
+	schedulerMock.On("Start").Return()
+
+	err := service.StartScheduledJobs()
+	assert.NoError(t, err)
+	schedulerMock.AssertExpectations(t)
+}
+
+func TestStartScheduledJobs_Error(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	schedulerMock := new(mocks.IScheduler)
+	service := NewService(WithRepo(repoMock), WithScheduler(schedulerMock))
+
+	expectedError := errors.New("bad spec")
+	repoMock.On("GetSetting", models.SettingPayDay, mock.Anything).Return(models.Setting{Value: "20"}, nil)
+	repoMock.On("GetLastAccrualRun").Return(models.AccrualRun{}, dto.ThereIsNoAccrualRun{})
+	repoMock.On("ChargeDueInstallments", mock.Anything).Return(0, nil)
+	repoMock.On("GetSetting", mock.Anything, mock.Anything).Return(models.Setting{}, dto.ThereIsNoSetting{})
+	schedulerMock.On("Schedule", JobDuesAccrual, "0 0 20 * *", mock.AnythingOfType("func()")).Return(expectedError)
+
+	err := service.StartScheduledJobs()
+	assert.Equal(t, expectedError, err)
+	schedulerMock.AssertNotCalled(t, "Start")
+}
+
+func TestChangePayDayReschedulesAccrual(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	schedulerMock := new(mocks.IScheduler)
+	service := NewService(WithRepo(repoMock), WithScheduler(schedulerMock))
+
+	repoMock.On("SaveSetting", mock.Anything).Return(nil)
+	repoMock.On("GetLastAccrualRun").Return(models.AccrualRun{}, dto.ThereIsNoAccrualRun{})
+	schedulerMock.On("Schedule", JobDuesAccrual, "0 0 5 * *", mock.AnythingOfType("func()")).Return(nil)
+
+	err := service.ChangePayDay(5, "admin")
+	assert.NoError(t, err)
+	schedulerMock.AssertExpectations(t)
+}
+
+func TestChangePayDayBackwardsAccruesCurrentPeriod(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	schedulerMock := new(mocks.IScheduler)
+	service := NewService(WithRepo(repoMock), WithScheduler(schedulerMock))
+
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	repoMock.On("SaveSetting", mock.Anything).Return(nil)
+	schedulerMock.On("Schedule", JobDuesAccrual, "0 0 1 * *", mock.AnythingOfType("func()")).Return(nil)
+	repoMock.On("GetLastAccrualRun").Return(models.AccrualRun{Period: BillingPeriod(start.AddDate(0, -1, 0))}, nil)
+	repoMock.On("GetSetting", models.SettingPayDay, mock.Anything).Return(models.Setting{Value: "1"}, nil)
+	repoMock.On("GetSetting", models.SettingDuesPrice, mock.Anything).Return(models.Setting{Value: "40.00"}, nil)
+	repoMock.On("GetAllInfoAboutAllFlats").Return([]models.Apartment{{FlatNo: 1, DuesCoefficient: 1}}, nil)
+	repoMock.On("AddDuesForAll", models.AccrualRun{Period: BillingPeriod(now), Amount: money.New(4000), DueDate: start}, map[int]money.Money{1: money.New(4000)}).Return(nil)
+
+	err := service.ChangePayDay(1, "admin")
+	assert.NoError(t, err)
+	repoMock.AssertExpectations(t)
+	repoMock.AssertNumberOfCalls(t, "AddDuesForAll", 1)
+}
+
+func TestGetScheduledJobs(t *testing.T) {
+	schedulerMock := new(mocks.IScheduler)
+	service := NewService(WithScheduler(schedulerMock))
+
+	next := time.Date(2026, 11, 15, 0, 0, 0, 0, time.UTC)
+	schedulerMock.On("NextRuns").Return(map[string]time.Time{
+		JobDuesAccrual: next,
+		"another_job":  next.Add(time.Hour),
+	})
+
+	jobs := service.GetScheduledJobs()
+	assert.Len(t, jobs, 2)
+	assert.Equal(t, "another_job", jobs[0].Name)
+	assert.Equal(t, JobDuesAccrual, jobs[1].Name)
+	assert.Equal(t, next, jobs[1].NextRun)
+}
+
+func TestAccrueDues(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	now := time.Now()
+	dueDate := time.Date(now.Year(), now.Month(), 20, 0, 0, 0, 0, time.Local)
+	repoMock.On("GetSetting", models.SettingDuesPrice, mock.Anything).Return(models.Setting{Value: "45.00"}, nil)
+	repoMock.On("GetSetting", models.SettingPayDay, mock.Anything).Return(models.Setting{Value: "20"}, nil)
+	repoMock.On("GetAllInfoAboutAllFlats").Return([]models.Apartment{
+		{FlatNo: 1, DuesCoefficient: 1},
+		{FlatNo: 2, DuesCoefficient: 1.5},
+	}, nil)
+	run := models.AccrualRun{Period: BillingPeriod(now), Amount: money.New(4500), DueDate: dueDate}
+	repoMock.On("AddDuesForAll", run, map[int]money.Money{1: money.New(4500), 2: money.New(6750)}).Return(nil)
+
+	service.accrueDues()
+	repoMock.AssertExpectations(t)
+}
+
+func TestCatchUpAccruals(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local)
+	repoMock.On("GetLastAccrualRun").Return(models.AccrualRun{Period: "2026-07"}, nil)
+	repoMock.On("GetSetting", models.SettingPayDay, mock.Anything).Return(models.Setting{Value: "15"}, nil)
+	repoMock.On("GetSetting", models.SettingDuesPrice, mock.Anything).Return(models.Setting{Value: "40.00"}, nil)
+	repoMock.On("GetAllInfoAboutAllFlats").Return([]models.Apartment{{FlatNo: 1, DuesCoefficient: 1}}, nil)
+	amounts := map[int]money.Money{1: money.New(4000)}
+	repoMock.On("AddDuesForAll", models.AccrualRun{Period: "2026-08", Amount: money.New(4000), DueDate: time.Date(2026, 8, 15, 0, 0, 0, 0, time.Local)}, amounts).Return(nil)
+	repoMock.On("AddDuesForAll", models.AccrualRun{Period: "2026-09", Amount: money.New(4000), DueDate: time.Date(2026, 9, 15, 0, 0, 0, 0, time.Local)}, amounts).Return(dto.PeriodAlreadyAccrued{})
+	repoMock.On("AddDuesForAll", models.AccrualRun{Period: "2026-10", Amount: money.New(4000), DueDate: time.Date(2026, 10, 15, 0, 0, 0, 0, time.Local)}, amounts).Return(nil)
+
+	err := service.CatchUpAccruals(now)
+	assert.NoError(t, err)
+	repoMock.AssertExpectations(t)
+	repoMock.AssertNumberOfCalls(t, "AddDuesForAll", 3)
+}
+
+func TestCatchUpAccrualsBeforePayDay(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	now := time.Date(2026, 10, 14, 9, 0, 0, 0, time.Local)
+	repoMock.On("GetLastAccrualRun").Return(models.AccrualRun{Period: "2026-09"}, nil)
+	repoMock.On("GetSetting", models.SettingPayDay, mock.Anything).Return(models.Setting{Value: "15"}, nil)
+
+	err := service.CatchUpAccruals(now)
+	assert.NoError(t, err)
+	repoMock.AssertNotCalled(t, "AddDuesForAll", mock.Anything, mock.Anything)
+}
+
+func TestCatchUpAccrualsWithoutHistory(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetLastAccrualRun").Return(models.AccrualRun{}, dto.ThereIsNoAccrualRun{})
+
+	err := service.CatchUpAccruals(time.Now())
+	assert.NoError(t, err)
+	repoMock.AssertNotCalled(t, "AddDuesForAll", mock.Anything, mock.Anything)
+}
+
+func TestGetAccrualRuns(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetAccrualRuns").Return([]models.AccrualRun{
+		{Period: "2026-10", Amount: money.New(4000), FlatCount: 12},
+		{Period: "2026-09", Amount: money.New(4000), FlatCount: 12},
+	}, nil)
+
+	runs, err := service.GetAccrualRuns()
+	assert.NoError(t, err)
+	assert.Len(t, runs, 2)
+	assert.Equal(t, "2026-10", runs[0].Period)
+	assert.Equal(t, 12, runs[0].FlatCount)
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+)
+
+// BillingPeriod returns the billing period a point in time belongs to, e.g. "2026-10".
+func BillingPeriod(t time.Time) string {
+	return t.Format("2006-01")
+}
+
+// DuesAmount is what a flat owes for one month at the given base price: its
+// fixed override when one is set, otherwise the price scaled by its coefficient
+// and rounded to the kuruş.
+func DuesAmount(flat models.Apartment, price money.Money) money.Money {
+	if flat.DuesOverride != nil {
+		return *flat.DuesOverride
+	}
+	return price.Mul(flat.DuesCoefficient)
+}
+
+// GetPaymentAmount is what the flat of the email pays online for the given
+// number of months of dues, late fees included. Only dues accruals count as
+// months; as a payment settles open charges oldest first, any other charge
+// older than the last month paid, such as an assessment installment or a
+// utility bill, is part of the amount too. Months beyond the open ones are
+// paid in advance at the current dues. With payAll the whole open balance is
+// paid instead.
+func (s *service) GetPaymentAmount(email string, months int, payAll bool) (money.Money, error) {
+	flat, err := s.Repo.GetFlatByEmail(email)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	groups, err := s.Repo.GetOpenChargeGroups(flat.FlatNo)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	amount := money.New(0)
+	if payAll {
+		if len(groups) == 0 {
+			return money.Money{}, dto.ThereIsNoDues{Message: "there is no dues"}
+		}
+		for _, group := range groups {
+			amount = amount.Add(group.Amount)
+		}
+		return amount, nil
+	}
+	if months < 1 {
+		months = 1
+	}
+
+	paid := 0
+	for _, group := range groups {
+		if paid == months {
+			break
+		}
+		amount = amount.Add(group.Amount)
+		if group.Type == models.LedgerAccrual {
+			paid++
+		}
+	}
+
+	if paid < months {
+		price, err := s.duesPriceForPeriod(BillingPeriod(time.Now()))
+		if err != nil {
+			return money.Money{}, err
+		}
+		amount = amount.Add(DuesAmount(flat, price).Mul(float64(months - paid)))
+	}
+
+	return amount, nil
+}
+
+func (s *service) GetBalanceByEmail(email string) (AccountBalance, error) {
+	flat, err := s.Repo.GetFlatByEmail(email)
+	if err != nil {
+		return AccountBalance{}, err
+	}
+
+	summary, err := s.Repo.GetDuesSummary(flat.FlatNo)
+	if err != nil {
+		return AccountBalance{}, err
+	}
+
+	price, err := s.duesPriceForPeriod(BillingPeriod(time.Now()))
+	if err != nil {
+		return AccountBalance{}, err
+	}
+
+	var balance AccountBalance
+	balance.ToAccountBalanceServiceObject(summary)
+	balance.MonthlyDues = DuesAmount(flat, price)
+	return balance, nil
+}
+
+func (s *service) UpdateDuesProfile(apartment Apartment) error {
+	err := s.Repo.UpdateDuesProfile(apartment.ToApartmentModel())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *service) GetLedger(flatNo int) (Ledger, error) {
+	modelEntries, err := s.Repo.GetLedgerEntries(flatNo)
+	if err != nil {
+		return Ledger{}, err
+	}
+
+	ledger := Ledger{FlatNo: flatNo, Balance: money.New(0)}
+	for _, modelEntry := range modelEntries {
+		entry := LedgerEntry{}
+		entry.ToLedgerEntryServiceObject(modelEntry)
+		ledger.Entries = append(ledger.Entries, entry)
+		ledger.Balance = ledger.Balance.Add(entry.Amount)
+	}
+
+	return ledger, nil
+}
+
+func (s *service) AddLedgerAdjustment(flatNo int, amount money.Money, note string) error {
+	entry := models.LedgerEntry{
+		FlatNo: flatNo,
+		Period: BillingPeriod(time.Now()),
+		Type:   models.LedgerAdjustment,
+		Amount: amount,
+		Source: models.SourceAdmin,
+		Note:   note,
+	}
+
+	err := s.Repo.AddLedgerEntry(entry)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	mocks "github.com/pragmataW/apartment_management/service_mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBillingPeriod(t *testing.T) {
+	assert.Equal(t, "2026-10", BillingPeriod(time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "2027-01", BillingPeriod(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestGetLedger(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	flatNo := 1
+	modelEntries := []models.LedgerEntry{
+		{ID: 1, FlatNo: flatNo, Period: "2026-09", Type: models.LedgerAccrual, Amount: money.New(4000), Source: models.SourceScheduler},
+		{ID: 2, FlatNo: flatNo, Period: "2026-10", Type: models.LedgerAccrual, Amount: money.New(4000), Source: models.SourceScheduler},
+		{ID: 3, FlatNo: flatNo, Period: "2026-09", Type: models.LedgerPayment, Amount: money.New(-4000), Source: models.SourcePaytr, Reference: "oid"},
+	}
+	repoMock.On("GetLedgerEntries", flatNo).Return(modelEntries, nil)
+
+	ledger, err := service.GetLedger(flatNo)
+	assert.NoError(t, err)
+	assert.Equal(t, flatNo, ledger.FlatNo)
+	assert.Equal(t, money.New(4000), ledger.Balance)
+	assert.Len(t, ledger.Entries, 3)
+	assert.Equal(t, "oid", ledger.Entries[2].Reference)
+	repoMock.AssertExpectations(t)
+}
+
+func TestGetLedger_Error(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	expectedError := errors.New("some error")
+	repoMock.On("GetLedgerEntries", 1).Return(nil, expectedError)
+
+	_, err := service.GetLedger(1)
+	assert.Equal(t, expectedError, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestAddLedgerAdjustment(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("AddLedgerEntry", mock.MatchedBy(func(entry models.LedgerEntry) bool {
+		return entry.FlatNo == 1 &&
+			entry.Type == models.LedgerAdjustment &&
+			entry.Amount == money.New(-1500) &&
+			entry.Source == models.SourceAdmin &&
+			entry.Note == "water leak refund"
+	})).Return(nil)
+
+	err := service.AddLedgerAdjustment(1, money.New(-1500), "water leak refund")
+	assert.NoError(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestDuesAmount(t *testing.T) {
+	override := money.New(0)
+	assert.Equal(t, money.New(4000), DuesAmount(models.Apartment{DuesCoefficient: 1}, money.New(4000)))
+	assert.Equal(t, money.New(5333), DuesAmount(models.Apartment{DuesCoefficient: 1.3333}, money.New(4000)))
+	assert.Equal(t, money.New(0), DuesAmount(models.Apartment{DuesCoefficient: 1, DuesOverride: &override}, money.New(4000)))
+}
+
+func TestGetPaymentAmount(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetFlatByEmail", "shop@mail.com").Return(models.Apartment{FlatNo: 1, FlatType: models.FlatShop, DuesCoefficient: 2.5}, nil)
+	repoMock.On("GetOpenChargeGroups", 1).Return([]models.ChargeGroup{
+		{Type: models.LedgerAccrual, Period: "2026-08", Amount: money.New(10400)},
+		{Type: models.LedgerAccrual, Period: "2026-09", Amount: money.New(10000)},
+		{Type: models.LedgerAccrual, Period: "2026-10", Amount: money.New(10000)},
+	}, nil)
+
+	amount, err := service.GetPaymentAmount("shop@mail.com", 0, false)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(10400), amount)
+
+	amount, err = service.GetPaymentAmount("shop@mail.com", 2, false)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(20400), amount)
+
+	amount, err = service.GetPaymentAmount("shop@mail.com", 0, true)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(30400), amount)
+	repoMock.AssertNotCalled(t, "GetSetting", mock.Anything, mock.Anything)
+}
+
+func TestGetPaymentAmountInAdvance(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetFlatByEmail", "shop@mail.com").Return(models.Apartment{FlatNo: 1, FlatType: models.FlatShop, DuesCoefficient: 2.5}, nil)
+	repoMock.On("GetOpenChargeGroups", 1).Return([]models.ChargeGroup{{Type: models.LedgerAccrual, Period: "2026-10", Amount: money.New(10400)}}, nil)
+	repoMock.On("GetSetting", models.SettingDuesPrice, mock.Anything).Return(models.Setting{Value: "40.00"}, nil)
+
+	amount, err := service.GetPaymentAmount("shop@mail.com", 3, false)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(30400), amount)
+}
+
+func TestGetPaymentAmountCountsOnlyDuesAsMonths(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetFlatByEmail", "flat@mail.com").Return(models.Apartment{FlatNo: 1, DuesCoefficient: 1}, nil)
+	repoMock.On("GetOpenChargeGroups", 1).Return([]models.ChargeGroup{
+		{Type: models.LedgerAssessment, Period: "2026-08", Amount: money.New(25000)},
+		{Type: models.LedgerAccrual, Period: "2026-09", Amount: money.New(4000)},
+		{Type: models.LedgerUtility, Period: "2026-09", Amount: money.New(1500)},
+		{Type: models.LedgerAccrual, Period: "2026-10", Amount: money.New(4000)},
+		{Type: models.LedgerUtility, Period: "2026-10", Amount: money.New(1500)},
+	}, nil)
+
+	amount, err := service.GetPaymentAmount("flat@mail.com", 1, false)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(29000), amount)
+
+	amount, err = service.GetPaymentAmount("flat@mail.com", 2, false)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(34500), amount)
+}
+
+func TestGetPaymentAmountPayAllWithoutDues(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetFlatByEmail", "shop@mail.com").Return(models.Apartment{FlatNo: 1, DuesCoefficient: 1}, nil)
+	repoMock.On("GetOpenChargeGroups", 1).Return(nil, nil)
+
+	_, err := service.GetPaymentAmount("shop@mail.com", 0, true)
+	assert.IsType(t, dto.ThereIsNoDues{}, err)
+}
+
+func TestUpdateDuesProfile(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	apartment := Apartment{FlatNo: 1, FlatType: models.FlatDuplex, Area: 180, DuesCoefficient: 1.8}
+	repoMock.On("UpdateDuesProfile", apartment.ToApartmentModel()).Return(nil)
+
+	err := service.UpdateDuesProfile(apartment)
+	assert.NoError(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestUpdateDuesProfile_Error(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	expectedError := errors.New("some error")
+	repoMock.On("UpdateDuesProfile", mock.Anything).Return(expectedError)
+
+	err := service.UpdateDuesProfile(Apartment{FlatNo: 1})
+	assert.Equal(t, expectedError, err)
+}
+
+func TestGetBalanceByEmail(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetFlatByEmail", "deneme@mail.com").Return(models.Apartment{FlatNo: 2, DuesCoefficient: 1}, nil)
+	repoMock.On("GetDuesSummary", 2).Return(models.DuesSummary{FlatNo: 2, Balance: money.New(-36000), Credit: money.New(36000)}, nil)
+	repoMock.On("GetSetting", models.SettingDuesPrice, mock.Anything).Return(models.Setting{Value: "40.00"}, nil)
+
+	balance, err := service.GetBalanceByEmail("deneme@mail.com")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, balance.FlatNo)
+	assert.Equal(t, money.New(36000), balance.Credit)
+	assert.Equal(t, money.New(-36000), balance.Balance)
+	assert.Equal(t, money.New(4000), balance.MonthlyDues)
+}
+
+func TestGetBalanceByEmail_Error(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetFlatByEmail", "nobody@mail.com").Return(models.Apartment{}, dto.ThereIsNoFlat{})
+
+	_, err := service.GetBalanceByEmail("nobody@mail.com")
+	assert.IsType(t, dto.ThereIsNoFlat{}, err)
+}
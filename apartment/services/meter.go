@@ -0,0 +1,412 @@
+package services
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+)
+
+// meterUnits is the unit a meter of each type is read in unless another one
+// is given.
+var meterUnits = map[string]string{
+	models.MeterWater: "m3",
+	models.MeterHeat:  "kWh",
+	models.MeterGas:   "m3",
+	models.MeterPower: "kWh",
+}
+
+func (s *service) CreateMeter(meter Meter) (Meter, error) {
+	meter.Serial = strings.TrimSpace(meter.Serial)
+	if meter.Serial == "" {
+		return Meter{}, dto.MeterError{Message: "meter serial is required"}
+	}
+	unit, ok := meterUnits[meter.Type]
+	if !ok {
+		return Meter{}, dto.MeterError{Message: "meter type must be water, heat, gas or electricity"}
+	}
+	if meter.Unit == "" {
+		meter.Unit = unit
+	}
+
+	modelMeter := meter.ToMeterModel()
+	if err := s.Repo.CreateMeter(&modelMeter); err != nil {
+		return Meter{}, err
+	}
+
+	meter.ToMeterServiceObject(modelMeter)
+	return meter, nil
+}
+
+func (s *service) GetMeters(flatNo int) ([]Meter, error) {
+	modelMeters, err := s.Repo.GetMeters(flatNo)
+	if err != nil {
+		return nil, err
+	}
+
+	meters := []Meter{}
+	for _, modelMeter := range modelMeters {
+		meter := Meter{}
+		meter.ToMeterServiceObject(modelMeter)
+		meters = append(meters, meter)
+	}
+	return meters, nil
+}
+
+func (s *service) DeleteMeter(id int) error {
+	return s.Repo.DeleteMeter(id)
+}
+
+// AddMeterReading records what the meter showed on ReadAt, today when it is
+// not given. Readings may not be dated in the future or go below an earlier
+// reading of the same meter.
+func (s *service) AddMeterReading(reading MeterReading) (MeterReading, error) {
+	if reading.ReadAt.IsZero() {
+		reading.ReadAt = time.Now()
+	}
+	if err := checkReading(reading); err != nil {
+		return MeterReading{}, err
+	}
+	reading.ReadAt = readingDay(reading.ReadAt)
+	if reading.Source == "" {
+		reading.Source = models.SourceAdmin
+	}
+
+	modelReadings := []models.MeterReading{reading.ToMeterReadingModel()}
+	if err := s.Repo.AddMeterReadings(modelReadings); err != nil {
+		return MeterReading{}, err
+	}
+
+	reading.ToMeterReadingServiceObject(modelReadings[0])
+	return reading, nil
+}
+
+func (s *service) GetMeterReadings(meterID int) ([]MeterReading, error) {
+	if _, err := s.Repo.GetMeter(meterID); err != nil {
+		return nil, err
+	}
+
+	modelReadings, err := s.Repo.GetMeterReadings(meterID)
+	if err != nil {
+		return nil, err
+	}
+
+	readings := []MeterReading{}
+	for _, modelReading := range modelReadings {
+		reading := MeterReading{}
+		reading.ToMeterReadingServiceObject(modelReading)
+		readings = append(readings, reading)
+	}
+	return readings, nil
+}
+
+// ImportMeterReadings reads a CSV file with serial, date and value columns,
+// in any order and separated by commas or semicolons. Dates look like
+// 2026-10-01 or 01.10.2026 and a value may use a decimal comma. The readings
+// are stored all together or, when any of them is wrong, not at all. It
+// returns how many readings were stored.
+func (s *service) ImportMeterReadings(content io.Reader, importedBy string) (int, error) {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return 0, err
+	}
+	text := strings.TrimPrefix(string(data), "\ufeff")
+
+	reader := csv.NewReader(strings.NewReader(text))
+	if header, _, _ := strings.Cut(text, "\n"); strings.Contains(header, ";") {
+		reader.Comma = ';'
+	}
+	reader.TrimLeadingSpace = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return 0, dto.MeterError{Message: "readings file is not valid csv: " + err.Error()}
+	}
+	if len(rows) < 2 {
+		return 0, dto.MeterError{Message: "readings file has no readings"}
+	}
+
+	columns := map[string]int{}
+	for i, name := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"serial", "date", "value"} {
+		if _, ok := columns[name]; !ok {
+			return 0, dto.MeterError{Message: "readings file has no " + name + " column"}
+		}
+	}
+
+	modelMeters, err := s.Repo.GetMeters(0)
+	if err != nil {
+		return 0, err
+	}
+	meters := map[string]int{}
+	for _, meter := range modelMeters {
+		meters[meter.Serial] = meter.ID
+	}
+
+	var readings []models.MeterReading
+	for n, row := range rows[1:] {
+		line := n + 2
+		if len(row) <= columns["serial"] || len(row) <= columns["date"] || len(row) <= columns["value"] {
+			return 0, dto.MeterError{Message: fmt.Sprintf("line %d: missing columns", line)}
+		}
+
+		serial := strings.TrimSpace(row[columns["serial"]])
+		meterID, ok := meters[serial]
+		if !ok {
+			return 0, dto.MeterError{Message: fmt.Sprintf("line %d: there is no meter with serial %s", line, serial)}
+		}
+		readAt, err := parseReadingDate(row[columns["date"]])
+		if err != nil {
+			return 0, dto.MeterError{Message: fmt.Sprintf("line %d: %v", line, err)}
+		}
+		value, err := parseReadingValue(row[columns["value"]])
+		if err != nil {
+			return 0, dto.MeterError{Message: fmt.Sprintf("line %d: %v", line, err)}
+		}
+
+		reading := MeterReading{MeterID: meterID, ReadAt: readAt, Value: value, Source: models.SourceImport, CreatedBy: importedBy}
+		if err := checkReading(reading); err != nil {
+			return 0, dto.MeterError{Message: fmt.Sprintf("line %d: %v", line, err)}
+		}
+		readings = append(readings, reading.ToMeterReadingModel())
+	}
+
+	if err := s.Repo.AddMeterReadings(readings); err != nil {
+		return 0, err
+	}
+	return len(readings), nil
+}
+
+// CreateUtilityBill splits a building-level bill among the flats and charges
+// each its part. CommonShare percent of the total is split by CommonRule,
+// equal by default; the rest is split by how much each flat's meters of the
+// bill's type moved between their last readings on or before From and To.
+// Every such meter needs both readings. Amounts are split in kuruş so that
+// the charges add up to the total exactly.
+func (s *service) CreateUtilityBill(bill UtilityBill) (UtilityBill, error) {
+	if _, ok := meterUnits[bill.Type]; !ok {
+		return UtilityBill{}, dto.MeterError{Message: "bill type must be water, heat, gas or electricity"}
+	}
+	if !bill.Total.IsPositive() {
+		return UtilityBill{}, dto.MeterError{Message: "bill total must be positive"}
+	}
+	if bill.CommonShare < 0 || bill.CommonShare > 100 {
+		return UtilityBill{}, dto.MeterError{Message: "common share must be between 0 and 100"}
+	}
+	if bill.CommonRule == "" {
+		bill.CommonRule = models.SplitEqual
+	}
+	if bill.From.IsZero() || bill.To.IsZero() || !bill.From.Before(bill.To) {
+		return UtilityBill{}, dto.MeterError{Message: "from must be before to"}
+	}
+	bill.From = readingDay(bill.From)
+	bill.To = readingDay(bill.To)
+	if bill.Period == "" {
+		bill.Period = BillingPeriod(bill.To)
+	}
+	if !monthPattern.MatchString(bill.Period) {
+		return UtilityBill{}, dto.MeterError{Message: "period must look like 2026-10"}
+	}
+	if bill.DueDate.IsZero() {
+		bill.DueDate = time.Now()
+	}
+
+	flats, err := s.Repo.GetAllInfoAboutAllFlats()
+	if err != nil {
+		return UtilityBill{}, err
+	}
+	if len(flats) == 0 {
+		return UtilityBill{}, dto.MeterError{Message: "there are no flats to bill"}
+	}
+	sort.Slice(flats, func(i, j int) bool {
+		return flats[i].FlatNo < flats[j].FlatNo
+	})
+
+	commonWeights, ok := ruleWeights(flats, bill.CommonRule)
+	if !ok {
+		return UtilityBill{}, dto.MeterError{Message: "unknown split rule: " + bill.CommonRule}
+	}
+
+	consumption, err := s.flatConsumption(bill.Type, bill.From, bill.To)
+	if err != nil {
+		return UtilityBill{}, err
+	}
+	usageWeights := make([]float64, len(flats))
+	for i, flat := range flats {
+		usageWeights[i] = consumption[flat.FlatNo]
+	}
+
+	common := bill.Total.Mul(bill.CommonShare / 100)
+	usage := bill.Total.Sub(common)
+
+	commonParts := common.Allocate(commonWeights)
+	if commonParts == nil {
+		if common.IsPositive() {
+			return UtilityBill{}, dto.MeterError{Message: "there is nothing to split the common share by"}
+		}
+		commonParts = common.Allocate(equalWeights(len(flats)))
+	}
+	usageParts := usage.Allocate(usageWeights)
+	if usageParts == nil {
+		if usage.IsPositive() {
+			return UtilityBill{}, dto.MeterError{Message: "no " + bill.Type + " was used between " + bill.From.Format("2006-01-02") + " and " + bill.To.Format("2006-01-02")}
+		}
+		usageParts = usage.Allocate(equalWeights(len(flats)))
+	}
+
+	var lines []models.UtilityBillLine
+	var charges []models.LedgerEntry
+	for i, flat := range flats {
+		line := models.UtilityBillLine{
+			FlatNo:            flat.FlatNo,
+			Consumption:       usageWeights[i],
+			CommonAmount:      commonParts[i],
+			ConsumptionAmount: usageParts[i],
+			Amount:            commonParts[i].Add(usageParts[i]),
+		}
+		lines = append(lines, line)
+		if !line.Amount.IsPositive() {
+			continue
+		}
+
+		charges = append(charges, models.LedgerEntry{
+			FlatNo:  flat.FlatNo,
+			Period:  bill.Period,
+			Type:    models.LedgerUtility,
+			Amount:  line.Amount,
+			Source:  models.SourceAdmin,
+			Note:    fmt.Sprintf("%s bill for %s, %s used", bill.Type, bill.Period, strconv.FormatFloat(line.Consumption, 'f', -1, 64)),
+			DueDate: bill.DueDate,
+		})
+	}
+
+	modelBill := bill.ToUtilityBillModel()
+	if err := s.Repo.CreateUtilityBill(&modelBill, lines, charges); err != nil {
+		return UtilityBill{}, err
+	}
+
+	bill.ToUtilityBillServiceObject(modelBill, lines)
+	return bill, nil
+}
+
+func (s *service) GetUtilityBills() ([]UtilityBill, error) {
+	modelBills, err := s.Repo.GetUtilityBills()
+	if err != nil {
+		return nil, err
+	}
+
+	bills := []UtilityBill{}
+	for _, modelBill := range modelBills {
+		bill := UtilityBill{}
+		bill.ToUtilityBillServiceObject(modelBill, nil)
+		bills = append(bills, bill)
+	}
+	return bills, nil
+}
+
+func (s *service) GetUtilityBill(id int) (UtilityBill, error) {
+	modelBill, lines, err := s.Repo.GetUtilityBill(id)
+	if err != nil {
+		return UtilityBill{}, err
+	}
+
+	bill := UtilityBill{}
+	bill.ToUtilityBillServiceObject(modelBill, lines)
+	return bill, nil
+}
+
+// flatConsumption adds up, per flat, how far its meters of the type moved
+// between their last readings on or before from and on or before to.
+func (s *service) flatConsumption(meterType string, from time.Time, to time.Time) (map[int]float64, error) {
+	modelMeters, err := s.Repo.GetMeters(0)
+	if err != nil {
+		return nil, err
+	}
+	opening, err := s.Repo.GetLatestReadings(meterType, from)
+	if err != nil {
+		return nil, err
+	}
+	closing, err := s.Repo.GetLatestReadings(meterType, to)
+	if err != nil {
+		return nil, err
+	}
+
+	openingByMeter := map[int]models.MeterReading{}
+	for _, reading := range opening {
+		openingByMeter[reading.MeterID] = reading
+	}
+	closingByMeter := map[int]models.MeterReading{}
+	for _, reading := range closing {
+		closingByMeter[reading.MeterID] = reading
+	}
+
+	consumption := map[int]float64{}
+	for _, meter := range modelMeters {
+		if meter.Type != meterType {
+			continue
+		}
+		start, ok := openingByMeter[meter.ID]
+		if !ok {
+			return nil, dto.MeterError{Message: "meter " + meter.Serial + " has no reading on or before " + from.Format("2006-01-02")}
+		}
+		end, ok := closingByMeter[meter.ID]
+		if !ok || !end.ReadAt.After(start.ReadAt) {
+			return nil, dto.MeterError{Message: "meter " + meter.Serial + " has no reading after " + start.ReadAt.Format("2006-01-02") + " on or before " + to.Format("2006-01-02")}
+		}
+		consumption[meter.FlatNo] += end.Value - start.Value
+	}
+	return consumption, nil
+}
+
+func checkReading(reading MeterReading) error {
+	if reading.Value < 0 {
+		return dto.MeterError{Message: "reading must not be negative"}
+	}
+	if readingDay(reading.ReadAt).After(time.Now()) {
+		return dto.MeterError{Message: "reading must not be dated in the future"}
+	}
+	return nil
+}
+
+// readingDay drops the time of day; a meter is read at most once a day.
+func readingDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+func parseReadingDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{"2006-01-02", "02.01.2006"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("date " + value + " must look like 2026-10-01 or 01.10.2026")
+}
+
+func parseReadingValue(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if !strings.Contains(value, ".") {
+		value = strings.Replace(value, ",", ".", 1)
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, errors.New("value " + value + " is not a number")
+	}
+	return number, nil
+}
+
+func equalWeights(n int) []float64 {
+	weights := make([]float64, n)
+	for i := range weights {
+		weights[i] = 1
+	}
+	return weights
+}
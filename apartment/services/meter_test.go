@@ -0,0 +1,128 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	mocks "github.com/pragmataW/apartment_management/service_mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateMeterDefaultsUnit(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("CreateMeter", mock.MatchedBy(func(meter *models.Meter) bool {
+		return meter.Serial == "W-1" && meter.Unit == "m3"
+	})).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.Meter).ID = 4
+	}).Return(nil)
+
+	meter, err := service.CreateMeter(Meter{FlatNo: 1, Type: models.MeterWater, Serial: " W-1 "})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, meter.ID)
+	assert.Equal(t, "m3", meter.Unit)
+
+	_, err = service.CreateMeter(Meter{FlatNo: 1, Type: "steam", Serial: "S-1"})
+	assert.IsType(t, dto.MeterError{}, err)
+}
+
+func TestAddMeterReadingRejectsFutureDate(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	_, err := service.AddMeterReading(MeterReading{MeterID: 1, Value: 10, ReadAt: time.Now().AddDate(0, 0, 2)})
+	assert.IsType(t, dto.MeterError{}, err)
+
+	_, err = service.AddMeterReading(MeterReading{MeterID: 1, Value: -1})
+	assert.IsType(t, dto.MeterError{}, err)
+	repoMock.AssertNotCalled(t, "AddMeterReadings", mock.Anything)
+}
+
+func TestImportMeterReadings(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetMeters", 0).Return([]models.Meter{
+		{ID: 1, FlatNo: 1, Type: models.MeterWater, Serial: "W-1"},
+		{ID: 2, FlatNo: 2, Type: models.MeterWater, Serial: "W-2"},
+	}, nil)
+	repoMock.On("AddMeterReadings", mock.MatchedBy(func(readings []models.MeterReading) bool {
+		return len(readings) == 2 &&
+			readings[0].MeterID == 1 && readings[0].Value == 123.5 && readings[0].Source == models.SourceImport &&
+			readings[1].MeterID == 2 && readings[1].ReadAt.Format("2006-01-02") == "2026-09-30" &&
+			readings[1].CreatedBy == "admin"
+	})).Return(nil)
+
+	imported, err := service.ImportMeterReadings(strings.NewReader("serial;date;value\nW-1;2026-09-30;123,5\nW-2;30.09.2026;88\n"), "admin")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, imported)
+
+	_, err = service.ImportMeterReadings(strings.NewReader("serial,date,value\nW-1,2026-09-30,1\nX-9,2026-09-30,2\n"), "admin")
+	assert.EqualError(t, err, "line 3: there is no meter with serial X-9")
+}
+
+func TestCreateUtilityBill(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	from := time.Date(2026, 9, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2026, 9, 30, 0, 0, 0, 0, time.Local)
+
+	repoMock.On("GetAllInfoAboutAllFlats").Return([]models.Apartment{{FlatNo: 3}, {FlatNo: 1}, {FlatNo: 2}}, nil)
+	repoMock.On("GetMeters", 0).Return([]models.Meter{
+		{ID: 1, FlatNo: 1, Type: models.MeterWater, Serial: "W-1"},
+		{ID: 2, FlatNo: 2, Type: models.MeterWater, Serial: "W-2"},
+		{ID: 3, FlatNo: 2, Type: models.MeterHeat, Serial: "H-2"},
+	}, nil)
+	repoMock.On("GetLatestReadings", models.MeterWater, from).Return([]models.MeterReading{
+		{MeterID: 1, ReadAt: from, Value: 100},
+		{MeterID: 2, ReadAt: from, Value: 50},
+	}, nil)
+	repoMock.On("GetLatestReadings", models.MeterWater, to).Return([]models.MeterReading{
+		{MeterID: 1, ReadAt: to, Value: 130},
+		{MeterID: 2, ReadAt: to, Value: 60},
+	}, nil)
+	repoMock.On("CreateUtilityBill", mock.AnythingOfType("*models.UtilityBill"), mock.Anything, mock.MatchedBy(func(charges []models.LedgerEntry) bool {
+		return len(charges) == 3 &&
+			charges[0].FlatNo == 1 && charges[0].Amount == money.New(66667) && charges[0].Type == models.LedgerUtility &&
+			charges[1].FlatNo == 2 && charges[1].Amount == money.New(26667) && charges[1].Period == "2026-09" &&
+			charges[2].FlatNo == 3 && charges[2].Amount == money.New(6666) && charges[0].Note == "water bill for 2026-09, 30 used"
+	})).Return(nil)
+
+	bill, err := service.CreateUtilityBill(UtilityBill{
+		Type:        models.MeterWater,
+		Total:       money.New(100000),
+		CommonShare: 20,
+		From:        from,
+		To:          to,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-09", bill.Period)
+	assert.Equal(t, models.SplitEqual, bill.CommonRule)
+	assert.Len(t, bill.Lines, 3)
+	assert.Equal(t, float64(10), bill.Lines[1].Consumption)
+	assert.Equal(t, money.New(20000), bill.Lines[1].ConsumptionAmount)
+}
+
+func TestCreateUtilityBillNeedsReadings(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	from := time.Date(2026, 9, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2026, 9, 30, 0, 0, 0, 0, time.Local)
+
+	repoMock.On("GetAllInfoAboutAllFlats").Return([]models.Apartment{{FlatNo: 1}}, nil)
+	repoMock.On("GetMeters", 0).Return([]models.Meter{{ID: 1, FlatNo: 1, Type: models.MeterWater, Serial: "W-1"}}, nil)
+	repoMock.On("GetLatestReadings", models.MeterWater, from).Return([]models.MeterReading{{MeterID: 1, ReadAt: from, Value: 100}}, nil)
+	repoMock.On("GetLatestReadings", models.MeterWater, to).Return([]models.MeterReading{{MeterID: 1, ReadAt: from, Value: 100}}, nil)
+
+	_, err := service.CreateUtilityBill(UtilityBill{Type: models.MeterWater, Total: money.New(100000), From: from, To: to})
+	assert.EqualError(t, err, "meter W-1 has no reading after 2026-09-01 on or before 2026-09-30")
+	repoMock.AssertNotCalled(t, "CreateUtilityBill", mock.Anything, mock.Anything, mock.Anything)
+}
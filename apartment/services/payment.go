@@ -0,0 +1,287 @@
+package services
+
+import (
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	paymentprovider "github.com/pragmataW/apartment_management/pkg/payment_provider"
+	randomkeygen "github.com/pragmataW/apartment_management/pkg/random_keygen"
+)
+
+// A payment the provider has not called back about within this time is
+// expired. PayTR gives up on its payment page after 30 minutes.
+const paymentExpiry = time.Hour
+
+// GetPaymentToken records the payment as a created transaction and asks the
+// payment provider for a token to pay it with, which makes it pending. The
+// transaction is recorded first so that a callback coming right after the
+// token always finds it.
+func (s *service) GetPaymentToken(payment paymentprovider.Payment) (string, error) {
+	if payment.OrderID == "" {
+		payment.OrderID = randomkeygen.NewKeygen(64).GenerateRandomKey()
+	}
+
+	flat, err := s.Repo.GetFlatByEmail(payment.Email)
+	if err != nil {
+		return "", err
+	}
+
+	transaction := models.PaymentTransaction{
+		MerchantOID: payment.OrderID,
+		Provider:    s.PaymentProvider.Name(),
+		FlatNo:      flat.FlatNo,
+		Email:       payment.Email,
+		Amount:      payment.Amount,
+		Status:      models.PaymentCreated,
+	}
+	if err := s.Repo.CreatePaymentTransaction(&transaction); err != nil {
+		return "", err
+	}
+
+	token, tokenErr := s.PaymentProvider.GetToken(payment)
+	if tokenErr != nil {
+		transaction.Status = models.PaymentFailed
+		transaction.FailReason = tokenErr.Error()
+	} else {
+		transaction.Status = models.PaymentPending
+	}
+
+	err = s.Repo.MovePaymentTransaction(transaction, []string{models.PaymentCreated})
+	if err != nil {
+		if _, ok := err.(dto.PaymentStateConflict); !ok {
+			return "", err
+		}
+	}
+	if tokenErr != nil {
+		return "", tokenErr
+	}
+	return token, nil
+}
+
+// PaymentCallback checks a payment provider's callback and settles its
+// transaction, or marks it failed. Providers resend a callback until it is
+// acknowledged, so each order is processed once: a callback about an order
+// that is already settled changes nothing and is acknowledged all the same,
+// as is one about an order this server never started, which no retry could
+// book. A payment whose total differs from what was asked for is held for an
+// admin to review rather than booked.
+func (s *service) PaymentCallback(form map[string]string) error {
+	notice, err := s.PaymentProvider.VerifyCallback(form)
+	if err != nil {
+		return dto.PaymentCallbackError{Message: s.PaymentProvider.Name() + " notification failed: " + err.Error()}
+	}
+
+	now := time.Now()
+	if !notice.Success {
+		return s.failPayment(notice, now)
+	}
+
+	paid, err := money.ParseMinor(notice.TotalAmount)
+	if err != nil {
+		return dto.PaymentCallbackError{Message: "total_amount is not an amount: " + notice.TotalAmount}
+	}
+
+	receipt, err := s.Repo.SettleOnlinePayment(notice.OrderID, paid, now)
+	if err != nil {
+		switch err.(type) {
+		case dto.PaymentAlreadySettled, dto.PaymentAmountMismatch, dto.ThereIsNoPaymentTransaction:
+			log.Println("payment " + notice.OrderID + ": " + err.Error())
+			return nil
+		}
+		return err
+	}
+
+	s.mailReceipt(receipt, now)
+	return nil
+}
+
+// AcceptPaymentReview books a payment held for review because the provider
+// reported a different amount than was asked for. The amount actually paid is
+// booked and the receipt is emailed as for any online payment.
+func (s *service) AcceptPaymentReview(merchantOID string, reviewedBy string) (PaymentTransaction, error) {
+	now := time.Now()
+	receipt, err := s.Repo.AcceptPaymentReview(merchantOID, reviewedBy, now)
+	if err != nil {
+		return PaymentTransaction{}, err
+	}
+
+	s.mailReceipt(receipt, now)
+	return s.GetPaymentTransaction(merchantOID)
+}
+
+// mailReceipt sends the receipt email queued with an online payment. The
+// payment stands even if the email cannot be sent; the email stays queued and
+// is tried again by the receipt mails job.
+func (s *service) mailReceipt(receipt models.PaymentReceipt, now time.Time) {
+	queued, err := s.Repo.GetReceiptMailByReceipt(receipt.ReceiptNo)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	mail, err := s.Repo.ClaimReceiptMail(queued.ID, now, now.Add(receiptMailClaim))
+	if err != nil {
+		if _, ok := err.(dto.ReceiptMailClaimed); !ok {
+			log.Println(err)
+		}
+		return
+	}
+	if err := s.deliverReceiptMail(&mail, now); err != nil {
+		log.Println("receipt " + receipt.Reference() + ": " + err.Error())
+	}
+}
+
+// failPayment marks an open transaction failed. One that has moved on, such
+// as a resent callback about a payment already marked failed, is left as it
+// is, and an unknown one is only logged.
+func (s *service) failPayment(notice paymentprovider.Notice, now time.Time) error {
+	log.Println("payment " + notice.OrderID + " is not done: " + notice.Status + " " + notice.FailReason)
+
+	transaction, err := s.Repo.GetPaymentTransaction(notice.OrderID)
+	if err != nil {
+		if _, ok := err.(dto.ThereIsNoPaymentTransaction); ok {
+			log.Println("payment " + notice.OrderID + ": " + err.Error())
+			return nil
+		}
+		return err
+	}
+
+	transaction.Status = models.PaymentFailed
+	transaction.FailReason = notice.FailReason
+	if transaction.FailReason == "" {
+		transaction.FailReason = notice.Status
+	}
+	transaction.CallbackAt = &now
+
+	err = s.Repo.MovePaymentTransaction(transaction, []string{models.PaymentCreated, models.PaymentPending, models.PaymentExpired})
+	if err != nil {
+		if _, ok := err.(dto.PaymentStateConflict); ok {
+			log.Println("payment " + notice.OrderID + ": " + err.Error())
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *service) GetPaymentTransaction(merchantOID string) (PaymentTransaction, error) {
+	modelTransaction, err := s.Repo.GetPaymentTransaction(merchantOID)
+	if err != nil {
+		return PaymentTransaction{}, err
+	}
+
+	var transaction PaymentTransaction
+	transaction.ToPaymentTransactionServiceObject(modelTransaction)
+	return transaction, nil
+}
+
+func (s *service) GetPaymentTransactions(filter PaymentTransactionFilter) ([]PaymentTransaction, error) {
+	switch filter.Status {
+	case "", models.PaymentCreated, models.PaymentPending, models.PaymentSucceeded,
+		models.PaymentFailed, models.PaymentExpired, models.PaymentRefunded, models.PaymentReview:
+	default:
+		return nil, dto.PaymentTransactionError{Message: "status must be created, pending, succeeded, failed, expired, refunded or review"}
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		return nil, dto.PaymentTransactionError{Message: "from must not be after to"}
+	}
+
+	modelTransactions, err := s.Repo.GetPaymentTransactions(filter.ToPaymentTransactionFilterModel())
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := []PaymentTransaction{}
+	for _, modelTransaction := range modelTransactions {
+		transaction := PaymentTransaction{}
+		transaction.ToPaymentTransactionServiceObject(modelTransaction)
+		transactions = append(transactions, transaction)
+	}
+	return transactions, nil
+}
+
+// RefundPayment gives back the amount, or all that is left of a succeeded
+// payment when amount is zero, through the provider that took it. The refund
+// is reserved before the provider is asked and its part of the payment
+// reversed only once the provider has made it.
+func (s *service) RefundPayment(merchantOID string, amount money.Money, reason string, refundedBy string) (PaymentTransaction, error) {
+	transaction, err := s.Repo.GetPaymentTransaction(merchantOID)
+	if err != nil {
+		return PaymentTransaction{}, err
+	}
+	if transaction.Status != models.PaymentSucceeded {
+		return PaymentTransaction{}, dto.PaymentTransactionError{Message: "only a succeeded payment can be refunded"}
+	}
+	if transaction.Provider != s.PaymentProvider.Name() {
+		return PaymentTransaction{}, dto.PaymentTransactionError{Message: "payment was taken by " + transaction.Provider + ", not " + s.PaymentProvider.Name()}
+	}
+
+	if amount.IsNegative() {
+		return PaymentTransaction{}, dto.PaymentTransactionError{Message: "refund amount must be positive"}
+	}
+
+	refund := models.PaymentRefund{
+		MerchantOID: merchantOID,
+		Amount:      amount,
+		Reason:      reason,
+		RefundedBy:  refundedBy,
+	}
+	if err := s.Repo.ReservePaymentRefund(&refund); err != nil {
+		return PaymentTransaction{}, err
+	}
+
+	if err := s.PaymentProvider.Refund(merchantOID, refund.Amount); err != nil {
+		if failErr := s.Repo.FailPaymentRefund(refund.ID, err.Error()); failErr != nil {
+			log.Println("refund " + strconv.Itoa(refund.ID) + " on payment " + merchantOID + " is turned down but left pending: " + failErr.Error())
+		}
+		return PaymentTransaction{}, dto.PaymentProviderError{Message: err.Error()}
+	}
+
+	modelTransaction, err := s.Repo.CompletePaymentRefund(refund.ID)
+	if err != nil {
+		log.Println("refund of " + refund.Amount.String() + " on payment " + merchantOID + " is made but not recorded: " + err.Error())
+		return PaymentTransaction{}, err
+	}
+
+	var refunded PaymentTransaction
+	refunded.ToPaymentTransactionServiceObject(modelTransaction)
+	return refunded, nil
+}
+
+func (s *service) GetPaymentRefunds(merchantOID string) ([]PaymentRefund, error) {
+	modelRefunds, err := s.Repo.GetPaymentRefunds(merchantOID)
+	if err != nil {
+		return nil, err
+	}
+
+	refunds := []PaymentRefund{}
+	for _, modelRefund := range modelRefunds {
+		refund := PaymentRefund{}
+		refund.ToPaymentRefundServiceObject(modelRefund)
+		refunds = append(refunds, refund)
+	}
+	return refunds, nil
+}
+
+// ExpirePaymentTransactions expires the payments that have waited longer
+// than paymentExpiry for their callback and returns how many.
+func (s *service) ExpirePaymentTransactions(now time.Time) (int, error) {
+	expired, err := s.Repo.ExpirePaymentTransactions(now.Add(-paymentExpiry))
+	if err != nil {
+		return 0, err
+	}
+	if expired > 0 {
+		log.Println("expired " + strconv.Itoa(expired) + " payment transactions")
+	}
+	return expired, nil
+}
+
+func (s *service) expirePaymentTransactions() {
+	_, err := s.ExpirePaymentTransactions(time.Now())
+	if err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,308 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	paymentprovider "github.com/pragmataW/apartment_management/pkg/payment_provider"
+	mocks "github.com/pragmataW/apartment_management/service_mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func fakeCallback(status string) map[string]string {
+	return map[string]string{
+		"merchant_oid": "oid",
+		"status":       status,
+		"total_amount": "12000",
+		"hash":         paymentprovider.FakeSignature("secret", "oid", status, "12000"),
+	}
+}
+
+func pendingTransaction() models.PaymentTransaction {
+	return models.PaymentTransaction{
+		MerchantOID: "oid", Provider: "fake", FlatNo: 3, Email: "deneme@mail.com",
+		Amount: money.New(12000), Status: models.PaymentPending,
+	}
+}
+
+func TestGetPaymentToken(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	providerMock := new(mocks.IPaymentProvider)
+	service := NewService(WithRepo(repoMock), WithPaymentProvider(providerMock))
+
+	providerMock.On("Name").Return("fake")
+	repoMock.On("GetFlatByEmail", "deneme@mail.com").Return(models.Apartment{FlatNo: 3}, nil)
+	repoMock.On("CreatePaymentTransaction", mock.MatchedBy(func(transaction *models.PaymentTransaction) bool {
+		return len(transaction.MerchantOID) == 64 && transaction.FlatNo == 3 && transaction.Provider == "fake" && transaction.Status == models.PaymentCreated
+	})).Return(nil)
+	providerMock.On("GetToken", mock.MatchedBy(func(payment paymentprovider.Payment) bool {
+		return len(payment.OrderID) == 64 && payment.Amount == money.New(12000)
+	})).Return("token", nil)
+	repoMock.On("MovePaymentTransaction", mock.MatchedBy(func(transaction models.PaymentTransaction) bool {
+		return transaction.Status == models.PaymentPending
+	}), []string{models.PaymentCreated}).Return(nil)
+
+	token, err := service.GetPaymentToken(paymentprovider.Payment{Email: "deneme@mail.com", Amount: money.New(12000)})
+	assert.NoError(t, err)
+	assert.Equal(t, "token", token)
+	repoMock.AssertExpectations(t)
+	providerMock.AssertExpectations(t)
+}
+
+func TestGetPaymentTokenProviderFails(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	providerMock := new(mocks.IPaymentProvider)
+	service := NewService(WithRepo(repoMock), WithPaymentProvider(providerMock))
+
+	providerMock.On("Name").Return("paytr")
+	repoMock.On("GetFlatByEmail", "deneme@mail.com").Return(models.Apartment{FlatNo: 3}, nil)
+	repoMock.On("CreatePaymentTransaction", mock.Anything).Return(nil)
+	providerMock.On("GetToken", mock.Anything).Return("", errors.New("status not ok"))
+	repoMock.On("MovePaymentTransaction", mock.MatchedBy(func(transaction models.PaymentTransaction) bool {
+		return transaction.Status == models.PaymentFailed && transaction.FailReason == "status not ok"
+	}), []string{models.PaymentCreated}).Return(nil)
+
+	_, err := service.GetPaymentToken(paymentprovider.Payment{OrderID: "oid", Email: "deneme@mail.com", Amount: money.New(12000)})
+	assert.EqualError(t, err, "status not ok")
+	repoMock.AssertExpectations(t)
+}
+
+func TestPaymentCallback(t *testing.T) {
+	var mails []map[string]interface{}
+	repoMock := new(mocks.IRepo)
+	provider := paymentprovider.NewFake(paymentprovider.FakeConfig{Secret: "secret"})
+	service := NewService(WithRepo(repoMock), WithConfigManager(newMailServer(t, http.StatusOK, &mails)), WithPaymentProvider(provider))
+
+	repoMock.On("SettleOnlinePayment", "oid", money.New(12000), mock.AnythingOfType("time.Time")).Return(models.PaymentReceipt{ReceiptNo: 12, FlatNo: 3}, nil)
+	repoMock.On("GetReceiptMailByReceipt", 12).Return(models.ReceiptMail{ID: 1, ReceiptNo: 12, Mail: "deneme@mail.com", Status: models.MailPending}, nil)
+	repoMock.On("ClaimReceiptMail", 1, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(claimedReceiptMail(), nil)
+	mockReceiptMailBody(repoMock)
+	repoMock.On("UpdateReceiptMail", mock.MatchedBy(func(mail models.ReceiptMail) bool {
+		return mail.ID == 1 && mail.Status == models.MailSent && mail.Attempts == 1 && mail.SentAt != nil
+	})).Return(nil)
+
+	err := service.PaymentCallback(fakeCallback("success"))
+	assert.NoError(t, err)
+	repoMock.AssertExpectations(t)
+
+	assert.Len(t, mails, 1)
+	assert.Equal(t, "deneme@mail.com", mails[0]["to_email"])
+	assert.Contains(t, mails[0]["html"], "R-000012")
+	assert.Contains(t, mails[0]["html"], "120.00 TL")
+	assert.Contains(t, mails[0]["html"], "2026-08, 2026-09")
+	assert.Contains(t, mails[0]["html"], "40.00 TL owed")
+}
+
+func TestPaymentCallbackMailFails(t *testing.T) {
+	var mails []map[string]interface{}
+	repoMock := new(mocks.IRepo)
+	provider := paymentprovider.NewFake(paymentprovider.FakeConfig{Secret: "secret"})
+	service := NewService(WithRepo(repoMock), WithConfigManager(newMailServer(t, http.StatusInternalServerError, &mails)), WithPaymentProvider(provider))
+
+	repoMock.On("SettleOnlinePayment", "oid", money.New(12000), mock.AnythingOfType("time.Time")).Return(models.PaymentReceipt{ReceiptNo: 12, FlatNo: 3}, nil)
+	repoMock.On("GetReceiptMailByReceipt", 12).Return(models.ReceiptMail{ID: 1, ReceiptNo: 12, Mail: "deneme@mail.com", Status: models.MailPending}, nil)
+	repoMock.On("ClaimReceiptMail", 1, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(claimedReceiptMail(), nil)
+	mockReceiptMailBody(repoMock)
+	repoMock.On("UpdateReceiptMail", mock.MatchedBy(func(mail models.ReceiptMail) bool {
+		return mail.Status == models.MailPending && mail.Attempts == 1 && mail.Error != "" && mail.NextAttempt.After(time.Now())
+	})).Return(nil)
+
+	err := service.PaymentCallback(fakeCallback("success"))
+	assert.NoError(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestPaymentCallbackBadHash(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	provider := paymentprovider.NewFake(paymentprovider.FakeConfig{Secret: "secret"})
+	service := NewService(WithRepo(repoMock), WithPaymentProvider(provider))
+
+	form := fakeCallback("success")
+	form["total_amount"] = "1"
+
+	err := service.PaymentCallback(form)
+	assert.IsType(t, dto.PaymentCallbackError{}, err)
+	repoMock.AssertNotCalled(t, "SettleOnlinePayment", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPaymentCallbackFailedPayment(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	provider := paymentprovider.NewFake(paymentprovider.FakeConfig{Secret: "secret"})
+	service := NewService(WithRepo(repoMock), WithPaymentProvider(provider))
+
+	repoMock.On("GetPaymentTransaction", "oid").Return(pendingTransaction(), nil)
+	repoMock.On("MovePaymentTransaction", mock.MatchedBy(func(transaction models.PaymentTransaction) bool {
+		return transaction.Status == models.PaymentFailed && transaction.FailReason == "failed" && transaction.CallbackAt != nil
+	}), []string{models.PaymentCreated, models.PaymentPending, models.PaymentExpired}).Return(nil)
+
+	err := service.PaymentCallback(fakeCallback("failed"))
+	assert.NoError(t, err)
+	repoMock.AssertExpectations(t)
+	repoMock.AssertNotCalled(t, "SettleOnlinePayment", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPaymentCallbackDuplicate(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	provider := paymentprovider.NewFake(paymentprovider.FakeConfig{Secret: "secret"})
+	service := NewService(WithRepo(repoMock), WithPaymentProvider(provider))
+
+	repoMock.On("SettleOnlinePayment", "oid", money.New(12000), mock.AnythingOfType("time.Time")).Return(models.PaymentReceipt{}, dto.PaymentAlreadySettled{Message: "payment is already succeeded"})
+
+	err := service.PaymentCallback(fakeCallback("success"))
+	assert.NoError(t, err)
+	repoMock.AssertNotCalled(t, "GetReceiptMailByReceipt", mock.Anything)
+}
+
+func TestPaymentCallbackUnknownOrder(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	provider := paymentprovider.NewFake(paymentprovider.FakeConfig{Secret: "secret"})
+	service := NewService(WithRepo(repoMock), WithPaymentProvider(provider))
+
+	repoMock.On("SettleOnlinePayment", "oid", money.New(12000), mock.AnythingOfType("time.Time")).Return(models.PaymentReceipt{}, dto.ThereIsNoPaymentTransaction{Message: "there is no payment transaction"})
+	repoMock.On("GetPaymentTransaction", "oid").Return(models.PaymentTransaction{}, dto.ThereIsNoPaymentTransaction{Message: "there is no payment transaction"})
+
+	err := service.PaymentCallback(fakeCallback("success"))
+	assert.NoError(t, err)
+
+	err = service.PaymentCallback(fakeCallback("failed"))
+	assert.NoError(t, err)
+	repoMock.AssertExpectations(t)
+	repoMock.AssertNotCalled(t, "GetReceiptMailByReceipt", mock.Anything)
+	repoMock.AssertNotCalled(t, "MovePaymentTransaction", mock.Anything, mock.Anything)
+}
+
+func TestPaymentCallbackAmountMismatch(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	provider := paymentprovider.NewFake(paymentprovider.FakeConfig{Secret: "secret"})
+	service := NewService(WithRepo(repoMock), WithPaymentProvider(provider))
+
+	form := map[string]string{
+		"merchant_oid": "oid",
+		"status":       "success",
+		"total_amount": "4000",
+		"hash":         paymentprovider.FakeSignature("secret", "oid", "success", "4000"),
+	}
+	repoMock.On("SettleOnlinePayment", "oid", money.New(4000), mock.AnythingOfType("time.Time")).Return(models.PaymentReceipt{}, dto.PaymentAmountMismatch{Message: "paid amount does not match the payment"})
+
+	err := service.PaymentCallback(form)
+	assert.NoError(t, err)
+	repoMock.AssertExpectations(t)
+	repoMock.AssertNotCalled(t, "GetReceiptMailByReceipt", mock.Anything)
+}
+
+func TestPaymentCallbackBadTotal(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	provider := paymentprovider.NewFake(paymentprovider.FakeConfig{Secret: "secret"})
+	service := NewService(WithRepo(repoMock), WithPaymentProvider(provider))
+
+	form := map[string]string{
+		"merchant_oid": "oid",
+		"status":       "success",
+		"total_amount": "12,000",
+		"hash":         paymentprovider.FakeSignature("secret", "oid", "success", "12,000"),
+	}
+
+	err := service.PaymentCallback(form)
+	assert.IsType(t, dto.PaymentCallbackError{}, err)
+	repoMock.AssertNotCalled(t, "SettleOnlinePayment", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPaymentCallbackDuplicateFailure(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	provider := paymentprovider.NewFake(paymentprovider.FakeConfig{Secret: "secret"})
+	service := NewService(WithRepo(repoMock), WithPaymentProvider(provider))
+
+	transaction := pendingTransaction()
+	transaction.Status = models.PaymentFailed
+	repoMock.On("GetPaymentTransaction", "oid").Return(transaction, nil)
+	repoMock.On("MovePaymentTransaction", mock.Anything, mock.Anything).Return(dto.PaymentStateConflict{Message: "payment transaction is not created or pending or expired"})
+
+	err := service.PaymentCallback(fakeCallback("failed"))
+	assert.NoError(t, err)
+}
+
+func TestGetPaymentTransactionsBadStatus(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	_, err := service.GetPaymentTransactions(PaymentTransactionFilter{Status: "lost"})
+	assert.IsType(t, dto.PaymentTransactionError{}, err)
+	repoMock.AssertNotCalled(t, "GetPaymentTransactions", mock.Anything)
+}
+
+func succeededTransaction() models.PaymentTransaction {
+	transaction := pendingTransaction()
+	transaction.Provider = "paytr"
+	transaction.Status = models.PaymentSucceeded
+	transaction.PaidAmount = money.New(12000)
+	return transaction
+}
+
+func TestRefundPayment(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	providerMock := new(mocks.IPaymentProvider)
+	service := NewService(WithRepo(repoMock), WithPaymentProvider(providerMock))
+
+	refunded := succeededTransaction()
+	refunded.Status = models.PaymentRefunded
+	refunded.RefundedAmount = money.New(12000)
+
+	providerMock.On("Name").Return("paytr")
+	repoMock.On("GetPaymentTransaction", "oid").Return(succeededTransaction(), nil)
+	repoMock.On("ReservePaymentRefund", mock.MatchedBy(func(refund *models.PaymentRefund) bool {
+		return refund.MerchantOID == "oid" && refund.Amount.IsZero() && refund.RefundedBy == "admin"
+	})).Run(func(args mock.Arguments) {
+		refund := args.Get(0).(*models.PaymentRefund)
+		refund.ID = 7
+		refund.Amount = money.New(12000)
+	}).Return(nil)
+	providerMock.On("Refund", "oid", money.New(12000)).Return(nil)
+	repoMock.On("CompletePaymentRefund", 7).Return(refunded, nil)
+
+	transaction, err := service.RefundPayment("oid", money.New(0), "paid twice", "admin")
+	assert.NoError(t, err)
+	assert.Equal(t, models.PaymentRefunded, transaction.Status)
+	repoMock.AssertExpectations(t)
+	providerMock.AssertExpectations(t)
+}
+
+func TestRefundPaymentTooMuch(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	providerMock := new(mocks.IPaymentProvider)
+	service := NewService(WithRepo(repoMock), WithPaymentProvider(providerMock))
+
+	transaction := succeededTransaction()
+	transaction.RefundedAmount = money.New(4000)
+	providerMock.On("Name").Return("paytr")
+	repoMock.On("GetPaymentTransaction", "oid").Return(transaction, nil)
+	repoMock.On("ReservePaymentRefund", mock.Anything).Return(dto.PaymentStateConflict{Message: "refund is more than the 80.00 TL left of the payment"})
+
+	_, err := service.RefundPayment("oid", money.New(10000), "paid twice", "admin")
+	assert.IsType(t, dto.PaymentStateConflict{}, err)
+	providerMock.AssertNotCalled(t, "Refund", mock.Anything, mock.Anything)
+}
+
+func TestRefundPaymentProviderFails(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	providerMock := new(mocks.IPaymentProvider)
+	service := NewService(WithRepo(repoMock), WithPaymentProvider(providerMock))
+
+	providerMock.On("Name").Return("paytr")
+	repoMock.On("GetPaymentTransaction", "oid").Return(succeededTransaction(), nil)
+	repoMock.On("ReservePaymentRefund", mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.PaymentRefund).ID = 7
+	}).Return(nil)
+	providerMock.On("Refund", "oid", money.New(4000)).Return(errors.New("refund failed"))
+	repoMock.On("FailPaymentRefund", 7, "refund failed").Return(nil)
+
+	_, err := service.RefundPayment("oid", money.New(4000), "paid twice", "admin")
+	assert.IsType(t, dto.PaymentProviderError{}, err)
+	repoMock.AssertExpectations(t)
+	repoMock.AssertNotCalled(t, "CompletePaymentRefund", mock.Anything)
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+)
+
+// MaxPenaltyRate is the monthly late fee ceiling of the condominium law, in
+// percent.
+const MaxPenaltyRate = 5.0
+
+func (s *service) GetPenaltyPolicy() (PenaltyPolicy, error) {
+	return s.penaltyPolicyAt(time.Now())
+}
+
+// penaltyPolicyAt returns the penalty policy that was in effect at the given
+// time.
+func (s *service) penaltyPolicyAt(at time.Time) (PenaltyPolicy, error) {
+	graceDays, err := s.floatSetting(models.SettingPenaltyGraceDays, at, dto.DefaultPenaltyGraceDays)
+	if err != nil {
+		return PenaltyPolicy{}, err
+	}
+
+	rate, err := s.floatSetting(models.SettingPenaltyRate, at, dto.DefaultPenaltyRate)
+	if err != nil {
+		return PenaltyPolicy{}, err
+	}
+
+	fee, err := s.moneySetting(models.SettingPenaltyFee, at, dto.DefaultPenaltyFee)
+	if err != nil {
+		return PenaltyPolicy{}, err
+	}
+
+	return PenaltyPolicy{GraceDays: int(graceDays), Rate: rate, Fee: fee}, nil
+}
+
+func (s *service) ChangePenaltyPolicy(policy PenaltyPolicy, changedBy string) error {
+	if policy.GraceDays < 0 || policy.GraceDays > 60 {
+		return dto.PenaltyPolicyError{Message: "grace days must be between 0 and 60"}
+	}
+	if policy.Rate < 0 || policy.Rate > MaxPenaltyRate {
+		return dto.PenaltyPolicyError{Message: "penalty rate must be between 0 and 5 percent"}
+	}
+	if policy.Fee.IsNegative() {
+		return dto.PenaltyPolicyError{Message: "penalty fee must not be negative"}
+	}
+
+	now := time.Now()
+	settings := []models.Setting{
+		{Key: models.SettingPenaltyGraceDays, Value: strconv.Itoa(policy.GraceDays)},
+		{Key: models.SettingPenaltyRate, Value: strconv.FormatFloat(policy.Rate, 'f', 2, 64)},
+		{Key: models.SettingPenaltyFee, Value: policy.Fee.String()},
+	}
+	for _, setting := range settings {
+		setting.ChangedBy = changedBy
+		setting.EffectiveFrom = now
+		if err := s.Repo.SaveSetting(setting); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ApplyLatePenalties raises a late fee on every dues charge that is still open
+// once its grace period is over, and one more for each month it stays open.
+// The fee is the policy rate applied to the unpaid part of the charge plus the
+// fixed fee, as they stood on the day the month's penalty falls due, so months
+// before the policy took effect add nothing; penalties themselves are never
+// penalised. Running it again for the same day adds nothing.
+func (s *service) ApplyLatePenalties(now time.Time) error {
+	policy, err := s.GetPenaltyPolicy()
+	if err != nil {
+		return err
+	}
+	if policy.Rate == 0 && policy.Fee.IsZero() {
+		return nil
+	}
+
+	charges, err := s.Repo.GetOverdueCharges(now.AddDate(0, 0, -policy.GraceDays))
+	if err != nil {
+		return err
+	}
+
+	policies := make(map[time.Time]PenaltyPolicy)
+	var penalties []models.LedgerEntry
+	for _, charge := range charges {
+		chargeID := charge.ID
+		start := charge.DueDate.AddDate(0, 0, policy.GraceDays)
+
+		for month := 0; addMonths(start, month).Before(now); month++ {
+			penaltyDate := addMonths(start, month)
+			monthPolicy, ok := policies[penaltyDate]
+			if !ok {
+				monthPolicy, err = s.penaltyPolicyAt(penaltyDate)
+				if err != nil {
+					return err
+				}
+				policies[penaltyDate] = monthPolicy
+			}
+			if monthPolicy.Rate == 0 && monthPolicy.Fee.IsZero() {
+				continue
+			}
+
+			amount := charge.Remaining.Mul(monthPolicy.Rate / 100).Add(monthPolicy.Fee)
+			penalties = append(penalties, models.LedgerEntry{
+				FlatNo:   charge.FlatNo,
+				Period:   BillingPeriod(penaltyDate),
+				Type:     models.LedgerPenalty,
+				Amount:   amount,
+				Source:   models.SourceScheduler,
+				Note:     "late fee for " + charge.Period,
+				ChargeID: &chargeID,
+				DueDate:  penaltyDate,
+			})
+		}
+	}
+
+	if len(penalties) == 0 {
+		return nil
+	}
+
+	added, err := s.Repo.AddPenalties(penalties)
+	if err != nil {
+		return err
+	}
+	if added > 0 {
+		log.Println("added " + strconv.Itoa(added) + " late penalties")
+	}
+	return nil
+}
+
+func (s *service) applyLatePenalties() {
+	err := s.ApplyLatePenalties(time.Now())
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// addMonths moves t by the given number of months, keeping the day of month
+// where it exists and using the last day of shorter months otherwise.
+func addMonths(t time.Time, months int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	mocks "github.com/pragmataW/apartment_management/service_mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetPenaltyPolicyDefaults(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetSetting", mock.Anything, mock.Anything).Return(models.Setting{}, dto.ThereIsNoSetting{})
+
+	policy, err := service.GetPenaltyPolicy()
+	assert.NoError(t, err)
+	assert.Equal(t, PenaltyPolicy{GraceDays: dto.DefaultPenaltyGraceDays}, policy)
+}
+
+func TestChangePenaltyPolicy(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("SaveSetting", mock.MatchedBy(func(setting models.Setting) bool {
+		return setting.Key == models.SettingPenaltyGraceDays && setting.Value == "7" && setting.ChangedBy == "admin"
+	})).Return(nil)
+	repoMock.On("SaveSetting", mock.MatchedBy(func(setting models.Setting) bool {
+		return setting.Key == models.SettingPenaltyRate && setting.Value == "5.00"
+	})).Return(nil)
+	repoMock.On("SaveSetting", mock.MatchedBy(func(setting models.Setting) bool {
+		return setting.Key == models.SettingPenaltyFee && setting.Value == "0.00"
+	})).Return(nil)
+
+	err := service.ChangePenaltyPolicy(PenaltyPolicy{GraceDays: 7, Rate: 5}, "admin")
+	assert.NoError(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestChangePenaltyPolicy_Invalid(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	err := service.ChangePenaltyPolicy(PenaltyPolicy{Rate: 6}, "admin")
+	assert.IsType(t, dto.PenaltyPolicyError{}, err)
+
+	err = service.ChangePenaltyPolicy(PenaltyPolicy{GraceDays: -1}, "admin")
+	assert.IsType(t, dto.PenaltyPolicyError{}, err)
+
+	err = service.ChangePenaltyPolicy(PenaltyPolicy{Fee: money.New(-1000)}, "admin")
+	assert.IsType(t, dto.PenaltyPolicyError{}, err)
+	repoMock.AssertNotCalled(t, "SaveSetting", mock.Anything)
+}
+
+func TestApplyLatePenalties(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	now := time.Date(2026, 11, 20, 9, 0, 0, 0, time.Local)
+	repoMock.On("GetSetting", models.SettingPenaltyGraceDays, mock.Anything).Return(models.Setting{Value: "10"}, nil)
+	repoMock.On("GetSetting", models.SettingPenaltyRate, mock.Anything).Return(models.Setting{Value: "5.00"}, nil)
+	repoMock.On("GetSetting", models.SettingPenaltyFee, mock.Anything).Return(models.Setting{Value: "1.00"}, nil)
+
+	charge := models.OpenCharge{
+		LedgerEntry: models.LedgerEntry{ID: 7, FlatNo: 3, Period: "2026-09", Type: models.LedgerAccrual, Amount: money.New(4000), DueDate: time.Date(2026, 9, 15, 0, 0, 0, 0, time.Local)},
+		Remaining:   money.New(3000),
+	}
+	repoMock.On("GetOverdueCharges", time.Date(2026, 11, 10, 9, 0, 0, 0, time.Local)).Return([]models.OpenCharge{charge}, nil)
+	repoMock.On("AddPenalties", mock.MatchedBy(func(penalties []models.LedgerEntry) bool {
+		if len(penalties) != 2 {
+			return false
+		}
+		for _, penalty := range penalties {
+			if penalty.Amount != money.New(250) || penalty.Type != models.LedgerPenalty || *penalty.ChargeID != 7 || penalty.FlatNo != 3 {
+				return false
+			}
+		}
+		return penalties[0].Period == "2026-09" && penalties[1].Period == "2026-10"
+	})).Return(2, nil)
+
+	err := service.ApplyLatePenalties(now)
+	assert.NoError(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestApplyLatePenaltiesFromEffectiveDate(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	now := time.Date(2026, 11, 20, 9, 0, 0, 0, time.Local)
+	introduced := time.Date(2026, 9, 1, 0, 0, 0, 0, time.Local)
+	lowered := time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)
+	repoMock.On("GetSetting", models.SettingPenaltyRate, mock.MatchedBy(func(at time.Time) bool {
+		return at.Before(introduced)
+	})).Return(models.Setting{}, dto.ThereIsNoSetting{})
+	repoMock.On("GetSetting", models.SettingPenaltyRate, mock.MatchedBy(func(at time.Time) bool {
+		return at.Before(lowered)
+	})).Return(models.Setting{Value: "5.00", EffectiveFrom: introduced}, nil)
+	repoMock.On("GetSetting", models.SettingPenaltyRate, mock.Anything).Return(models.Setting{Value: "2.00", EffectiveFrom: lowered}, nil)
+	repoMock.On("GetSetting", mock.Anything, mock.Anything).Return(models.Setting{}, dto.ThereIsNoSetting{})
+
+	charge := models.OpenCharge{
+		LedgerEntry: models.LedgerEntry{ID: 7, FlatNo: 3, Period: "2026-07", Type: models.LedgerAccrual, Amount: money.New(4000), DueDate: time.Date(2026, 7, 15, 0, 0, 0, 0, time.Local)},
+		Remaining:   money.New(3000),
+	}
+	repoMock.On("GetOverdueCharges", time.Date(2026, 11, 10, 9, 0, 0, 0, time.Local)).Return([]models.OpenCharge{charge}, nil)
+	repoMock.On("AddPenalties", mock.MatchedBy(func(penalties []models.LedgerEntry) bool {
+		return len(penalties) == 2 &&
+			penalties[0].Period == "2026-09" && penalties[0].Amount == money.New(150) &&
+			penalties[1].Period == "2026-10" && penalties[1].Amount == money.New(60)
+	})).Return(2, nil)
+
+	err := service.ApplyLatePenalties(now)
+	assert.NoError(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestApplyLatePenaltiesDisabled(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetSetting", mock.Anything, mock.Anything).Return(models.Setting{}, dto.ThereIsNoSetting{})
+
+	err := service.ApplyLatePenalties(time.Now())
+	assert.NoError(t, err)
+	repoMock.AssertNotCalled(t, "GetOverdueCharges", mock.Anything)
+}
+
+func TestApplyLatePenalties_Error(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	expectedError := errors.New("some error")
+	repoMock.On("GetSetting", models.SettingPenaltyRate, mock.Anything).Return(models.Setting{Value: "5.00"}, nil)
+	repoMock.On("GetSetting", mock.Anything, mock.Anything).Return(models.Setting{}, dto.ThereIsNoSetting{})
+	repoMock.On("GetOverdueCharges", mock.Anything).Return(nil, expectedError)
+
+	err := service.ApplyLatePenalties(time.Now())
+	assert.Equal(t, expectedError, err)
+}
+
+func TestAddMonths(t *testing.T) {
+	start := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC), addMonths(start, 1))
+	assert.Equal(t, time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC), addMonths(start, 2))
+	assert.Equal(t, time.Date(2027, 1, 31, 0, 0, 0, 0, time.UTC), addMonths(start, 12))
+}
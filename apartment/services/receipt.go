@@ -0,0 +1,89 @@
+package services
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+)
+
+// RecordManualPayment books a cash, transfer or cheque payment for the flat
+// and returns its receipt. PaidAt defaults to now and CollectedBy to whoever
+// records the payment.
+func (s *service) RecordManualPayment(receipt PaymentReceipt) (PaymentReceipt, error) {
+	if !receipt.Amount.IsPositive() {
+		return PaymentReceipt{}, dto.ManualPaymentError{Message: "payment amount must be positive"}
+	}
+
+	switch receipt.Method {
+	case models.MethodCash, models.MethodTransfer, models.MethodCheque:
+	default:
+		return PaymentReceipt{}, dto.ManualPaymentError{Message: "payment method must be cash, transfer or cheque"}
+	}
+
+	now := time.Now()
+	if receipt.PaidAt.IsZero() {
+		receipt.PaidAt = now
+	}
+	if receipt.PaidAt.After(now) {
+		return PaymentReceipt{}, dto.ManualPaymentError{Message: "payment date cannot be in the future"}
+	}
+
+	receipt.CollectedBy = strings.TrimSpace(receipt.CollectedBy)
+	if receipt.CollectedBy == "" {
+		receipt.CollectedBy = receipt.CreatedBy
+	}
+
+	receiptModel := receipt.ToPaymentReceiptModel()
+	if err := s.Repo.AddManualPayment(&receiptModel); err != nil {
+		return PaymentReceipt{}, err
+	}
+
+	receipt.ToPaymentReceiptServiceObject(receiptModel)
+	return receipt, nil
+}
+
+// CancelManualPayment cancels a receipt. The payment is reversed on the
+// ledger, so the months it settled are open again.
+func (s *service) CancelManualPayment(receiptNo int, cancelledBy string, reason string) (PaymentReceipt, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return PaymentReceipt{}, dto.ManualPaymentError{Message: "a reason is required to cancel a receipt"}
+	}
+
+	receiptModel, err := s.Repo.CancelManualPayment(receiptNo, cancelledBy, reason)
+	if err != nil {
+		return PaymentReceipt{}, err
+	}
+
+	var receipt PaymentReceipt
+	receipt.ToPaymentReceiptServiceObject(receiptModel)
+	return receipt, nil
+}
+
+func (s *service) GetPaymentReceipt(receiptNo int) (PaymentReceipt, error) {
+	receiptModel, err := s.Repo.GetPaymentReceipt(receiptNo)
+	if err != nil {
+		return PaymentReceipt{}, err
+	}
+
+	var receipt PaymentReceipt
+	receipt.ToPaymentReceiptServiceObject(receiptModel)
+	return receipt, nil
+}
+
+func (s *service) GetPaymentReceipts(flatNo int) ([]PaymentReceipt, error) {
+	receiptModels, err := s.Repo.GetPaymentReceipts(flatNo)
+	if err != nil {
+		return nil, err
+	}
+
+	receipts := []PaymentReceipt{}
+	for _, receiptModel := range receiptModels {
+		receipt := PaymentReceipt{}
+		receipt.ToPaymentReceiptServiceObject(receiptModel)
+		receipts = append(receipts, receipt)
+	}
+	return receipts, nil
+}
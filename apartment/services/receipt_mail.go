@@ -0,0 +1,200 @@
+package services
+
+import (
+	"bytes"
+	"html/template"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+)
+
+const (
+	receiptMailSubject = "Payment receipt"
+
+	// A receipt email is tried this many times, waiting a little longer
+	// after each failure, before it is left for an admin to retry.
+	receiptMailAttempts = 5
+	receiptMailBackoff  = 10 * time.Minute
+
+	// A claimed receipt email is left to its sender this long before another
+	// may take it over, in case the sender stopped before recording the send.
+	receiptMailClaim = 15 * time.Minute
+)
+
+var receiptMailTemplate = template.Must(template.New("receipt").Funcs(template.FuncMap{
+	"join": strings.Join,
+}).Parse(`<p>Thank you, we have received your payment for flat {{.FlatNo}}.</p>
+<table>
+<tr><td>Receipt number</td><td>{{.ReceiptNo}}</td></tr>
+<tr><td>Date</td><td>{{.PaidAt}}</td></tr>
+<tr><td>Amount</td><td>{{.Amount}} TL</td></tr>
+<tr><td>Months covered</td><td>{{if .Months}}{{join .Months ", "}}{{else}}none, kept as credit{{end}}</td></tr>
+<tr><td>Remaining balance</td><td>{{.Balance}}</td></tr>
+</table>`))
+
+type receiptMailData struct {
+	FlatNo    int
+	ReceiptNo string
+	PaidAt    string
+	Amount    string
+	Months    []string
+	Balance   string
+}
+
+// SendPendingReceiptMails sends the receipt emails whose next attempt is due.
+// Each is claimed first, so one taken by another sender is skipped. It
+// returns how many went out.
+func (s *service) SendPendingReceiptMails(now time.Time) (int, error) {
+	mails, err := s.Repo.GetDueReceiptMails(now)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, due := range mails {
+		mail, err := s.Repo.ClaimReceiptMail(due.ID, now, now.Add(receiptMailClaim))
+		if err != nil {
+			if _, ok := err.(dto.ReceiptMailClaimed); !ok {
+				return sent, err
+			}
+			continue
+		}
+
+		err = s.deliverReceiptMail(&mail, now)
+		if err != nil {
+			if _, ok := err.(dto.SendMailError); !ok {
+				return sent, err
+			}
+			continue
+		}
+		sent++
+	}
+
+	if sent > 0 {
+		log.Println("sent " + strconv.Itoa(sent) + " receipt mails")
+	}
+	return sent, nil
+}
+
+func (s *service) sendPendingReceiptMails() {
+	_, err := s.SendPendingReceiptMails(time.Now())
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// RetryReceiptMail sends a receipt email again now, whether it failed for
+// good or is still waiting for its next attempt. A mail that is already sent
+// or being sent is refused.
+func (s *service) RetryReceiptMail(id int) (ReceiptMail, error) {
+	now := time.Now()
+	mail, err := s.Repo.ClaimReceiptMailForRetry(id, now, now.Add(receiptMailClaim))
+	if err != nil {
+		return ReceiptMail{}, err
+	}
+
+	if err := s.deliverReceiptMail(&mail, now); err != nil {
+		if _, ok := err.(dto.SendMailError); !ok {
+			return ReceiptMail{}, err
+		}
+	}
+
+	var receiptMail ReceiptMail
+	receiptMail.ToReceiptMailServiceObject(mail)
+	return receiptMail, nil
+}
+
+func (s *service) GetReceiptMails(status string) ([]ReceiptMail, error) {
+	switch status {
+	case "", models.MailPending, models.MailSending, models.MailSent, models.MailFailed:
+	default:
+		return nil, dto.ReceiptMailError{Message: "status must be pending, sending, sent or failed"}
+	}
+
+	modelMails, err := s.Repo.GetReceiptMails(status)
+	if err != nil {
+		return nil, err
+	}
+
+	mails := []ReceiptMail{}
+	for _, modelMail := range modelMails {
+		mail := ReceiptMail{}
+		mail.ToReceiptMailServiceObject(modelMail)
+		mails = append(mails, mail)
+	}
+	return mails, nil
+}
+
+// deliverReceiptMail sends one receipt email the caller has claimed and
+// records the attempt. A failed send goes back to pending, put off by the
+// backoff times the attempts so far, and is given up on after
+// receiptMailAttempts tries. Only a failure to record the attempt is worse
+// than a SendMailError.
+func (s *service) deliverReceiptMail(mail *models.ReceiptMail, now time.Time) error {
+	body, err := s.receiptMailBody(*mail)
+	if err != nil {
+		return err
+	}
+
+	sendErr := s.SendMail(receiptMailSubject, body, mail.Mail)
+	mail.Attempts++
+	if sendErr != nil {
+		mail.Status = models.MailPending
+		mail.Error = sendErr.Error()
+		mail.NextAttempt = now.Add(time.Duration(mail.Attempts) * receiptMailBackoff)
+		if mail.Attempts >= receiptMailAttempts {
+			mail.Status = models.MailFailed
+		}
+	} else {
+		mail.Status = models.MailSent
+		mail.Error = ""
+		mail.SentAt = &now
+	}
+
+	if err := s.Repo.UpdateReceiptMail(*mail); err != nil {
+		return err
+	}
+	if sendErr != nil {
+		return dto.SendMailError{Message: sendErr.Error()}
+	}
+	return nil
+}
+
+// receiptMailBody renders the email with the balance recorded when the
+// payment was booked, not the one at the time of sending.
+func (s *service) receiptMailBody(mail models.ReceiptMail) (string, error) {
+	receipt, err := s.Repo.GetPaymentReceipt(mail.ReceiptNo)
+	if err != nil {
+		return "", err
+	}
+
+	months, err := s.Repo.GetPaymentPeriods(receipt.EntryID)
+	if err != nil {
+		return "", err
+	}
+
+	balance := "nothing owed"
+	if mail.Balance.IsPositive() {
+		balance = mail.Balance.String() + " TL owed"
+	} else if mail.Balance.IsNegative() {
+		balance = mail.Balance.Neg().String() + " TL in credit"
+	}
+
+	var body bytes.Buffer
+	err = receiptMailTemplate.Execute(&body, receiptMailData{
+		FlatNo:    receipt.FlatNo,
+		ReceiptNo: receipt.Reference(),
+		PaidAt:    receipt.PaidAt.Format("02.01.2006 15:04"),
+		Amount:    receipt.Amount.String(),
+		Months:    months,
+		Balance:   balance,
+	})
+	if err != nil {
+		return "", err
+	}
+	return body.String(), nil
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	mocks "github.com/pragmataW/apartment_management/service_mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newMailServer(t *testing.T, status int, mails *[]map[string]interface{}) *mocks.IConfigManager {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var mail map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&mail)
+		*mails = append(*mails, mail)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	configManagerMock := new(mocks.IConfigManager)
+	configManagerMock.On("GetFromMail").Return("site@mail.com")
+	configManagerMock.On("GetMailServer").Return(server.URL)
+	return configManagerMock
+}
+
+func mockReceiptMailBody(repoMock *mocks.IRepo) {
+	repoMock.On("GetPaymentReceipt", 12).Return(models.PaymentReceipt{
+		ReceiptNo: 12, FlatNo: 3, Amount: money.New(12000), Method: models.MethodOnline,
+		PaidAt: time.Date(2026, 10, 17, 14, 30, 0, 0, time.Local), EntryID: 40,
+	}, nil)
+	repoMock.On("GetPaymentPeriods", 40).Return([]string{"2026-08", "2026-09"}, nil)
+}
+
+func claimedReceiptMail() models.ReceiptMail {
+	return models.ReceiptMail{ID: 1, ReceiptNo: 12, Mail: "deneme@mail.com", Balance: money.New(4000), Status: models.MailSending}
+}
+
+func TestSendPendingReceiptMailsGivesUp(t *testing.T) {
+	var mails []map[string]interface{}
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock), WithConfigManager(newMailServer(t, http.StatusInternalServerError, &mails)))
+
+	now := time.Now()
+	repoMock.On("GetDueReceiptMails", now).Return([]models.ReceiptMail{
+		{ID: 1, ReceiptNo: 12, Mail: "deneme@mail.com", Status: models.MailPending, Attempts: receiptMailAttempts - 1},
+	}, nil)
+	repoMock.On("ClaimReceiptMail", 1, now, now.Add(receiptMailClaim)).Return(models.ReceiptMail{
+		ID: 1, ReceiptNo: 12, Mail: "deneme@mail.com", Balance: money.New(4000), Status: models.MailSending, Attempts: receiptMailAttempts - 1,
+	}, nil)
+	mockReceiptMailBody(repoMock)
+	repoMock.On("UpdateReceiptMail", mock.MatchedBy(func(mail models.ReceiptMail) bool {
+		return mail.Status == models.MailFailed && mail.Attempts == receiptMailAttempts
+	})).Return(nil)
+
+	sent, err := service.SendPendingReceiptMails(now)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	assert.Len(t, mails, 1)
+	repoMock.AssertExpectations(t)
+}
+
+func TestSendPendingReceiptMailsSkipsClaimed(t *testing.T) {
+	var mails []map[string]interface{}
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock), WithConfigManager(newMailServer(t, http.StatusOK, &mails)))
+
+	now := time.Now()
+	repoMock.On("GetDueReceiptMails", now).Return([]models.ReceiptMail{
+		{ID: 2, ReceiptNo: 11, Mail: "other@mail.com", Status: models.MailPending},
+		{ID: 1, ReceiptNo: 12, Mail: "deneme@mail.com", Status: models.MailPending},
+	}, nil)
+	repoMock.On("ClaimReceiptMail", 2, now, now.Add(receiptMailClaim)).Return(models.ReceiptMail{}, dto.ReceiptMailClaimed{Message: "receipt mail is being sent or not due yet"})
+	repoMock.On("ClaimReceiptMail", 1, now, now.Add(receiptMailClaim)).Return(claimedReceiptMail(), nil)
+	mockReceiptMailBody(repoMock)
+	repoMock.On("UpdateReceiptMail", mock.MatchedBy(func(mail models.ReceiptMail) bool {
+		return mail.ID == 1 && mail.Status == models.MailSent
+	})).Return(nil)
+
+	sent, err := service.SendPendingReceiptMails(now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Len(t, mails, 1)
+	assert.Equal(t, "deneme@mail.com", mails[0]["to_email"])
+	assert.Contains(t, mails[0]["html"], "40.00 TL owed")
+	repoMock.AssertExpectations(t)
+}
+
+func TestRetryReceiptMail(t *testing.T) {
+	var mails []map[string]interface{}
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock), WithConfigManager(newMailServer(t, http.StatusOK, &mails)))
+
+	repoMock.On("ClaimReceiptMailForRetry", 1, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(claimedReceiptMail(), nil)
+	mockReceiptMailBody(repoMock)
+	repoMock.On("UpdateReceiptMail", mock.MatchedBy(func(mail models.ReceiptMail) bool {
+		return mail.Status == models.MailSent && mail.Attempts == 1
+	})).Return(nil)
+
+	mail, err := service.RetryReceiptMail(1)
+	assert.NoError(t, err)
+	assert.Equal(t, models.MailSent, mail.Status)
+	assert.Len(t, mails, 1)
+	repoMock.AssertExpectations(t)
+}
+
+func TestRetryReceiptMailClaimed(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("ClaimReceiptMailForRetry", 1, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(models.ReceiptMail{}, dto.ReceiptMailClaimed{Message: "receipt mail is already sent"})
+
+	_, err := service.RetryReceiptMail(1)
+	assert.EqualError(t, err, "receipt mail is already sent")
+	repoMock.AssertNotCalled(t, "UpdateReceiptMail", mock.Anything)
+}
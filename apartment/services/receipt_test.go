@@ -0,0 +1,64 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	mocks "github.com/pragmataW/apartment_management/service_mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRecordManualPayment(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	paidAt := time.Date(2026, 10, 10, 0, 0, 0, 0, time.Local)
+	repoMock.On("AddManualPayment", mock.MatchedBy(func(receipt *models.PaymentReceipt) bool {
+		return receipt.FlatNo == 3 && receipt.Amount == money.New(12050) && receipt.Method == models.MethodCash &&
+			receipt.PaidAt.Equal(paidAt) && receipt.CollectedBy == "admin"
+	})).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.PaymentReceipt).ReceiptNo = 12
+	}).Return(nil)
+
+	receipt, err := service.RecordManualPayment(PaymentReceipt{FlatNo: 3, Amount: money.New(12050), Method: models.MethodCash, PaidAt: paidAt, CreatedBy: "admin"})
+	assert.NoError(t, err)
+	assert.Equal(t, 12, receipt.ReceiptNo)
+	assert.Equal(t, "R-000012", receipt.Reference)
+}
+
+func TestRecordManualPayment_Invalid(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	_, err := service.RecordManualPayment(PaymentReceipt{FlatNo: 3, Amount: money.New(0), Method: models.MethodCash})
+	assert.IsType(t, dto.ManualPaymentError{}, err)
+
+	_, err = service.RecordManualPayment(PaymentReceipt{FlatNo: 3, Amount: money.New(1000), Method: "card"})
+	assert.IsType(t, dto.ManualPaymentError{}, err)
+
+	_, err = service.RecordManualPayment(PaymentReceipt{FlatNo: 3, Amount: money.New(1000), Method: models.MethodCheque, PaidAt: time.Now().AddDate(0, 0, 2)})
+	assert.IsType(t, dto.ManualPaymentError{}, err)
+
+	repoMock.AssertNotCalled(t, "AddManualPayment", mock.Anything)
+}
+
+func TestCancelManualPayment(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	_, err := service.CancelManualPayment(4, "admin", "  ")
+	assert.IsType(t, dto.ManualPaymentError{}, err)
+	repoMock.AssertNotCalled(t, "CancelManualPayment", mock.Anything, mock.Anything, mock.Anything)
+
+	now := time.Now()
+	repoMock.On("CancelManualPayment", 4, "admin", "wrong flat").Return(models.PaymentReceipt{ReceiptNo: 4, CancelledAt: &now, CancelReason: "wrong flat"}, nil)
+
+	receipt, err := service.CancelManualPayment(4, "admin", "wrong flat")
+	assert.NoError(t, err)
+	assert.NotNil(t, receipt.CancelledAt)
+	assert.Equal(t, "wrong flat", receipt.CancelReason)
+}
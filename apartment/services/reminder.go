@@ -0,0 +1,200 @@
+package services
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+)
+
+const reminderSubject = "Overdue dues reminder"
+
+func (s *service) GetReminderPolicy() (ReminderPolicy, error) {
+	now := time.Now()
+	days, err := s.floatSetting(models.SettingReminderDays, now, dto.DefaultReminderDays)
+	if err != nil {
+		return ReminderPolicy{}, err
+	}
+
+	interval, err := s.floatSetting(models.SettingReminderIntervalDays, now, dto.DefaultReminderIntervalDays)
+	if err != nil {
+		return ReminderPolicy{}, err
+	}
+
+	enabled, err := s.boolSetting(models.SettingRemindersEnabled, now, dto.DefaultRemindersEnabled)
+	if err != nil {
+		return ReminderPolicy{}, err
+	}
+
+	return ReminderPolicy{Enabled: enabled, DaysAfterPayDay: int(days), IntervalDays: int(interval)}, nil
+}
+
+func (s *service) ChangeReminderPolicy(policy ReminderPolicy, changedBy string) error {
+	if policy.DaysAfterPayDay < 0 || policy.DaysAfterPayDay > 27 {
+		return dto.ReminderPolicyError{Message: "days after pay day must be between 0 and 27, 0 being the pay day itself"}
+	}
+	if policy.IntervalDays < 1 || policy.IntervalDays > 90 {
+		return dto.ReminderPolicyError{Message: "interval days must be between 1 and 90"}
+	}
+
+	now := time.Now()
+	settings := []models.Setting{
+		{Key: models.SettingRemindersEnabled, Value: strconv.FormatBool(policy.Enabled)},
+		{Key: models.SettingReminderDays, Value: strconv.Itoa(policy.DaysAfterPayDay)},
+		{Key: models.SettingReminderIntervalDays, Value: strconv.Itoa(policy.IntervalDays)},
+	}
+	for _, setting := range settings {
+		setting.ChangedBy = changedBy
+		setting.EffectiveFrom = now
+		if err := s.Repo.SaveSetting(setting); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SendDuesReminders emails every flat that has charges past their due date,
+// once the policy's number of days after the last pay day has passed. It does
+// nothing while reminders are off. A flat
+// reminded within the interval is skipped, so running it every day sends a
+// flat at most one reminder per interval. Every attempt is logged, and a
+// failed send is tried again on the next run. It returns how many reminders
+// went out.
+func (s *service) SendDuesReminders(now time.Time) (int, error) {
+	policy, err := s.GetReminderPolicy()
+	if err != nil {
+		return 0, err
+	}
+	if !policy.Enabled {
+		return 0, nil
+	}
+
+	payDay, err := s.GetPayDay()
+	if err != nil {
+		return 0, err
+	}
+	lastPayDay := payDate(time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()), payDay)
+	if lastPayDay.After(now) {
+		lastPayDay = lastPayDay.AddDate(0, -1, 0)
+	}
+	if daysBetween(lastPayDay, now) < policy.DaysAfterPayDay {
+		return 0, nil
+	}
+
+	charges, err := s.Repo.GetDueCharges(now)
+	if err != nil {
+		return 0, err
+	}
+	if len(charges) == 0 {
+		return 0, nil
+	}
+
+	modelFlats, err := s.Repo.GetAllInfoAboutAllFlats()
+	if err != nil {
+		return 0, err
+	}
+	flats := map[int]models.Apartment{}
+	for _, flat := range modelFlats {
+		flats[flat.FlatNo] = flat
+	}
+
+	lastSent, err := s.Repo.GetLastReminderTimes()
+	if err != nil {
+		return 0, err
+	}
+
+	owed := map[int]money.Money{}
+	oldest := map[int]time.Time{}
+	for _, charge := range charges {
+		owed[charge.FlatNo] = owed[charge.FlatNo].Add(charge.Remaining)
+		if due, ok := oldest[charge.FlatNo]; !ok || charge.DueDate.Before(due) {
+			oldest[charge.FlatNo] = charge.DueDate
+		}
+	}
+	flatNos := make([]int, 0, len(owed))
+	for flatNo := range owed {
+		flatNos = append(flatNos, flatNo)
+	}
+	sort.Ints(flatNos)
+
+	sent := 0
+	for _, flatNo := range flatNos {
+		flat, ok := flats[flatNo]
+		if !ok || flat.Mail == "" || !owed[flatNo].IsPositive() {
+			continue
+		}
+		if last, ok := lastSent[flatNo]; ok && daysBetween(last, now) < policy.IntervalDays {
+			continue
+		}
+
+		reminder := models.DuesReminder{
+			FlatNo: flatNo,
+			Mail:   flat.Mail,
+			Amount: owed[flatNo],
+			Status: models.ReminderSent,
+			SentAt: now,
+		}
+		body := s.reminderBody(flat, owed[flatNo], oldest[flatNo])
+		if err := s.SendMail(reminderSubject, body, flat.Mail); err != nil {
+			reminder.Status = models.ReminderFailed
+			reminder.Error = err.Error()
+		} else {
+			sent++
+		}
+
+		if err := s.Repo.SaveDuesReminder(&reminder); err != nil {
+			return sent, err
+		}
+	}
+
+	if sent > 0 {
+		log.Println("sent " + strconv.Itoa(sent) + " dues reminders")
+	}
+	return sent, nil
+}
+
+func (s *service) sendDuesReminders() {
+	_, err := s.SendDuesReminders(time.Now())
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (s *service) GetDuesReminders(flatNo int) ([]DuesReminder, error) {
+	modelReminders, err := s.Repo.GetDuesReminders(flatNo)
+	if err != nil {
+		return nil, err
+	}
+
+	reminders := []DuesReminder{}
+	for _, modelReminder := range modelReminders {
+		reminder := DuesReminder{}
+		reminder.ToDuesReminderServiceObject(modelReminder)
+		reminders = append(reminders, reminder)
+	}
+	return reminders, nil
+}
+
+func (s *service) reminderBody(flat models.Apartment, owed money.Money, oldest time.Time) string {
+	name := strings.TrimSpace(flat.OwnerName + " " + flat.OwnerSurname)
+	if name == "" {
+		name = "resident"
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "<p>Dear %s,</p>", html.EscapeString(name))
+	fmt.Fprintf(&body, "<p>Flat %d owes %s TL in charges that are past their due date, the oldest since %s.</p>",
+		flat.FlatNo, owed.String(), oldest.Format("02.01.2006"))
+	if link := s.ConfigManager.GetPaymentPageUrl(); link != "" {
+		fmt.Fprintf(&body, `<p>You can pay online at <a href="%s">%s</a>.</p>`, html.EscapeString(link), html.EscapeString(link))
+	}
+	body.WriteString("<p>If you have paid in the meantime, please ignore this email.</p>")
+	return body.String()
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	mocks "github.com/pragmataW/apartment_management/service_mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSendDuesReminders(t *testing.T) {
+	var mails []map[string]interface{}
+	repoMock := new(mocks.IRepo)
+	configManagerMock := newMailServer(t, http.StatusOK, &mails)
+	service := NewService(WithRepo(repoMock), WithConfigManager(configManagerMock))
+
+	now := time.Date(2026, 10, 25, 9, 0, 0, 0, time.Local)
+	configManagerMock.On("GetPaymentPageUrl").Return("https://site.example/pay")
+	repoMock.On("GetSetting", models.SettingRemindersEnabled, mock.Anything).Return(models.Setting{Value: "true"}, nil)
+	repoMock.On("GetSetting", models.SettingReminderDays, mock.Anything).Return(models.Setting{Value: "5"}, nil)
+	repoMock.On("GetSetting", models.SettingPayDay, mock.Anything).Return(models.Setting{Value: "15"}, nil)
+	repoMock.On("GetSetting", mock.Anything, mock.Anything).Return(models.Setting{}, dto.ThereIsNoSetting{})
+	repoMock.On("GetDueCharges", now).Return([]models.OpenCharge{
+		{LedgerEntry: models.LedgerEntry{FlatNo: 1, DueDate: time.Date(2026, 9, 15, 0, 0, 0, 0, time.Local)}, Remaining: money.New(4000)},
+		{LedgerEntry: models.LedgerEntry{FlatNo: 1, DueDate: time.Date(2026, 10, 15, 0, 0, 0, 0, time.Local)}, Remaining: money.New(4000)},
+		{LedgerEntry: models.LedgerEntry{FlatNo: 2, DueDate: time.Date(2026, 10, 15, 0, 0, 0, 0, time.Local)}, Remaining: money.New(4000)},
+		{LedgerEntry: models.LedgerEntry{FlatNo: 3, DueDate: time.Date(2026, 10, 15, 0, 0, 0, 0, time.Local)}, Remaining: money.New(4000)},
+	}, nil)
+	repoMock.On("GetAllInfoAboutAllFlats").Return([]models.Apartment{
+		{FlatNo: 1, Mail: "a@mail.com", OwnerName: "Ayse"},
+		{FlatNo: 2, Mail: "b@mail.com"},
+		{FlatNo: 3},
+	}, nil)
+	repoMock.On("GetLastReminderTimes").Return(map[int]time.Time{2: now.AddDate(0, 0, -3), 1: now.AddDate(0, 0, -8)}, nil)
+	repoMock.On("SaveDuesReminder", mock.MatchedBy(func(reminder *models.DuesReminder) bool {
+		return reminder.FlatNo == 1 && reminder.Amount == money.New(8000) && reminder.Status == models.ReminderSent && reminder.SentAt == now
+	})).Return(nil)
+
+	sent, err := service.SendDuesReminders(now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Len(t, mails, 1)
+	assert.Equal(t, "a@mail.com", mails[0]["to_email"])
+	assert.Contains(t, mails[0]["html"], "80.00 TL")
+	assert.Contains(t, mails[0]["html"], "15.09.2026")
+	assert.Contains(t, mails[0]["html"], "https://site.example/pay")
+	repoMock.AssertExpectations(t)
+}
+
+func TestSendDuesRemindersBeforeReminderDay(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetSetting", models.SettingRemindersEnabled, mock.Anything).Return(models.Setting{Value: "true"}, nil)
+	repoMock.On("GetSetting", models.SettingReminderDays, mock.Anything).Return(models.Setting{Value: "5"}, nil)
+	repoMock.On("GetSetting", models.SettingPayDay, mock.Anything).Return(models.Setting{Value: "15"}, nil)
+	repoMock.On("GetSetting", mock.Anything, mock.Anything).Return(models.Setting{}, dto.ThereIsNoSetting{})
+
+	sent, err := service.SendDuesReminders(time.Date(2026, 10, 17, 9, 0, 0, 0, time.Local))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	repoMock.AssertNotCalled(t, "GetDueCharges", mock.Anything)
+}
+
+func TestSendDuesRemindersOffByDefault(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetSetting", mock.Anything, mock.Anything).Return(models.Setting{}, dto.ThereIsNoSetting{})
+
+	sent, err := service.SendDuesReminders(time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	repoMock.AssertNotCalled(t, "GetDueCharges", mock.Anything)
+}
+
+func TestSendDuesRemindersDisabled(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetSetting", models.SettingRemindersEnabled, mock.Anything).Return(models.Setting{Value: "false"}, nil)
+	repoMock.On("GetSetting", models.SettingReminderDays, mock.Anything).Return(models.Setting{Value: "5"}, nil)
+	repoMock.On("GetSetting", mock.Anything, mock.Anything).Return(models.Setting{}, dto.ThereIsNoSetting{})
+
+	sent, err := service.SendDuesReminders(time.Date(2026, 10, 25, 9, 0, 0, 0, time.Local))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	repoMock.AssertNotCalled(t, "GetDueCharges", mock.Anything)
+}
+
+func TestGetReminderPolicy(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetSetting", models.SettingRemindersEnabled, mock.Anything).Return(models.Setting{Value: "true"}, nil)
+	repoMock.On("GetSetting", mock.Anything, mock.Anything).Return(models.Setting{}, dto.ThereIsNoSetting{})
+
+	policy, err := service.GetReminderPolicy()
+	assert.NoError(t, err)
+	assert.Equal(t, ReminderPolicy{Enabled: true, DaysAfterPayDay: 0, IntervalDays: 7}, policy)
+}
+
+func TestChangeReminderPolicyRange(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	err := service.ChangeReminderPolicy(ReminderPolicy{DaysAfterPayDay: 30, IntervalDays: 7}, "admin")
+	assert.IsType(t, dto.ReminderPolicyError{}, err)
+
+	err = service.ChangeReminderPolicy(ReminderPolicy{DaysAfterPayDay: 5, IntervalDays: 0}, "admin")
+	assert.IsType(t, dto.ReminderPolicyError{}, err)
+	repoMock.AssertNotCalled(t, "SaveSetting", mock.Anything)
+}
+
+func TestChangeReminderPolicy(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("SaveSetting", mock.MatchedBy(func(setting models.Setting) bool {
+		return setting.Key == models.SettingRemindersEnabled && setting.Value == "true"
+	})).Return(nil)
+	repoMock.On("SaveSetting", mock.MatchedBy(func(setting models.Setting) bool {
+		return setting.Key == models.SettingReminderDays && setting.Value == "0"
+	})).Return(nil)
+	repoMock.On("SaveSetting", mock.MatchedBy(func(setting models.Setting) bool {
+		return setting.Key == models.SettingReminderIntervalDays && setting.Value == "7"
+	})).Return(nil)
+
+	err := service.ChangeReminderPolicy(ReminderPolicy{Enabled: true, DaysAfterPayDay: 0, IntervalDays: 7}, "admin")
+	assert.NoError(t, err)
+	repoMock.AssertExpectations(t)
+}
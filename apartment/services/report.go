@@ -0,0 +1,114 @@
+package services
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+)
+
+var (
+	yearPattern    = regexp.MustCompile(`^\d{4}$`)
+	quarterPattern = regexp.MustCompile(`^(\d{4})-Q([1-4])$`)
+	monthPattern   = regexp.MustCompile(`^\d{4}-\d{2}$`)
+)
+
+// ReportRange resolves a report period to the dates it covers. A period is a
+// year ("2026"), a quarter ("2026-Q3") or a month ("2026-10"); to is the first
+// day after it.
+func ReportRange(period string) (time.Time, time.Time, error) {
+	switch {
+	case yearPattern.MatchString(period):
+		year, _ := strconv.Atoi(period)
+		from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+		return from, from.AddDate(1, 0, 0), nil
+	case quarterPattern.MatchString(period):
+		match := quarterPattern.FindStringSubmatch(period)
+		year, _ := strconv.Atoi(match[1])
+		quarter, _ := strconv.Atoi(match[2])
+		from := time.Date(year, time.Month(quarter*3-2), 1, 0, 0, 0, 0, time.Local)
+		return from, from.AddDate(0, 3, 0), nil
+	case monthPattern.MatchString(period):
+		from, err := time.ParseInLocation("2006-01", period, time.Local)
+		if err != nil {
+			break
+		}
+		return from, from.AddDate(0, 1, 0), nil
+	}
+	return time.Time{}, time.Time{}, dto.ReportPeriodError{Message: "period must look like 2026, 2026-Q3 or 2026-10"}
+}
+
+// GetFinancialReport summarizes a month, quarter or year. Accrued amounts and
+// the collection rate cover the charges billed for the periods in range;
+// collections, waivers and expenses cover what was booked within its dates.
+// Balance is what was collected less what was spent.
+func (s *service) GetFinancialReport(period string) (FinancialReport, error) {
+	from, to, err := ReportRange(period)
+	if err != nil {
+		return FinancialReport{}, err
+	}
+
+	totals, err := s.Repo.GetReportTotals(BillingPeriod(from), BillingPeriod(to.AddDate(0, 0, -1)), from, to)
+	if err != nil {
+		return FinancialReport{}, err
+	}
+
+	report := FinancialReport{
+		Period:           period,
+		From:             from,
+		To:               to.AddDate(0, 0, -1),
+		TotalOutstanding: totals.TotalOutstanding,
+		Expenses:         []ExpenseLine{},
+	}
+
+	paid := money.New(0)
+	for _, charge := range totals.Charges {
+		switch charge.Type {
+		case models.LedgerAccrual:
+			report.DuesAccrued = report.DuesAccrued.Add(charge.Charged)
+		case models.LedgerPenalty:
+			report.PenaltiesAccrued = report.PenaltiesAccrued.Add(charge.Charged)
+		case models.LedgerAssessment:
+			report.AssessmentsAccrued = report.AssessmentsAccrued.Add(charge.Charged)
+		default:
+			report.OtherCharges = report.OtherCharges.Add(charge.Charged)
+		}
+		report.TotalAccrued = report.TotalAccrued.Add(charge.Charged)
+		report.PeriodOutstanding = report.PeriodOutstanding.Add(charge.Outstanding)
+		paid = paid.Add(charge.Paid)
+	}
+
+	for _, credit := range totals.Credits {
+		switch {
+		case (credit.Type == models.LedgerPayment || credit.Type == models.LedgerReversal) && credit.Source == models.SourcePaytr:
+			report.CollectedPaytr = report.CollectedPaytr.Add(credit.Amount)
+		case credit.Type == models.LedgerPayment || credit.Type == models.LedgerReversal:
+			report.CollectedManual = report.CollectedManual.Add(credit.Amount)
+		case credit.Type == models.LedgerWaiver:
+			report.Waived = report.Waived.Add(credit.Amount)
+		default:
+			report.OtherCredits = report.OtherCredits.Add(credit.Amount)
+		}
+	}
+	report.TotalCollected = report.CollectedPaytr.Add(report.CollectedManual)
+
+	for _, expense := range totals.Expenses {
+		report.Expenses = append(report.Expenses, ExpenseLine{
+			Category: expense.CategoryName,
+			Count:    expense.Count,
+			Amount:   expense.Amount,
+		})
+		report.TotalExpenses = report.TotalExpenses.Add(expense.Amount)
+	}
+	report.Balance = report.TotalCollected.Sub(report.TotalExpenses)
+
+	if report.TotalAccrued.IsPositive() {
+		report.CollectionRate = math.Round(float64(paid.Minor)/float64(report.TotalAccrued.Minor)*10000) / 100
+	}
+
+	return report, nil
+}
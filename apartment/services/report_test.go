@@ -0,0 +1,82 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	mocks "github.com/pragmataW/apartment_management/service_mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReportRange(t *testing.T) {
+	from, to, err := ReportRange("2026")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local), from)
+	assert.Equal(t, time.Date(2027, 1, 1, 0, 0, 0, 0, time.Local), to)
+
+	from, to, err = ReportRange("2026-Q3")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 7, 1, 0, 0, 0, 0, time.Local), from)
+	assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local), to)
+
+	from, to, err = ReportRange("2026-02")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.Local), from)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local), to)
+
+	for _, period := range []string{"", "26", "2026-Q5", "2026-13", "2026/10"} {
+		_, _, err = ReportRange(period)
+		assert.IsType(t, dto.ReportPeriodError{}, err, period)
+	}
+}
+
+func TestGetFinancialReport(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetReportTotals", "2026-07", "2026-09", time.Date(2026, 7, 1, 0, 0, 0, 0, time.Local), time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)).Return(models.ReportTotals{
+		Charges: []models.ChargeTotal{
+			{Type: models.LedgerAccrual, Charged: money.New(900000), Paid: money.New(600000), Outstanding: money.New(250000)},
+			{Type: models.LedgerPenalty, Charged: money.New(15000), Paid: money.New(5000), Outstanding: money.New(10000)},
+			{Type: models.LedgerAssessment, Charged: money.New(85000), Paid: money.New(0), Outstanding: money.New(85000)},
+		},
+		Credits: []models.CreditTotal{
+			{Type: models.LedgerPayment, Source: models.SourcePaytr, Amount: money.New(520000)},
+			{Type: models.LedgerPayment, Source: models.SourceAdmin, Amount: money.New(100000)},
+			{Type: models.LedgerWaiver, Source: models.SourceAdmin, Amount: money.New(50000)},
+		},
+		Expenses: []models.ExpenseTotal{
+			{CategoryName: "elevator", Count: 3, Amount: money.New(240000)},
+			{CategoryName: "cleaning", Count: 1, Amount: money.New(150050)},
+		},
+		TotalOutstanding: money.New(730000),
+	}, nil)
+
+	report, err := service.GetFinancialReport("2026-Q3")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 9, 30, 0, 0, 0, 0, time.Local), report.To)
+	assert.Equal(t, money.New(900000), report.DuesAccrued)
+	assert.Equal(t, money.New(1000000), report.TotalAccrued)
+	assert.Equal(t, money.New(620000), report.TotalCollected)
+	assert.Equal(t, money.New(100000), report.CollectedManual)
+	assert.Equal(t, money.New(50000), report.Waived)
+	assert.Equal(t, money.New(390050), report.TotalExpenses)
+	assert.Equal(t, money.New(229950), report.Balance)
+	assert.Equal(t, 60.5, report.CollectionRate)
+	assert.Equal(t, money.New(345000), report.PeriodOutstanding)
+	assert.Equal(t, money.New(730000), report.TotalOutstanding)
+	assert.Len(t, report.Expenses, 2)
+}
+
+func TestGetFinancialReport_InvalidPeriod(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	_, err := service.GetFinancialReport("last-month")
+	assert.IsType(t, dto.ReportPeriodError{}, err)
+	repoMock.AssertNotCalled(t, "GetReportTotals", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+)
+
+// residentFlat loads the flat a resident's token was issued for. The email
+// of the token has to still be the flat's email, so a token stops working
+// for a flat once its owner is replaced.
+func (s *service) residentFlat(flatNo int, email string) (models.Apartment, error) {
+	flat, err := s.Repo.GetAllInfoAboutFlat(flatNo)
+	if err != nil {
+		return models.Apartment{}, err
+	}
+	if email == "" || flat.Mail != email {
+		return models.Apartment{}, dto.FlatAccessDenied{Message: "token does not belong to this flat"}
+	}
+	return flat, nil
+}
+
+// GetResidentFlat returns the resident's flat with its dues profile and
+// account summary. The password is left out.
+func (s *service) GetResidentFlat(flatNo int, email string) (Apartment, error) {
+	flat, err := s.residentFlat(flatNo, email)
+	if err != nil {
+		return Apartment{}, err
+	}
+
+	summary, err := s.Repo.GetDuesSummary(flatNo)
+	if err != nil {
+		return Apartment{}, err
+	}
+
+	price, err := s.duesPriceForPeriod(BillingPeriod(time.Now()))
+	if err != nil {
+		return Apartment{}, err
+	}
+
+	flat.Password = ""
+	var apartment Apartment
+	apartment.ToApartmentServiceObject(flat)
+	apartment.ApplyDuesSummary(summary)
+	apartment.MonthlyDues = DuesAmount(flat, price)
+	return apartment, nil
+}
+
+// GetResidentDues lists what the resident's flat still owes, oldest due
+// first, and what it comes to in total.
+func (s *service) GetResidentDues(flatNo int, email string) ([]OpenCharge, money.Money, error) {
+	if _, err := s.residentFlat(flatNo, email); err != nil {
+		return nil, money.Money{}, err
+	}
+
+	modelCharges, err := s.Repo.GetOpenCharges(flatNo)
+	if err != nil {
+		return nil, money.Money{}, err
+	}
+
+	charges := []OpenCharge{}
+	total := money.New(0)
+	for _, modelCharge := range modelCharges {
+		charge := OpenCharge{}
+		charge.ToOpenChargeServiceObject(modelCharge)
+		charges = append(charges, charge)
+		total = total.Add(charge.Remaining)
+	}
+	return charges, total, nil
+}
+
+// GetResidentPayments lists the payments that stand for the resident's
+// flat, newest first. Cancelled and refunded payments are not listed.
+func (s *service) GetResidentPayments(flatNo int, email string) ([]LedgerEntry, error) {
+	if _, err := s.residentFlat(flatNo, email); err != nil {
+		return nil, err
+	}
+
+	modelPayments, err := s.Repo.GetPayments(flatNo)
+	if err != nil {
+		return nil, err
+	}
+
+	payments := []LedgerEntry{}
+	for _, modelPayment := range modelPayments {
+		payment := LedgerEntry{}
+		payment.ToLedgerEntryServiceObject(modelPayment)
+		payment.Amount = payment.Amount.Neg()
+		payments = append(payments, payment)
+	}
+	return payments, nil
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	mocks "github.com/pragmataW/apartment_management/service_mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetResidentFlat(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetAllInfoAboutFlat", 3).Return(models.Apartment{FlatNo: 3, Mail: "user@mail.com", Password: "secret", DuesCoefficient: 1.5}, nil)
+	repoMock.On("GetDuesSummary", 3).Return(models.DuesSummary{FlatNo: 3, Balance: money.New(12000), OpenDues: 2}, nil)
+	repoMock.On("GetSetting", models.SettingDuesPrice, mock.Anything).Return(models.Setting{Value: "40.00"}, nil)
+
+	apartment, err := service.GetResidentFlat(3, "user@mail.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "", apartment.Password)
+	assert.Equal(t, 2, apartment.DuesCount)
+	assert.Equal(t, money.New(6000), apartment.MonthlyDues)
+}
+
+func TestGetResidentFlat_OtherEmail(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetAllInfoAboutFlat", 3).Return(models.Apartment{FlatNo: 3, Mail: "new-owner@mail.com"}, nil)
+
+	_, err := service.GetResidentFlat(3, "user@mail.com")
+	assert.IsType(t, dto.FlatAccessDenied{}, err)
+	repoMock.AssertNotCalled(t, "GetDuesSummary", mock.Anything)
+}
+
+func TestGetResidentDues(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetAllInfoAboutFlat", 3).Return(models.Apartment{FlatNo: 3, Mail: "user@mail.com"}, nil)
+	repoMock.On("GetOpenCharges", 3).Return([]models.OpenCharge{
+		{LedgerEntry: models.LedgerEntry{ID: 1, Period: "2026-09", Type: models.LedgerAccrual, Amount: money.New(4000)}, Remaining: money.New(1550)},
+		{LedgerEntry: models.LedgerEntry{ID: 2, Period: "2026-10", Type: models.LedgerAccrual, Amount: money.New(4000)}, Remaining: money.New(4000)},
+	}, nil)
+
+	charges, total, err := service.GetResidentDues(3, "user@mail.com")
+	assert.NoError(t, err)
+	assert.Len(t, charges, 2)
+	assert.Equal(t, money.New(5550), total)
+}
+
+func TestGetResidentPayments(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetAllInfoAboutFlat", 3).Return(models.Apartment{FlatNo: 3, Mail: "user@mail.com"}, nil)
+	repoMock.On("GetPayments", 3).Return([]models.LedgerEntry{
+		{ID: 4, Type: models.LedgerPayment, Amount: money.New(-8000), Reference: "oid-2", CreatedAt: time.Date(2026, 10, 5, 0, 0, 0, 0, time.Local)},
+		{ID: 2, Type: models.LedgerPayment, Amount: money.New(-4000), Reference: "oid-1", CreatedAt: time.Date(2026, 9, 5, 0, 0, 0, 0, time.Local)},
+	}, nil)
+
+	payments, err := service.GetResidentPayments(3, "user@mail.com")
+	assert.NoError(t, err)
+	assert.Len(t, payments, 2)
+	assert.Equal(t, "oid-2", payments[0].Reference)
+	assert.Equal(t, money.New(8000), payments[0].Amount)
+	assert.Equal(t, "oid-1", payments[1].Reference)
+}
@@ -3,16 +3,13 @@ package services
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
-	"io"
 	"log"
 	"net/http"
-	"net/url"
 	"time"
 
 	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
 	"github.com/pragmataW/apartment_management/pkg/jwt"
-	"github.com/robfig/cron/v3"
 )
 
 func (s *service) LoginAdmin(password string) (string, error) {
@@ -111,8 +108,20 @@ func (s *service) GetAllInfoAboutFlat(flatNo int) (Apartment, error) {
 		return Apartment{}, err
 	}
 
+	summary, err := s.Repo.GetDuesSummary(flatNo)
+	if err != nil {
+		return Apartment{}, err
+	}
+
+	price, err := s.duesPriceForPeriod(BillingPeriod(time.Now()))
+	if err != nil {
+		return Apartment{}, err
+	}
+
 	var apartment Apartment
 	apartment.ToApartmentServiceObject(modelApartments)
+	apartment.ApplyDuesSummary(summary)
+	apartment.MonthlyDues = DuesAmount(modelApartments, price)
 
 	return apartment, nil
 }
@@ -123,45 +132,56 @@ func (s *service) GetAllInfoAboutAllFlat() ([]Apartment, error) {
 		return []Apartment{}, err
 	}
 
+	summaries, err := s.Repo.GetDuesSummaries()
+	if err != nil {
+		return []Apartment{}, err
+	}
+
+	price, err := s.duesPriceForPeriod(BillingPeriod(time.Now()))
+	if err != nil {
+		return []Apartment{}, err
+	}
+
+	summaryByFlat := make(map[int]models.DuesSummary, len(summaries))
+	for _, summary := range summaries {
+		summaryByFlat[summary.FlatNo] = summary
+	}
+
 	var apartments []Apartment
 	for _, modelApartment := range modelApartments {
 		apartment := Apartment{}
 		apartment.ToApartmentServiceObject(modelApartment)
+		apartment.ApplyDuesSummary(summaryByFlat[modelApartment.FlatNo])
+		apartment.MonthlyDues = DuesAmount(modelApartment, price)
 		apartments = append(apartments, apartment)
 	}
 	return apartments, nil
 }
 
 func (s *service) AddDues(flatNo int) error {
-	err := s.Repo.AddDues(flatNo)
+	flat, err := s.Repo.GetAllInfoAboutFlat(flatNo)
 	if err != nil {
 		return err
 	}
-	return nil
-}
 
-func (s *service) DeleteDues(flatNo int) error {
-	err := s.Repo.DeleteDues(flatNo)
+	period := BillingPeriod(time.Now())
+	price, err := s.duesPriceForPeriod(period)
 	if err != nil {
 		return err
 	}
-	return nil
-}
 
-func (s *service) ChangeDuesPrice(price float64) {
-	dto.Mutx.Lock()
-	dto.DuesPrice = price
-	dto.Mutx.Unlock()
+	err = s.Repo.AddDues(flatNo, period, DuesAmount(flat, price))
+	if err != nil {
+		return err
+	}
+	return nil
 }
 
-func (s *service) ChangePayDay(payDay int) error {
-	if payDay < 1 || payDay > 28 {
-		return dto.PayDayRangeError{Message: "invalid range"}
+func (s *service) DeleteDues(flatNo int) error {
+	err := s.Repo.DeleteDues(flatNo)
+	if err != nil {
+		return err
 	}
-	dto.Mutx.Lock()
-	dto.PayDay = payDay
-	dto.Mutx.Unlock()
-
 	return nil
 }
 
@@ -225,87 +245,3 @@ func (s *service) SendMail(subject string, body string, mail string) error {
 
 	return nil
 }
-
-func (s *service) GetPaymentToken(payment dto.PaymentSendReq) (string, error) {
-	params := url.Values{
-		"merchant_id":       {payment.MerchantId},
-		"user_ip":           {payment.UserIP},
-		"merchant_oid":      {payment.MerchantOID},
-		"email":             {payment.Email},
-		"payment_amount":    {payment.PaymentAmount},
-		"paytr_token":       {payment.PaytrToken},
-		"user_basket":       {payment.UserBasket},
-		"debug_on":          {payment.DebugOn},
-		"no_installment":    {payment.NoInstallment},
-		"max_installment":   {payment.MaxInstallment},
-		"user_name":         {payment.UserName},
-		"user_address":      {payment.UserAddress},
-		"user_phone":        {payment.UserPhone},
-		"merchant_ok_url":   {payment.OkURL},
-		"merchant_fail_url": {payment.FailURL},
-		"timeout_limit":     {payment.TimeOutLimit},
-		"currency":          {payment.Currency},
-		"test_mode":         {payment.TestMode},
-	}
-
-	resp, err := http.PostForm("https://www.paytr.com/odeme/api/get-token", params)
-	if err != nil {
-		return "", fmt.Errorf("post request error: %v", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("read response body error: %v", err)
-	}
-
-	var res map[string]interface{}
-	if err := json.Unmarshal(body, &res); err != nil {
-		return "", fmt.Errorf("decode response body error: %v", err)
-	}
-
-	if status, ok := res["status"].(string); ok && status == "success" {
-		if token, ok := res["token"].(string); ok {
-			err := s.Repo.AddMerchant(payment.MerchantOID, payment.Email)
-			if err != nil{
-				return "", err
-			}
-			return token, nil
-		} else {
-			return "", fmt.Errorf("there is no token in response")
-		}
-	} else {
-		return "", fmt.Errorf("status not ok: %s", string(body))
-	}
-}
-
-func (s *service) PaymentCallback(merchantOID string) error {
-	email, err := s.Repo.GetEmailFromMerchant(merchantOID)
-	if err != nil {
-		return err
-	}
-
-	err = s.Repo.DeleteDuesByEmail(email)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func (s *service) IncreaseDuesAutomatically() error {
-	cronTab := cron.New()
-
-	_, err := cronTab.AddFunc(fmt.Sprintf("0 0 %d * *", dto.PayDay), func() {
-		err := s.Repo.AddDuesForAll()
-		if err != nil {
-			log.Println(err)
-		}
-	})
-	if err != nil {
-		return err
-	}
-	cronTab.Start()
-	select {}
-}
-
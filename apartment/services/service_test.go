@@ -3,13 +3,16 @@ package services
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/jarcoal/httpmock"
 	"github.com/pragmataW/apartment_management/dto"
 	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
 	mocks "github.com/pragmataW/apartment_management/service_mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestLoginAdmin(t *testing.T) {
@@ -195,15 +198,19 @@ func TestGetAllInfoAboutFlat(t *testing.T) {
 
 	flatNo := 1
 	repoReturn := models.Apartment{
-		FlatNo:       flatNo,
-		OwnerName:    "deneme",
-		OwnerSurname: "deneme",
-		Mail:         "deneme@mail.com",
-		Password:     "123",
-		DuesCount:    1,
+		FlatNo:          flatNo,
+		OwnerName:       "deneme",
+		OwnerSurname:    "deneme",
+		Mail:            "deneme@mail.com",
+		Password:        "123",
+		FlatType:        models.FlatDuplex,
+		DuesCoefficient: 1.5,
 	}
+	summary := models.DuesSummary{FlatNo: flatNo, Balance: money.New(4200), OpenDues: 1, OpenPenalties: money.New(200)}
 
 	repoMock.On("GetAllInfoAboutFlat", 1).Return(repoReturn, nil)
+	repoMock.On("GetDuesSummary", 1).Return(summary, nil)
+	repoMock.On("GetSetting", models.SettingDuesPrice, mock.Anything).Return(models.Setting{Value: "40.00"}, nil)
 	EncryptorMock.On("Decrypt", repoReturn.Password).Return("123", nil)
 
 	actual, err := service.GetAllInfoAboutFlat(flatNo)
@@ -213,7 +220,12 @@ func TestGetAllInfoAboutFlat(t *testing.T) {
 	assert.Equal(t, repoReturn.OwnerSurname, actual.OwnerSurname)
 	assert.Equal(t, repoReturn.Mail, actual.Mail)
 	assert.Equal(t, repoReturn.Password, actual.Password)
-	assert.Equal(t, repoReturn.DuesCount, actual.DuesCount)
+	assert.Equal(t, summary.OpenDues, actual.DuesCount)
+	assert.Equal(t, summary.Balance, actual.Balance)
+	assert.Equal(t, summary.OpenPenalties, actual.Penalties)
+	assert.Equal(t, summary.Credit, actual.Credit)
+	assert.Equal(t, models.FlatDuplex, actual.FlatType)
+	assert.Equal(t, money.New(6000), actual.MonthlyDues)
 }
 
 func TestGetAllInfoAboutAllFlat(t *testing.T) {
@@ -222,14 +234,15 @@ func TestGetAllInfoAboutAllFlat(t *testing.T) {
 		WithRepo(repoMock),
 	)
 
+	override := money.New(2500)
 	repoReturn := []models.Apartment{
 		{
-			FlatNo:       1,
-			OwnerName:    "deneme1",
-			OwnerSurname: "deneme1",
-			Mail:         "deneme1@mail.com",
-			Password:     "123",
-			DuesCount:    1,
+			FlatNo:          1,
+			OwnerName:       "deneme1",
+			OwnerSurname:    "deneme1",
+			Mail:            "deneme1@mail.com",
+			Password:        "123",
+			DuesCoefficient: 1,
 		},
 		{
 			FlatNo:       2,
@@ -237,11 +250,17 @@ func TestGetAllInfoAboutAllFlat(t *testing.T) {
 			OwnerSurname: "deneme2",
 			Mail:         "deneme2@mail.com",
 			Password:     "456",
-			DuesCount:    2,
+			DuesOverride: &override,
 		},
 	}
+	summaries := []models.DuesSummary{
+		{FlatNo: 1, Balance: money.New(4000), OpenDues: 1},
+		{FlatNo: 2, Balance: money.New(8000), OpenDues: 2},
+	}
 
 	repoMock.On("GetAllInfoAboutAllFlats").Return(repoReturn, nil)
+	repoMock.On("GetDuesSummaries").Return(summaries, nil)
+	repoMock.On("GetSetting", models.SettingDuesPrice, mock.Anything).Return(models.Setting{Value: "40.00"}, nil)
 
 	actual, err := service.GetAllInfoAboutAllFlat()
 	assert.NoError(t, err)
@@ -253,8 +272,11 @@ func TestGetAllInfoAboutAllFlat(t *testing.T) {
 		assert.Equal(t, repoApartment.OwnerSurname, actual[i].OwnerSurname)
 		assert.Equal(t, repoApartment.Mail, actual[i].Mail)
 		assert.Equal(t, repoApartment.Password, actual[i].Password)
-		assert.Equal(t, repoApartment.DuesCount, actual[i].DuesCount)
+		assert.Equal(t, summaries[i].OpenDues, actual[i].DuesCount)
+		assert.Equal(t, summaries[i].Balance, actual[i].Balance)
 	}
+	assert.Equal(t, money.New(4000), actual[0].MonthlyDues)
+	assert.Equal(t, money.New(2500), actual[1].MonthlyDues)
 
 	repoMock.AssertExpectations(t)
 }
@@ -267,7 +289,9 @@ func TestAddDues(t *testing.T) {
 
 	flatNo := 1
 
-	repoMock.On("AddDues", flatNo).Return(nil)
+	repoMock.On("GetAllInfoAboutFlat", flatNo).Return(models.Apartment{FlatNo: flatNo, DuesCoefficient: 1}, nil)
+	repoMock.On("GetSetting", models.SettingDuesPrice, mock.Anything).Return(models.Setting{}, dto.ThereIsNoSetting{})
+	repoMock.On("AddDues", flatNo, BillingPeriod(time.Now()), dto.DefaultDuesPrice).Return(nil)
 
 	err := service.AddDues(flatNo)
 	assert.NoError(t, err)
@@ -282,7 +306,9 @@ func TestAddDues_Error(t *testing.T) {
 
 	flatNo := 1
 	expectedError := errors.New("some error")
-	repoMock.On("AddDues", flatNo).Return(expectedError)
+	repoMock.On("GetAllInfoAboutFlat", flatNo).Return(models.Apartment{FlatNo: flatNo, DuesCoefficient: 2}, nil)
+	repoMock.On("GetSetting", models.SettingDuesPrice, mock.Anything).Return(models.Setting{Value: "55.50"}, nil)
+	repoMock.On("AddDues", flatNo, BillingPeriod(time.Now()), money.New(11100)).Return(expectedError)
 
 	err := service.AddDues(flatNo)
 	assert.Equal(t, expectedError, err)
@@ -323,28 +349,47 @@ func TestDeleteDues_Error(t *testing.T) {
 }
 
 func TestChangeDuesPrice(t *testing.T) {
-	service := NewService()
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
 
-	newPrice := 100.0
+	newPrice := money.New(10000)
 
-	service.ChangeDuesPrice(newPrice)
+	repoMock.On("SaveSetting", mock.MatchedBy(func(setting models.Setting) bool {
+		return setting.Key == models.SettingDuesPrice &&
+			setting.Value == "100.00" &&
+			setting.ChangedBy == "admin" &&
+			setting.EffectiveFrom.After(time.Now()) &&
+			setting.EffectiveFrom.Day() == 1
+	})).Return(nil)
 
-	dto.Mutx.Lock()
-	assert.Equal(t, newPrice, dto.DuesPrice)
-	dto.Mutx.Unlock()
+	err := service.ChangeDuesPrice(newPrice, "admin")
+	assert.NoError(t, err)
+	repoMock.AssertExpectations(t)
 }
 
-func TestChangePayDay(t *testing.T) {
+func TestChangeDuesPrice_Invalid(t *testing.T) {
 	service := NewService()
 
+	err := service.ChangeDuesPrice(money.New(-500), "admin")
+	assert.Error(t, err)
+	assert.IsType(t, dto.DuesPriceRangeError{}, err)
+}
+
+func TestChangePayDay(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
 	validPayDay := 15
 
-	err := service.ChangePayDay(validPayDay)
-	assert.NoError(t, err)
+	repoMock.On("SaveSetting", mock.MatchedBy(func(setting models.Setting) bool {
+		return setting.Key == models.SettingPayDay &&
+			setting.Value == "15" &&
+			setting.ChangedBy == "admin"
+	})).Return(nil)
 
-	dto.Mutx.Lock()
-	assert.Equal(t, validPayDay, dto.PayDay)
-	dto.Mutx.Unlock()
+	err := service.ChangePayDay(validPayDay, "admin")
+	assert.NoError(t, err)
+	repoMock.AssertExpectations(t)
 }
 
 func TestChangePayDay_InvalidLow(t *testing.T) {
@@ -352,7 +397,7 @@ func TestChangePayDay_InvalidLow(t *testing.T) {
 
 	invalidPayDay := 0
 
-	err := service.ChangePayDay(invalidPayDay)
+	err := service.ChangePayDay(invalidPayDay, "admin")
 	assert.Error(t, err)
 	assert.IsType(t, dto.PayDayRangeError{}, err)
 }
@@ -362,7 +407,7 @@ func TestChangePayDay_InvalidHigh(t *testing.T) {
 
 	invalidPayDay := 29
 
-	err := service.ChangePayDay(invalidPayDay)
+	err := service.ChangePayDay(invalidPayDay, "admin")
 	assert.Error(t, err)
 	assert.IsType(t, dto.PayDayRangeError{}, err)
 }
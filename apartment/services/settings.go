@@ -0,0 +1,158 @@
+package services
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+)
+
+// PeriodStart returns the first moment of a billing period such as "2026-10".
+func PeriodStart(period string) (time.Time, error) {
+	return time.ParseInLocation("2006-01", period, time.Local)
+}
+
+func nextPeriodStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+}
+
+func (s *service) GetDuesPrice() (DuesPrice, error) {
+	now := time.Now()
+	price, err := s.moneySetting(models.SettingDuesPrice, now, dto.DefaultDuesPrice)
+	if err != nil {
+		return DuesPrice{}, err
+	}
+
+	duesPrice := DuesPrice{Price: price}
+
+	pending, err := s.Repo.GetPendingSetting(models.SettingDuesPrice, now)
+	if err != nil {
+		if _, ok := err.(dto.ThereIsNoSetting); ok {
+			return duesPrice, nil
+		}
+		return DuesPrice{}, err
+	}
+
+	duesPrice.NextPrice, err = money.Parse(pending.Value)
+	if err != nil {
+		return DuesPrice{}, err
+	}
+	duesPrice.NextEffectiveFrom = pending.EffectiveFrom
+
+	return duesPrice, nil
+}
+
+// ChangeDuesPrice stores a new dues price. It applies from the next billing
+// period so that the current month is not billed at two different prices.
+func (s *service) ChangeDuesPrice(price money.Money, changedBy string) error {
+	if !price.IsPositive() {
+		return dto.DuesPriceRangeError{Message: "dues price must be positive"}
+	}
+
+	setting := models.Setting{
+		Key:           models.SettingDuesPrice,
+		Value:         price.String(),
+		ChangedBy:     changedBy,
+		EffectiveFrom: nextPeriodStart(time.Now()),
+	}
+
+	err := s.Repo.SaveSetting(setting)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *service) GetPayDay() (int, error) {
+	payDay, err := s.floatSetting(models.SettingPayDay, time.Now(), dto.DefaultPayDay)
+	if err != nil {
+		return 0, err
+	}
+	return int(payDay), nil
+}
+
+func (s *service) ChangePayDay(payDay int, changedBy string) error {
+	if payDay < 1 || payDay > 28 {
+		return dto.PayDayRangeError{Message: "invalid range"}
+	}
+
+	setting := models.Setting{
+		Key:           models.SettingPayDay,
+		Value:         strconv.Itoa(payDay),
+		ChangedBy:     changedBy,
+		EffectiveFrom: time.Now(),
+	}
+
+	err := s.Repo.SaveSetting(setting)
+	if err != nil {
+		return err
+	}
+
+	err = s.rescheduleAccrual(payDay)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *service) GetSettingHistory(key string) ([]SettingChange, error) {
+	modelHistory, err := s.Repo.GetSettingHistory(key)
+	if err != nil {
+		return []SettingChange{}, err
+	}
+
+	var history []SettingChange
+	for _, modelSetting := range modelHistory {
+		change := SettingChange{}
+		change.ToSettingChangeServiceObject(modelSetting)
+		history = append(history, change)
+	}
+	return history, nil
+}
+
+// duesPriceForPeriod returns the dues price that was in force at the start of
+// the given billing period.
+func (s *service) duesPriceForPeriod(period string) (money.Money, error) {
+	start, err := PeriodStart(period)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return s.moneySetting(models.SettingDuesPrice, start, dto.DefaultDuesPrice)
+}
+
+func (s *service) floatSetting(key string, at time.Time, fallback float64) (float64, error) {
+	setting, err := s.Repo.GetSetting(key, at)
+	if err != nil {
+		if _, ok := err.(dto.ThereIsNoSetting); ok {
+			return fallback, nil
+		}
+		return 0, err
+	}
+	return strconv.ParseFloat(setting.Value, 64)
+}
+
+// boolSetting reads a setting that holds a switch, stored as true or false.
+func (s *service) boolSetting(key string, at time.Time, fallback bool) (bool, error) {
+	setting, err := s.Repo.GetSetting(key, at)
+	if err != nil {
+		if _, ok := err.(dto.ThereIsNoSetting); ok {
+			return fallback, nil
+		}
+		return false, err
+	}
+	return strconv.ParseBool(setting.Value)
+}
+
+// moneySetting reads a setting that holds an amount, stored as a decimal.
+func (s *service) moneySetting(key string, at time.Time, fallback money.Money) (money.Money, error) {
+	setting, err := s.Repo.GetSetting(key, at)
+	if err != nil {
+		if _, ok := err.(dto.ThereIsNoSetting); ok {
+			return fallback, nil
+		}
+		return money.Money{}, err
+	}
+	return money.Parse(setting.Value)
+}
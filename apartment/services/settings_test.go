@@ -0,0 +1,106 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	mocks "github.com/pragmataW/apartment_management/service_mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetDuesPriceDefault(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetSetting", models.SettingDuesPrice, mock.Anything).Return(models.Setting{}, dto.ThereIsNoSetting{})
+	repoMock.On("GetPendingSetting", models.SettingDuesPrice, mock.Anything).Return(models.Setting{}, dto.ThereIsNoSetting{})
+
+	duesPrice, err := service.GetDuesPrice()
+	assert.NoError(t, err)
+	assert.Equal(t, dto.DefaultDuesPrice, duesPrice.Price)
+	assert.True(t, duesPrice.NextEffectiveFrom.IsZero())
+	repoMock.AssertExpectations(t)
+}
+
+func TestGetDuesPriceWithPendingChange(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	effectiveFrom := nextPeriodStart(time.Now())
+	repoMock.On("GetSetting", models.SettingDuesPrice, mock.Anything).Return(models.Setting{Value: "40.00"}, nil)
+	repoMock.On("GetPendingSetting", models.SettingDuesPrice, mock.Anything).Return(models.Setting{Value: "55.00", EffectiveFrom: effectiveFrom}, nil)
+
+	duesPrice, err := service.GetDuesPrice()
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(4000), duesPrice.Price)
+	assert.Equal(t, money.New(5500), duesPrice.NextPrice)
+	assert.Equal(t, effectiveFrom, duesPrice.NextEffectiveFrom)
+}
+
+func TestGetDuesPrice_Error(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	expectedError := errors.New("some error")
+	repoMock.On("GetSetting", models.SettingDuesPrice, mock.Anything).Return(models.Setting{}, expectedError)
+
+	_, err := service.GetDuesPrice()
+	assert.Equal(t, expectedError, err)
+}
+
+func TestGetPayDay(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetSetting", models.SettingPayDay, mock.Anything).Return(models.Setting{Value: "20"}, nil)
+
+	payDay, err := service.GetPayDay()
+	assert.NoError(t, err)
+	assert.Equal(t, 20, payDay)
+}
+
+func TestGetPayDayDefault(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetSetting", models.SettingPayDay, mock.Anything).Return(models.Setting{}, dto.ThereIsNoSetting{})
+
+	payDay, err := service.GetPayDay()
+	assert.NoError(t, err)
+	assert.Equal(t, dto.DefaultPayDay, payDay)
+}
+
+func TestDuesPriceForPeriod(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	periodStart := time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)
+	repoMock.On("GetSetting", models.SettingDuesPrice, periodStart).Return(models.Setting{Value: "42.50"}, nil)
+
+	price, err := service.duesPriceForPeriod("2026-10")
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(4250), price)
+	repoMock.AssertExpectations(t)
+}
+
+func TestGetSettingHistory(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	modelHistory := []models.Setting{
+		{ID: 2, Key: models.SettingPayDay, Value: "20", PreviousValue: "15", ChangedBy: "admin"},
+		{ID: 1, Key: models.SettingPayDay, Value: "15", ChangedBy: "admin"},
+	}
+	repoMock.On("GetSettingHistory", models.SettingPayDay).Return(modelHistory, nil)
+
+	history, err := service.GetSettingHistory(models.SettingPayDay)
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+	assert.Equal(t, "15", history[0].PreviousValue)
+	assert.Equal(t, "admin", history[1].ChangedBy)
+}
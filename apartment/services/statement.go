@@ -0,0 +1,109 @@
+package services
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+)
+
+// GetStatement lists the flat's account movements booked between from and
+// to, both inclusive dates, with a running balance. Everything booked before
+// from is carried in as the opening balance. A zero from starts at the first
+// movement and a zero to runs up to now.
+func (s *service) GetStatement(flatNo int, from time.Time, to time.Time) (Statement, error) {
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return Statement{}, dto.StatementRangeError{Message: "from must not be after to"}
+	}
+
+	flat, err := s.Repo.GetAllInfoAboutFlat(flatNo)
+	if err != nil {
+		return Statement{}, err
+	}
+
+	entries, err := s.Repo.GetLedgerEntries(flatNo)
+	if err != nil {
+		return Statement{}, err
+	}
+
+	end := to.AddDate(0, 0, 1)
+	if to.IsZero() {
+		to = time.Now()
+		end = to
+	}
+
+	statement := Statement{
+		FlatNo:    flatNo,
+		OwnerName: strings.TrimSpace(flat.OwnerName + " " + flat.OwnerSurname),
+		From:      from,
+		To:        to,
+		Lines:     []StatementLine{},
+	}
+
+	balance := money.New(0)
+	for _, entry := range entries {
+		if entry.CreatedAt.Before(from) {
+			statement.OpeningBalance = statement.OpeningBalance.Add(entry.Amount)
+			balance = balance.Add(entry.Amount)
+			continue
+		}
+		if !entry.CreatedAt.Before(end) {
+			continue
+		}
+
+		balance = balance.Add(entry.Amount)
+		line := StatementLine{
+			Date:        entry.CreatedAt,
+			Period:      entry.Period,
+			Type:        entry.Type,
+			Description: statementDescription(entry),
+			Balance:     balance,
+		}
+		if entry.Amount.IsPositive() {
+			line.Debit = entry.Amount
+			statement.TotalDebit = statement.TotalDebit.Add(entry.Amount)
+		} else {
+			line.Credit = entry.Amount.Neg()
+			statement.TotalCredit = statement.TotalCredit.Sub(entry.Amount)
+		}
+		statement.Lines = append(statement.Lines, line)
+	}
+
+	statement.ClosingBalance = balance
+	return statement, nil
+}
+
+func (s *service) GetStatementByEmail(email string, from time.Time, to time.Time) (Statement, error) {
+	flat, err := s.Repo.GetFlatByEmail(email)
+	if err != nil {
+		return Statement{}, err
+	}
+	return s.GetStatement(flat.FlatNo, from, to)
+}
+
+// statementDescription is the note of the entry, or a line made up from its
+// type when it has none.
+func statementDescription(entry models.LedgerEntry) string {
+	if entry.Note != "" {
+		return entry.Note
+	}
+
+	switch entry.Type {
+	case models.LedgerAccrual:
+		return "dues for " + entry.Period
+	case models.LedgerPenalty:
+		return "late fee for " + entry.Period
+	case models.LedgerPayment:
+		if entry.Reference != "" {
+			return "payment " + entry.Reference
+		}
+		return "payment"
+	case models.LedgerWaiver:
+		return "waived dues for " + entry.Period
+	case models.LedgerReversal:
+		return "cancelled payment " + entry.Reference
+	}
+	return entry.Type
+}
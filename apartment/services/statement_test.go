@@ -0,0 +1,80 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pragmataW/apartment_management/dto"
+	"github.com/pragmataW/apartment_management/models"
+	"github.com/pragmataW/apartment_management/pkg/money"
+	mocks "github.com/pragmataW/apartment_management/service_mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func statementEntries() []models.LedgerEntry {
+	return []models.LedgerEntry{
+		{ID: 1, Period: "2026-08", Type: models.LedgerAccrual, Amount: money.New(100000), CreatedAt: time.Date(2026, 8, 1, 9, 0, 0, 0, time.Local)},
+		{ID: 2, Period: "2026-09", Type: models.LedgerAccrual, Amount: money.New(100000), CreatedAt: time.Date(2026, 9, 1, 9, 0, 0, 0, time.Local)},
+		{ID: 3, Period: "2026-08", Type: models.LedgerPayment, Amount: money.New(-150000), Reference: "oid-1", CreatedAt: time.Date(2026, 9, 10, 14, 0, 0, 0, time.Local)},
+		{ID: 4, Period: "2026-09", Type: models.LedgerPenalty, Amount: money.New(2500), Note: "late fee for 2026-09", CreatedAt: time.Date(2026, 9, 30, 23, 0, 0, 0, time.Local)},
+		{ID: 5, Period: "2026-10", Type: models.LedgerAccrual, Amount: money.New(100000), CreatedAt: time.Date(2026, 10, 1, 9, 0, 0, 0, time.Local)},
+	}
+}
+
+func TestGetStatement(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetAllInfoAboutFlat", 4).Return(models.Apartment{FlatNo: 4, OwnerName: "Ayşe", OwnerSurname: "Yılmaz"}, nil)
+	repoMock.On("GetLedgerEntries", 4).Return(statementEntries(), nil)
+
+	statement, err := service.GetStatement(4, time.Date(2026, 9, 1, 0, 0, 0, 0, time.Local), time.Date(2026, 9, 30, 0, 0, 0, 0, time.Local))
+	assert.NoError(t, err)
+	assert.Equal(t, "Ayşe Yılmaz", statement.OwnerName)
+	assert.Equal(t, money.New(100000), statement.OpeningBalance)
+	assert.Len(t, statement.Lines, 3)
+
+	assert.Equal(t, "dues for 2026-09", statement.Lines[0].Description)
+	assert.Equal(t, money.New(200000), statement.Lines[0].Balance)
+	assert.Equal(t, "payment oid-1", statement.Lines[1].Description)
+	assert.Equal(t, money.New(150000), statement.Lines[1].Credit)
+	assert.Equal(t, money.New(50000), statement.Lines[1].Balance)
+	assert.Equal(t, "late fee for 2026-09", statement.Lines[2].Description)
+
+	assert.Equal(t, money.New(102500), statement.TotalDebit)
+	assert.Equal(t, money.New(150000), statement.TotalCredit)
+	assert.Equal(t, money.New(52500), statement.ClosingBalance)
+}
+
+func TestGetStatement_WholeHistory(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetAllInfoAboutFlat", 4).Return(models.Apartment{FlatNo: 4}, nil)
+	repoMock.On("GetLedgerEntries", 4).Return(statementEntries(), nil)
+
+	statement, err := service.GetStatement(4, time.Time{}, time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(0), statement.OpeningBalance)
+	assert.Len(t, statement.Lines, 5)
+	assert.Equal(t, money.New(152500), statement.ClosingBalance)
+	assert.False(t, statement.To.IsZero())
+}
+
+func TestGetStatement_InvalidRange(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	_, err := service.GetStatement(4, time.Date(2026, 9, 1, 0, 0, 0, 0, time.Local), time.Date(2026, 8, 1, 0, 0, 0, 0, time.Local))
+	assert.IsType(t, dto.StatementRangeError{}, err)
+}
+
+func TestGetStatementByEmail(t *testing.T) {
+	repoMock := new(mocks.IRepo)
+	service := NewService(WithRepo(repoMock))
+
+	repoMock.On("GetFlatByEmail", "mail@mail.com").Return(models.Apartment{}, dto.ThereIsNoFlat{Message: "there is no flat"})
+
+	_, err := service.GetStatementByEmail("mail@mail.com", time.Time{}, time.Time{})
+	assert.IsType(t, dto.ThereIsNoFlat{}, err)
+}
@@ -8,7 +8,11 @@ CREATE TABLE apartments (
     owner_surname VARCHAR(255), 
     mail VARCHAR(255) UNIQUE,           
     password VARCHAR(255),
-    dues_count INT
+    flat_type VARCHAR(32) NOT NULL DEFAULT 'residential',
+    area NUMERIC(10,2) NOT NULL DEFAULT 0,
+    share NUMERIC(10,4) NOT NULL DEFAULT 0,
+    dues_coefficient NUMERIC(6,3) NOT NULL DEFAULT 1,
+    dues_override NUMERIC(12,2)
 );
 
 CREATE TABLE announcements (
@@ -17,7 +21,294 @@ CREATE TABLE announcements (
     content TEXT NOT NULL
 );
 
-CREATE TABLE merchants (
-    merchant_id VARCHAR(255) PRIMARY KEY,
-    email TEXT NOT NULL
-);
\ No newline at end of file
+CREATE TABLE payment_transactions (
+    merchant_oid VARCHAR(255) PRIMARY KEY,
+    provider TEXT NOT NULL,
+    flat_no INT NOT NULL,
+    email TEXT NOT NULL,
+    amount NUMERIC(12,2) NOT NULL DEFAULT 0,
+    paid_amount NUMERIC(12,2) NOT NULL DEFAULT 0,
+    status TEXT NOT NULL,
+    fail_reason TEXT,
+    receipt_no INT,
+    entry_id INT,
+    reviewed_by TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    callback_at TIMESTAMP,
+    refunded_amount NUMERIC(12,2) NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_payment_transactions_flat_no ON payment_transactions (flat_no);
+CREATE INDEX idx_payment_transactions_status ON payment_transactions (status);
+
+CREATE TABLE payment_refunds (
+    id SERIAL PRIMARY KEY,
+    merchant_oid VARCHAR(255) NOT NULL,
+    amount NUMERIC(12,2) NOT NULL,
+    reason TEXT,
+    refunded_by TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'done',
+    error TEXT,
+    reversal_entry_id INT,
+    rebook_entry_id INT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_payment_refunds_merchant_oid ON payment_refunds (merchant_oid);
+
+CREATE TABLE ledger_entries (
+    id SERIAL PRIMARY KEY,
+    flat_no INT NOT NULL,
+    period TEXT NOT NULL,
+    type TEXT NOT NULL,
+    amount NUMERIC(12,2) NOT NULL,
+    source TEXT NOT NULL,
+    reference TEXT,
+    note TEXT,
+    charge_id INT,
+    assessment_id INT,
+    utility_bill_id INT,
+    due_date TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_ledger_entries_flat_no ON ledger_entries (flat_no);
+CREATE UNIQUE INDEX idx_ledger_penalty ON ledger_entries (charge_id, period);
+CREATE INDEX idx_ledger_entries_assessment_id ON ledger_entries (assessment_id);
+CREATE INDEX idx_ledger_entries_utility_bill_id ON ledger_entries (utility_bill_id);
+
+CREATE TABLE ledger_allocations (
+    id SERIAL PRIMARY KEY,
+    credit_id INT NOT NULL,
+    charge_id INT NOT NULL,
+    amount NUMERIC(12,2) NOT NULL,
+    created_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_ledger_allocations_credit_id ON ledger_allocations (credit_id);
+CREATE INDEX idx_ledger_allocations_charge_id ON ledger_allocations (charge_id);
+
+CREATE TABLE settings (
+    id SERIAL PRIMARY KEY,
+    key TEXT NOT NULL,
+    value TEXT NOT NULL,
+    previous_value TEXT,
+    changed_by TEXT NOT NULL,
+    changed_at TIMESTAMPTZ,
+    effective_from TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_settings_key ON settings (key);
+
+CREATE TABLE accrual_runs (
+    period TEXT PRIMARY KEY,
+    amount NUMERIC(12,2) NOT NULL,
+    flat_count INT NOT NULL,
+    due_date TIMESTAMPTZ NOT NULL,
+    run_at TIMESTAMPTZ
+);
+
+CREATE TABLE assessments (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT,
+    total NUMERIC(12,2) NOT NULL,
+    split_rule TEXT NOT NULL,
+    installments INT NOT NULL DEFAULT 1,
+    first_due_date TIMESTAMPTZ NOT NULL,
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ
+);
+
+CREATE TABLE assessment_installments (
+    id SERIAL PRIMARY KEY,
+    assessment_id INT NOT NULL,
+    flat_no INT NOT NULL,
+    period TEXT NOT NULL,
+    amount NUMERIC(12,2) NOT NULL,
+    note TEXT,
+    due_date TIMESTAMPTZ NOT NULL,
+    entry_id INT
+);
+
+CREATE INDEX idx_assessment_installments_assessment_id ON assessment_installments (assessment_id);
+CREATE INDEX idx_assessment_installments_due_date ON assessment_installments (due_date);
+
+CREATE TABLE expense_categories (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE
+);
+
+CREATE TABLE vendors (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    tax_no TEXT,
+    phone TEXT,
+    email TEXT
+);
+
+CREATE TABLE expenses (
+    id SERIAL PRIMARY KEY,
+    category_id INT NOT NULL,
+    vendor_id INT,
+    date DATE NOT NULL,
+    amount NUMERIC(12,2) NOT NULL,
+    description TEXT,
+    receipt_file TEXT,
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_expenses_category_id ON expenses (category_id);
+CREATE INDEX idx_expenses_vendor_id ON expenses (vendor_id);
+CREATE INDEX idx_expenses_date ON expenses (date);
+
+CREATE TABLE payment_receipts (
+    id SERIAL PRIMARY KEY,
+    receipt_no INT NOT NULL,
+    flat_no INT NOT NULL,
+    amount NUMERIC(12,2) NOT NULL,
+    method TEXT NOT NULL,
+    paid_at TIMESTAMPTZ NOT NULL,
+    collected_by TEXT NOT NULL,
+    note TEXT,
+    entry_id INT NOT NULL,
+    cancelled_at TIMESTAMPTZ,
+    cancelled_by TEXT,
+    cancel_reason TEXT,
+    cancel_entry_id INT,
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX idx_payment_receipts_receipt_no ON payment_receipts (receipt_no);
+CREATE INDEX idx_payment_receipts_flat_no ON payment_receipts (flat_no);
+
+CREATE TABLE bank_imports (
+    id SERIAL PRIMARY KEY,
+    file_name TEXT NOT NULL,
+    format TEXT NOT NULL,
+    lines INT NOT NULL,
+    matched INT NOT NULL,
+    unmatched INT NOT NULL,
+    duplicates INT NOT NULL,
+    skipped INT NOT NULL,
+    imported_by TEXT NOT NULL,
+    imported_at TIMESTAMPTZ
+);
+
+CREATE TABLE bank_transactions (
+    id SERIAL PRIMARY KEY,
+    import_id INT NOT NULL,
+    bank_ref TEXT NOT NULL,
+    date DATE NOT NULL,
+    amount NUMERIC(12,2) NOT NULL,
+    currency TEXT NOT NULL DEFAULT 'TRY',
+    description TEXT,
+    counterparty TEXT,
+    status TEXT NOT NULL,
+    flat_no INT,
+    rule_id INT,
+    entry_id INT,
+    resolved_by TEXT,
+    resolved_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_bank_transactions_import_id ON bank_transactions (import_id);
+CREATE UNIQUE INDEX idx_bank_transactions_bank_ref ON bank_transactions (bank_ref);
+CREATE INDEX idx_bank_transactions_status ON bank_transactions (status);
+
+CREATE TABLE reference_rules (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    pattern TEXT NOT NULL,
+    flat_no INT,
+    priority INT NOT NULL,
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ
+);
+
+CREATE TABLE meters (
+    id SERIAL PRIMARY KEY,
+    flat_no INT NOT NULL,
+    type TEXT NOT NULL,
+    serial TEXT NOT NULL,
+    unit TEXT NOT NULL,
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_meters_flat_no ON meters (flat_no);
+CREATE UNIQUE INDEX idx_meters_serial ON meters (serial);
+
+CREATE TABLE meter_readings (
+    id SERIAL PRIMARY KEY,
+    meter_id INT NOT NULL,
+    read_at DATE NOT NULL,
+    value NUMERIC(14,3) NOT NULL,
+    source TEXT NOT NULL,
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX idx_meter_readings_day ON meter_readings (meter_id, read_at);
+
+CREATE TABLE utility_bills (
+    id SERIAL PRIMARY KEY,
+    type TEXT NOT NULL,
+    period TEXT NOT NULL,
+    total NUMERIC(12,2) NOT NULL,
+    common_share NUMERIC(5,2) NOT NULL,
+    common_rule TEXT NOT NULL,
+    from_date DATE NOT NULL,
+    to_date DATE NOT NULL,
+    due_date TIMESTAMPTZ NOT NULL,
+    note TEXT,
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX idx_utility_bills_period ON utility_bills (type, period);
+
+CREATE TABLE utility_bill_lines (
+    id SERIAL PRIMARY KEY,
+    bill_id INT NOT NULL,
+    flat_no INT NOT NULL,
+    consumption NUMERIC(14,3) NOT NULL,
+    common_amount NUMERIC(12,2) NOT NULL,
+    consumption_amount NUMERIC(12,2) NOT NULL,
+    amount NUMERIC(12,2) NOT NULL
+);
+
+CREATE INDEX idx_utility_bill_lines_bill_id ON utility_bill_lines (bill_id);
+
+CREATE TABLE dues_reminders (
+    id SERIAL PRIMARY KEY,
+    flat_no INT NOT NULL,
+    mail TEXT NOT NULL,
+    amount NUMERIC(12,2) NOT NULL,
+    status TEXT NOT NULL,
+    error TEXT,
+    sent_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_dues_reminders_flat_no ON dues_reminders (flat_no);
+CREATE INDEX idx_dues_reminders_sent_at ON dues_reminders (sent_at);
+
+CREATE TABLE receipt_mails (
+    id SERIAL PRIMARY KEY,
+    receipt_no INT NOT NULL,
+    mail TEXT NOT NULL,
+    balance NUMERIC(12,2) NOT NULL,
+    status TEXT NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    error TEXT,
+    next_attempt TIMESTAMPTZ NOT NULL,
+    sent_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX idx_receipt_mails_receipt_no ON receipt_mails (receipt_no);
+CREATE INDEX idx_receipt_mails_status ON receipt_mails (status);